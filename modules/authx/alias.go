@@ -14,6 +14,7 @@ const (
 	CodeSpaceAuthX           = types.CodeSpaceAuthX
 	CodeGasPriceTooLow       = types.CodeGasPriceTooLow
 	CodeRefereeChangeTooFast = types.CodeRefereeChangeTooFast
+	CodeMsgNotClosed         = types.CodeMsgNotClosed
 
 	DefaultParamspace       = types.DefaultParamspace
 	DefaultMinGasPriceLimit = types.DefaultMinGasPriceLimit
@@ -23,6 +24,7 @@ var (
 	ErrInvalidMinGasPriceLimit = types.ErrInvalidMinGasPriceLimit
 	ErrGasPriceTooLow          = types.ErrGasPriceTooLow
 	ErrRefereeChangeTooFast    = types.ErrRefereeChangeTooFast
+	ErrMsgNotClosed            = types.ErrMsgNotClosed
 	NewLockedCoin              = types.NewLockedCoin
 	NewSupervisedLockedCoin    = types.NewSupervisedLockedCoin
 	NewParams                  = types.NewParams
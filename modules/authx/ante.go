@@ -15,6 +15,12 @@ type AnteHelper interface {
 	CheckMsg(ctx sdk.Context, msg sdk.Msg, memo string) sdk.Error
 }
 
+// MsgToBeClosed opens something which must be closed by a later msg in the same tx, such as a flash
+// loan which must be repaid. A tx leaving any of them open is rejected.
+type MsgToBeClosed interface {
+	IsClosedBy(msg sdk.Msg) bool
+}
+
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
 // numbers, checks signatures & account numbers, and deducts fees from the first
 // signer.
@@ -71,6 +77,26 @@ func doAdditionalCheck(ctx sdk.Context, tx auth.StdTx, simulate bool,
 			return err
 		}
 	}
+	return checkMsgsClosed(tx.Msgs)
+}
+
+func checkMsgsClosed(msgs []sdk.Msg) sdk.Error {
+	for i, msg := range msgs {
+		opening, ok := msg.(MsgToBeClosed)
+		if !ok {
+			continue
+		}
+		closed := false
+		for _, later := range msgs[i+1:] {
+			if opening.IsClosedBy(later) {
+				closed = true
+				break
+			}
+		}
+		if !closed {
+			return ErrMsgNotClosed(msg.Type())
+		}
+	}
 	return nil
 }
 
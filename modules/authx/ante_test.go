@@ -58,3 +58,38 @@ func TestAdditionalError(t *testing.T) {
 	require.True(t, abort)
 	require.Equal(t, expectedErr.Result(), res)
 }
+
+type testBorrowMsg struct {
+	sdk.Msg
+}
+
+func (m testBorrowMsg) Type() string {
+	return "borrow"
+}
+
+func (m testBorrowMsg) IsClosedBy(msg sdk.Msg) bool {
+	_, ok := msg.(testRepayMsg)
+	return ok
+}
+
+type testRepayMsg struct {
+	sdk.Msg
+}
+
+func TestMsgNotClosed(t *testing.T) {
+	ah := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		return ctx, sdk.Result{}, false
+	}
+	ah2 := authx.WrapAnteHandler(ah, authx.AccountXKeeper{}, testAnteHelper{})
+
+	tx := auth.StdTx{Msgs: []sdk.Msg{testBorrowMsg{}, testRepayMsg{}}}
+	_, res, abort := ah2(sdk.Context{}, tx, true)
+	require.False(t, abort)
+	require.True(t, res.IsOK())
+
+	// the repay must come after the borrow
+	tx = auth.StdTx{Msgs: []sdk.Msg{testRepayMsg{}, testBorrowMsg{}}}
+	_, res, abort = ah2(sdk.Context{}, tx, true)
+	require.True(t, abort)
+	require.Equal(t, authx.CodeMsgNotClosed, res.Code)
+}
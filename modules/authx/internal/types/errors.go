@@ -13,6 +13,7 @@ const (
 	CodeRefereeChangeTooFast    sdk.CodeType = 203
 	CodeRefereeMemoRequired     sdk.CodeType = 204
 	CodeRefereeCanNotBeYourself sdk.CodeType = 205
+	CodeMsgNotClosed            sdk.CodeType = 206
 )
 
 func ErrInvalidMinGasPriceLimit(limit sdk.Dec) sdk.Error {
@@ -33,3 +34,6 @@ func ErrRefereeMemoRequired(referee string) sdk.Error {
 func ErrRefereeCanNotBeYouself(referee string) sdk.Error {
 	return sdk.NewError(CodeSpaceAuthX, CodeRefereeCanNotBeYourself, "referee %s can not be yourself", referee)
 }
+func ErrMsgNotClosed(msgType string) sdk.Error {
+	return sdk.NewError(CodeSpaceAuthX, CodeMsgNotClosed, "%s must be closed by a later msg in the same tx", msgType)
+}
@@ -9,6 +9,9 @@ const (
 	StoreKey      = types.StoreKey
 	ModuleName    = types.ModuleName
 	PoolModuleAcc = types.PoolModuleAcc
+
+	CurveConstantProduct = types.CurveConstantProduct
+	CurveStableSwap      = types.CurveStableSwap
 )
 
 var (
@@ -23,4 +26,28 @@ type (
 	MsgRemoveLiquidity = types.MsgRemoveLiquidity
 	MsgCreateOrder     = types.MsgAutoSwapCreateOrder
 	MsgCancelOrder     = types.MsgAutoSwapCancelOrder
+	MsgSwapExactIn     = types.MsgSwapExactIn
+	MsgSwapExactOut    = types.MsgSwapExactOut
+
+	MsgTransferLiquidity = types.MsgTransferLiquidity
+
+	MsgAutoSwapCreateTradingPair = types.MsgAutoSwapCreateTradingPair
+	MsgAddRangeLiquidity         = types.MsgAddRangeLiquidity
+	MsgRemoveRangeLiquidity      = types.MsgRemoveRangeLiquidity
+	RangePosition                = keepers.RangePosition
+	RangeLiquidityPosition       = keepers.RangeLiquidityPosition
+	MsgFundRewardProgram         = types.MsgFundRewardProgram
+	MsgClaimRewards              = types.MsgClaimRewards
+	RewardProgram                = keepers.RewardProgram
+	LiquidityReward              = keepers.LiquidityReward
+	PoolReward                   = keepers.PoolReward
+	MsgFlashBorrow               = types.MsgFlashBorrow
+	MsgFlashRepay                = types.MsgFlashRepay
+	FlashLoan                    = keepers.FlashLoan
+
+	QuerySwapQuoteParam    = keepers.QuerySwapQuoteParam
+	QuerySwapQuoteResponse = keepers.QuerySwapQuoteResponse
+	QueryLiquidityParam    = keepers.QueryLiquidityParam
+	LiquidityPosition      = keepers.LiquidityPosition
+	PoolReserves           = keepers.PoolReserves
 )
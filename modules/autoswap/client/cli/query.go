@@ -1,10 +1,19 @@
 package cli
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	"github.com/coinexchain/cosmos-utils/client/cliutil"
 
 	"github.com/coinexchain/cet-sdk/modules/market"
 	mktcli "github.com/coinexchain/cet-sdk/modules/market/client/cli"
@@ -14,6 +23,13 @@ import (
 func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 	mktCmd := getMarketQueryCmd(cdc)
 	// TODO: remove unsupported commands
+	mktCmd.AddCommand(client.GetCommands(
+		QuerySwapQuoteCmd(cdc),
+		QueryLiquidityCmd(cdc),
+		QueryRangePositionsCmd(cdc),
+		QueryRewardsCmd(cdc),
+		QueryPoolReservesCmd(cdc),
+	)...)
 	return mktCmd
 }
 
@@ -26,9 +42,189 @@ func getMarketQueryCmd(cdc *codec.Codec) *cobra.Command {
 		mktcli.QueryParamsCmd(cdc),
 		mktcli.QueryMarketCmd(cdc),
 		mktcli.QueryMarketListCmd(cdc),
-		mktcli.QueryOrderbookCmd(cdc),
+		QueryOrderbookCmd(cdc),
+		mktcli.QueryDepthCmd(cdc),
 		mktcli.QueryOrderCmd(cdc),
-		mktcli.QueryUserOrderList(cdc),
+		QueryUserOrderListCmd(cdc),
 	)...)
 	return mktTxCmd
 }
+
+const (
+	flagExactOut = "exact-out"
+	flagPage     = "page"
+	flagLimit    = "limit"
+)
+
+func addPageFlags(cmd *cobra.Command) {
+	cmd.Flags().Int(flagPage, 1, "the page to query, starting from 1")
+	cmd.Flags().Int(flagLimit, 0, "the max number of results in a page, all the results are returned if zero")
+}
+
+func QueryOrderbookCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orderbook [pair]",
+		Short: "query the orders in a market",
+		Long: `query the orders in a market. 
+
+Example : 
+	cetcli query market orderbook eth/cet \
+	--page=1 --limit=100 --trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(strings.Split(args[0], market.SymbolSeparator)) != 2 {
+				return fmt.Errorf("trading-pair illegal : %s, For example : eth/cet", args[0])
+			}
+			query := fmt.Sprintf("custom/%s/%s", market.StoreKey, market.QueryOrdersInMarket)
+			param := keepers.QueryOrdersInMarketParam{
+				TradingPair: args[0],
+				Page:        viper.GetInt(flagPage),
+				Limit:       viper.GetInt(flagLimit),
+			}
+			return cliutil.CliQuery(cdc, query, param)
+		},
+	}
+	addPageFlags(cmd)
+	return cmd
+}
+
+func QueryUserOrderListCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "order-list [userAddress]",
+		Short: "Query user order list in blockchain",
+		Long: `Query user order list in blockchain. 
+
+Example:
+	cetcli query market order-list [userAddress] \
+	--page=1 --limit=100 --trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return err
+			}
+			query := fmt.Sprintf("custom/%s/%s", market.StoreKey, market.QueryUserOrders)
+			param := keepers.QueryUserOrderListParam{
+				User:  args[0],
+				Page:  viper.GetInt(flagPage),
+				Limit: viper.GetInt(flagLimit),
+			}
+			return cliutil.CliQuery(cdc, query, param)
+		},
+	}
+	addPageFlags(cmd)
+	return cmd
+}
+
+func QueryLiquidityCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "liquidity [owner]",
+		Short: "query the liquidity positions of an owner",
+		Long: `query the liquidity of an owner in every pool, with the reserves it can be removed for.
+The positions in the pools with range liquidity are queried by range-positions.
+
+Example : 
+	cetcli query market liquidity coinex1px8alypku5j84qlwzdpynhn4nyrkagaytu5u4a \
+	--trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			owner, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf("custom/%s/%s", market.StoreKey, types.QueryLiquidity)
+			return cliutil.CliQuery(cdc, query, keepers.QueryLiquidityParam{Owner: owner})
+		},
+	}
+}
+
+func QueryRangePositionsCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "range-positions [owner]",
+		Short: "query the range liquidity positions of an owner",
+		Long: `query the liquidity of an owner within every price range, with the amounts it can be removed for.
+
+Example : 
+	cetcli query market range-positions coinex1px8alypku5j84qlwzdpynhn4nyrkagaytu5u4a \
+	--trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			owner, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf("custom/%s/%s", market.StoreKey, types.QueryRangePositions)
+			return cliutil.CliQuery(cdc, query, keepers.QueryLiquidityParam{Owner: owner})
+		},
+	}
+}
+
+func QueryRewardsCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewards [owner]",
+		Short: "query the reward programs of the pools, and the rewards an owner can claim",
+		Long: `query the reward programs of all the pools, with the rewards the owner can claim from each of them.
+
+Example : 
+	cetcli query market rewards coinex1px8alypku5j84qlwzdpynhn4nyrkagaytu5u4a \
+	--trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			owner, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf("custom/%s/%s", market.StoreKey, types.QueryRewards)
+			return cliutil.CliQuery(cdc, query, keepers.QueryLiquidityParam{Owner: owner})
+		},
+	}
+}
+
+func QueryPoolReservesCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pool-reserves [pair]",
+		Short: "query the AMM and order book reserves of a pool",
+		Long: `query the AMM and order book reserves of a pool.
+
+Example : 
+	cetcli query market pool-reserves eth/cet \
+	--trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(strings.Split(args[0], market.SymbolSeparator)) != 2 {
+				return fmt.Errorf("trading-pair illegal : %s, For example : eth/cet", args[0])
+			}
+			query := fmt.Sprintf("custom/%s/%s", market.StoreKey, types.QueryPoolReserves)
+			return cliutil.CliQuery(cdc, query, market.QueryMarketParam{TradingPair: args[0]})
+		},
+	}
+}
+
+func QuerySwapQuoteCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "swap-quote [path] [amount]",
+		Short: "query the amounts of a swap through a path of pools without executing it",
+		Long: `query the amounts of every token in path when swapping through the pools of
+every two adjacent tokens. The amount is of the first token in path, or of the last
+token in path if --exact-out is set.
+
+Example : 
+	cetcli query market swap-quote foo,cet,bar 100000000 \
+	--trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			amount, ok := sdk.NewIntFromString(args[1])
+			if !ok {
+				return fmt.Errorf("amount must be a valid integer number: %s", args[1])
+			}
+			query := fmt.Sprintf("custom/%s/%s", market.StoreKey, types.QuerySwapQuote)
+			param := keepers.QuerySwapQuoteParam{
+				Path:     strings.Split(args[0], ","),
+				Amount:   amount,
+				ExactOut: viper.GetBool(flagExactOut),
+			}
+			return cliutil.CliQuery(cdc, query, param)
+		},
+	}
+	cmd.Flags().Bool(flagExactOut, false, "quote the amount in for an exact amount of the last token")
+	return cmd
+}
@@ -4,6 +4,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
+	"github.com/coinexchain/cosmos-utils/client/cliutil"
 )
 
 func TestQueryParams(t *testing.T) {
@@ -17,3 +22,43 @@ func TestQueryParams(t *testing.T) {
 	assert.Equal(t, "custom/market/parameters", resultPath)
 	assert.Equal(t, nil, resultParam)
 }
+
+func TestQuerySwapQuote(t *testing.T) {
+	cmd := GetQueryCmd(nil)
+	args := []string{
+		"swap-quote",
+		"foo,cet,bar",
+		"12345",
+		"--exact-out",
+	}
+	cmd.SetArgs(args)
+	cliutil.SetViperWithArgs(args)
+	err := cmd.Execute()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "custom/market/swap-quote", resultPath)
+	assert.Equal(t, keepers.QuerySwapQuoteParam{
+		Path:     []string{"foo", "cet", "bar"},
+		Amount:   sdk.NewInt(12345),
+		ExactOut: true,
+	}, resultParam)
+}
+
+func TestQueryOrderbook(t *testing.T) {
+	cmd := GetQueryCmd(nil)
+	args := []string{
+		"orderbook",
+		"foo/bar",
+		"--page=2",
+		"--limit=10",
+	}
+	cmd.SetArgs(args)
+	cliutil.SetViperWithArgs(args)
+	err := cmd.Execute()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "custom/market/orders-in-market", resultPath)
+	assert.Equal(t, keepers.QueryOrdersInMarketParam{
+		TradingPair: "foo/bar",
+		Page:        2,
+		Limit:       10,
+	}, resultParam)
+}
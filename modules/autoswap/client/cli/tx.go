@@ -24,6 +24,26 @@ const (
 	flagMoneyIn = "money-in"
 	flagTo      = "to"
 	flagAmount  = "amount"
+
+	flagPath         = "path"
+	flagAmountIn     = "amount-in"
+	flagAmountOut    = "amount-out"
+	flagMinAmountOut = "min-amount-out"
+	flagMaxAmountIn  = "max-amount-in"
+	flagDeadline     = "deadline"
+	flagMinStockIn   = "min-stock-in"
+	flagMinMoneyIn   = "min-money-in"
+	flagMinStockOut  = "min-stock-out"
+	flagMinMoneyOut  = "min-money-out"
+
+	flagPricePrecision = "price-precision"
+	flagInitPrice      = "init-price"
+	flagLowerPrice     = "lower-price"
+	flagUpperPrice     = "upper-price"
+	flagLiquidity      = "liquidity"
+	flagAmplification  = "amplification"
+	flagDenom          = "denom"
+	flagRewardPerBlock = "reward-per-block"
 )
 
 // get the root tx command of this module
@@ -34,6 +54,15 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	txCmd.AddCommand(client.PostCommands(
 		GetAddLiquidityCmd(cdc),
 		GetRemoveLiquidityCmd(cdc),
+		GetTransferLiquidityCmd(cdc),
+		GetSwapExactInCmd(cdc),
+		GetSwapExactOutCmd(cdc),
+		GetCreateRangePairCmd(cdc),
+		GetCreateStablePairCmd(cdc),
+		GetAddRangeLiquidityCmd(cdc),
+		GetRemoveRangeLiquidityCmd(cdc),
+		GetFundRewardProgramCmd(cdc),
+		GetClaimRewardsCmd(cdc),
 	)...)
 
 	return txCmd
@@ -67,6 +96,7 @@ func GetAddLiquidityCmd(cdc *codec.Codec) *cobra.Command {
 Example:
 $ cetcli tx market add-liquidity --stock="foo" --money="bar" \
 	--stock-in=100000000 --money-in=100000000 \
+	--min-stock-in=99000000 --min-money-in=99000000 --deadline=12345 \
 	--to=coinex1px8alypku5j84qlwzdpynhn4nyrkagaytu5u4a \
 	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
 `),
@@ -82,6 +112,9 @@ $ cetcli tx market add-liquidity --stock="foo" --money="bar" \
 	addBasicPairFlags(cmd)
 	cmd.Flags().String(flagStockIn, "", "the amount of stock to put into the pool")
 	cmd.Flags().String(flagMoneyIn, "", "the amount of money to put into the pool")
+	cmd.Flags().String(flagMinStockIn, "", "the minimum amount of stock actually put into the pool")
+	cmd.Flags().String(flagMinMoneyIn, "", "the minimum amount of money actually put into the pool")
+	cmd.Flags().Int64(flagDeadline, 0, "the last height this tx can be executed at, no limit if zero")
 	cmd.Flags().String(flagTo, "", "mint to")
 	_ = markRequiredFlags(cmd, flagStock, flagMoney,
 		flagStockIn, flagMoneyIn, flagTo)
@@ -98,7 +131,7 @@ func GetRemoveLiquidityCmd(cdc *codec.Codec) *cobra.Command {
 
 Example:
 $ cetcli tx market remove-liquidity --stock="foo" --money="bar" \
-	--amount=12345 \
+	--amount=12345 --min-stock-out=100 --min-money-out=100 --deadline=12345 \
 	--to=coinex1px8alypku5j84qlwzdpynhn4nyrkagaytu5u4a \
 	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
 `),
@@ -113,21 +146,316 @@ $ cetcli tx market remove-liquidity --stock="foo" --money="bar" \
 
 	addBasicPairFlags(cmd)
 	cmd.Flags().String(flagAmount, "", "the amount of liquidity to be removed")
+	cmd.Flags().String(flagMinStockOut, "", "the minimum amount of stock taken out of the pool")
+	cmd.Flags().String(flagMinMoneyOut, "", "the minimum amount of money taken out of the pool")
+	cmd.Flags().Int64(flagDeadline, 0, "the last height this tx can be executed at, no limit if zero")
 	cmd.Flags().String(flagTo, "", "mint to")
 	_ = markRequiredFlags(cmd, flagStock, flagMoney, flagAmount, flagTo)
 
 	return cmd
 }
 
+func GetTransferLiquidityCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-liquidity",
+		Short: "generate tx to transfer liquidity of trading pair to another account",
+		Long: strings.TrimSpace(
+			`generate a tx and sign it to transfer liquidity of trading pair to another account in Dex blockchain. 
+
+Example:
+$ cetcli tx market transfer-liquidity --stock="foo" --money="bar" \
+	--amount=12345 \
+	--to=coinex1px8alypku5j84qlwzdpynhn4nyrkagaytu5u4a \
+	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := getTransferLiquidityMsg()
+			if err != nil {
+				return err
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+
+	addBasicPairFlags(cmd)
+	cmd.Flags().String(flagAmount, "", "the amount of liquidity to be transferred")
+	cmd.Flags().String(flagTo, "", "transfer to")
+	_ = markRequiredFlags(cmd, flagStock, flagMoney, flagAmount, flagTo)
+
+	return cmd
+}
+
+func GetSwapExactInCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "swap-exact-in",
+		Short: "generate tx to swap an exact amount of token through a path of pools",
+		Long: strings.TrimSpace(
+			`generate a tx and sign it to swap an exact amount of the first token in path
+for the last token in path, through the pools of every two adjacent tokens. 
+
+Example:
+$ cetcli tx market swap-exact-in --path="foo,cet,bar" \
+	--amount-in=100000000 --min-amount-out=90000000 --deadline=12345 \
+	--to=coinex1px8alypku5j84qlwzdpynhn4nyrkagaytu5u4a \
+	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := getSwapExactInMsg()
+			if err != nil {
+				return err
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+
+	cmd.Flags().String(flagPath, "", "the tokens to swap through, separated by comma")
+	cmd.Flags().String(flagAmountIn, "", "the amount of the first token to swap")
+	cmd.Flags().String(flagMinAmountOut, "", "the minimum amount of the last token to get")
+	cmd.Flags().Int64(flagDeadline, 0, "the last height this tx can be executed at")
+	cmd.Flags().String(flagTo, "", "the receiver of the last token")
+	_ = markRequiredFlags(cmd, flagPath, flagAmountIn, flagMinAmountOut, flagDeadline, flagTo)
+
+	return cmd
+}
+
+func GetSwapExactOutCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "swap-exact-out",
+		Short: "generate tx to swap for an exact amount of token through a path of pools",
+		Long: strings.TrimSpace(
+			`generate a tx and sign it to swap the first token in path for an exact amount
+of the last token in path, through the pools of every two adjacent tokens. 
+
+Example:
+$ cetcli tx market swap-exact-out --path="foo,cet,bar" \
+	--amount-out=100000000 --max-amount-in=110000000 --deadline=12345 \
+	--to=coinex1px8alypku5j84qlwzdpynhn4nyrkagaytu5u4a \
+	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := getSwapExactOutMsg()
+			if err != nil {
+				return err
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+
+	cmd.Flags().String(flagPath, "", "the tokens to swap through, separated by comma")
+	cmd.Flags().String(flagAmountOut, "", "the amount of the last token to get")
+	cmd.Flags().String(flagMaxAmountIn, "", "the maximum amount of the first token to pay")
+	cmd.Flags().Int64(flagDeadline, 0, "the last height this tx can be executed at")
+	cmd.Flags().String(flagTo, "", "the receiver of the last token")
+	_ = markRequiredFlags(cmd, flagPath, flagAmountOut, flagMaxAmountIn, flagDeadline, flagTo)
+
+	return cmd
+}
+
+func GetCreateRangePairCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-range-pair",
+		Short: "generate tx to create a trading pair whose liquidity is provided within price ranges",
+		Long: strings.TrimSpace(
+			`generate a tx and sign it to create a trading pair in Dex blockchain, whose pool
+takes liquidity within price ranges instead of over all prices. 
+
+Example:
+$ cetcli tx market create-range-pair --stock="foo" --money="bar" \
+	--price-precision=8 --init-price=1.5 \
+	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := getCreateRangePairMsg()
+			if err != nil {
+				return err
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+
+	addBasicPairFlags(cmd)
+	cmd.Flags().Uint8(flagPricePrecision, 0, "the price precision of the trading pair")
+	cmd.Flags().String(flagInitPrice, "", "the price of the pool before any liquidity is added")
+	_ = markRequiredFlags(cmd, flagStock, flagMoney, flagPricePrecision, flagInitPrice)
+
+	return cmd
+}
+
+func GetCreateStablePairCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-stable-pair",
+		Short: "generate tx to create a trading pair of pegged assets on the StableSwap curve",
+		Long: strings.TrimSpace(
+			`generate a tx and sign it to create a trading pair in Dex blockchain, whose pool
+trades on the StableSwap curve instead of x*y=k. A larger amplification gives more depth
+around the price of 1. 
+
+Example:
+$ cetcli tx market create-stable-pair --stock="usdx" --money="usdy" \
+	--price-precision=8 --amplification=100 \
+	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := &types.MsgAutoSwapCreateTradingPair{
+				Stock:          viper.GetString(flagStock),
+				Money:          viper.GetString(flagMoney),
+				PricePrecision: byte(viper.GetUint(flagPricePrecision)),
+				CurveType:      types.CurveStableSwap,
+				Amplification:  viper.GetInt64(flagAmplification),
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+
+	addBasicPairFlags(cmd)
+	cmd.Flags().Uint8(flagPricePrecision, 0, "the price precision of the trading pair")
+	cmd.Flags().Int64(flagAmplification, 0, "the amplification of the StableSwap curve")
+	_ = markRequiredFlags(cmd, flagStock, flagMoney, flagPricePrecision, flagAmplification)
+
+	return cmd
+}
+
+func GetAddRangeLiquidityCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-range-liquidity",
+		Short: "generate tx to add liquidity within a price range into trading pair",
+		Long: strings.TrimSpace(
+			`generate a tx and sign it to add liquidity within [lower-price, upper-price) into
+a trading pair with range liquidity in Dex blockchain. At most stock-in and money-in are taken. 
+
+Example:
+$ cetcli tx market add-range-liquidity --stock="foo" --money="bar" \
+	--lower-price=1.2 --upper-price=1.8 \
+	--stock-in=100000000 --money-in=100000000 \
+	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := getAddRangeLiquidityMsg()
+			if err != nil {
+				return err
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+
+	addBasicPairFlags(cmd)
+	addPriceRangeFlags(cmd)
+	cmd.Flags().String(flagStockIn, "", "the maximum amount of stock to put into the pool")
+	cmd.Flags().String(flagMoneyIn, "", "the maximum amount of money to put into the pool")
+	_ = markRequiredFlags(cmd, flagStock, flagMoney, flagLowerPrice, flagUpperPrice,
+		flagStockIn, flagMoneyIn)
+
+	return cmd
+}
+
+func GetRemoveRangeLiquidityCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-range-liquidity",
+		Short: "generate tx to remove liquidity within a price range from trading pair",
+		Long: strings.TrimSpace(
+			`generate a tx and sign it to remove liquidity within [lower-price, upper-price) from
+a trading pair with range liquidity in Dex blockchain, together with the fees it earned. 
+
+Example:
+$ cetcli tx market remove-range-liquidity --stock="foo" --money="bar" \
+	--lower-price=1.2 --upper-price=1.8 --liquidity=12345 \
+	--to=coinex1px8alypku5j84qlwzdpynhn4nyrkagaytu5u4a \
+	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := getRemoveRangeLiquidityMsg()
+			if err != nil {
+				return err
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+
+	addBasicPairFlags(cmd)
+	addPriceRangeFlags(cmd)
+	cmd.Flags().String(flagLiquidity, "", "the amount of liquidity to be removed, only the fees are taken if zero")
+	cmd.Flags().String(flagTo, "", "the receiver of the tokens, the sender if empty")
+	_ = markRequiredFlags(cmd, flagStock, flagMoney, flagLowerPrice, flagUpperPrice, flagLiquidity)
+
+	return cmd
+}
+
+func GetFundRewardProgramCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fund-reward-program",
+		Short: "generate tx to fund the reward program of a trading pair",
+		Long: strings.TrimSpace(
+			`generate a tx and sign it to fund the reward program of a trading pair in Dex blockchain,
+which pays reward-per-block to the liquidity providers of the pool pro rata till the funded amount
+runs out. The reward per block is set when the program is created or has run out. 
+
+Example:
+$ cetcli tx market fund-reward-program --stock="foo" --money="bar" \
+	--denom=cet --amount=100000000 --reward-per-block=1000 \
+	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := getFundRewardProgramMsg()
+			if err != nil {
+				return err
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+
+	addBasicPairFlags(cmd)
+	cmd.Flags().String(flagDenom, "", "the token the rewards are paid in")
+	cmd.Flags().String(flagAmount, "", "the amount to fund the program with")
+	cmd.Flags().String(flagRewardPerBlock, "", "the rewards paid per block, unchanged if empty")
+	_ = markRequiredFlags(cmd, flagStock, flagMoney, flagDenom, flagAmount)
+
+	return cmd
+}
+
+func GetClaimRewardsCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-rewards",
+		Short: "generate tx to claim the liquidity rewards of a trading pair",
+		Long: strings.TrimSpace(
+			`generate a tx and sign it to claim the rewards earned from the reward program of
+a trading pair in Dex blockchain. 
+
+Example:
+$ cetcli tx market claim-rewards --stock="foo" --money="bar" \
+	--to=coinex1px8alypku5j84qlwzdpynhn4nyrkagaytu5u4a \
+	--from=bob --chain-id=coinexdex --gas=1000000 --fees=1000cet
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := getClaimRewardsMsg()
+			if err != nil {
+				return err
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+
+	addBasicPairFlags(cmd)
+	cmd.Flags().String(flagTo, "", "the receiver of the rewards, the sender if empty")
+	_ = markRequiredFlags(cmd, flagStock, flagMoney)
+
+	return cmd
+}
+
 func addBasicPairFlags(cmd *cobra.Command) {
 	cmd.Flags().String(flagStock, "", "the stock symbol of the pool")
 	cmd.Flags().String(flagMoney, "", "the money symbol of the pool")
 }
 
+func addPriceRangeFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagLowerPrice, "", "the lowest price of the range")
+	cmd.Flags().String(flagUpperPrice, "", "the price above the range")
+}
+
 func getAddLiquidityMsg() (msg *types.MsgAddLiquidity, err error) {
 	msg = &types.MsgAddLiquidity{
-		Stock: viper.GetString(flagStock),
-		Money: viper.GetString(flagMoney),
+		Stock:    viper.GetString(flagStock),
+		Money:    viper.GetString(flagMoney),
+		Deadline: viper.GetInt64(flagDeadline),
 	}
 
 	if msg.StockIn, err = parseSdkInt(flagStockIn); err != nil {
@@ -136,6 +464,12 @@ func getAddLiquidityMsg() (msg *types.MsgAddLiquidity, err error) {
 	if msg.MoneyIn, err = parseSdkInt(flagMoneyIn); err != nil {
 		return
 	}
+	if msg.MinStockIn, err = parseOptionalSdkInt(flagMinStockIn); err != nil {
+		return
+	}
+	if msg.MinMoneyIn, err = parseOptionalSdkInt(flagMinMoneyIn); err != nil {
+		return
+	}
 	if msg.To, err = sdk.AccAddressFromBech32(viper.GetString(flagTo)); err != nil {
 		return
 	}
@@ -144,6 +478,27 @@ func getAddLiquidityMsg() (msg *types.MsgAddLiquidity, err error) {
 
 func getRemoveLiquidityMsg() (msg *types.MsgRemoveLiquidity, err error) {
 	msg = &types.MsgRemoveLiquidity{
+		Stock:    viper.GetString(flagStock),
+		Money:    viper.GetString(flagMoney),
+		Deadline: viper.GetInt64(flagDeadline),
+	}
+	if msg.Amount, err = parseSdkInt(flagAmount); err != nil {
+		return
+	}
+	if msg.MinStockOut, err = parseOptionalSdkInt(flagMinStockOut); err != nil {
+		return
+	}
+	if msg.MinMoneyOut, err = parseOptionalSdkInt(flagMinMoneyOut); err != nil {
+		return
+	}
+	if msg.To, err = sdk.AccAddressFromBech32(viper.GetString(flagTo)); err != nil {
+		return
+	}
+	return
+}
+
+func getTransferLiquidityMsg() (msg *types.MsgTransferLiquidity, err error) {
+	msg = &types.MsgTransferLiquidity{
 		Stock: viper.GetString(flagStock),
 		Money: viper.GetString(flagMoney),
 	}
@@ -156,6 +511,117 @@ func getRemoveLiquidityMsg() (msg *types.MsgRemoveLiquidity, err error) {
 	return
 }
 
+func getSwapExactInMsg() (msg *types.MsgSwapExactIn, err error) {
+	msg = &types.MsgSwapExactIn{
+		Path:     strings.Split(viper.GetString(flagPath), ","),
+		Deadline: viper.GetInt64(flagDeadline),
+	}
+	if msg.AmountIn, err = parseSdkInt(flagAmountIn); err != nil {
+		return
+	}
+	if msg.MinAmountOut, err = parseSdkInt(flagMinAmountOut); err != nil {
+		return
+	}
+	if msg.To, err = sdk.AccAddressFromBech32(viper.GetString(flagTo)); err != nil {
+		return
+	}
+	return
+}
+
+func getSwapExactOutMsg() (msg *types.MsgSwapExactOut, err error) {
+	msg = &types.MsgSwapExactOut{
+		Path:     strings.Split(viper.GetString(flagPath), ","),
+		Deadline: viper.GetInt64(flagDeadline),
+	}
+	if msg.AmountOut, err = parseSdkInt(flagAmountOut); err != nil {
+		return
+	}
+	if msg.MaxAmountIn, err = parseSdkInt(flagMaxAmountIn); err != nil {
+		return
+	}
+	if msg.To, err = sdk.AccAddressFromBech32(viper.GetString(flagTo)); err != nil {
+		return
+	}
+	return
+}
+
+func getCreateRangePairMsg() (msg *types.MsgAutoSwapCreateTradingPair, err error) {
+	msg = &types.MsgAutoSwapCreateTradingPair{
+		Stock:          viper.GetString(flagStock),
+		Money:          viper.GetString(flagMoney),
+		PricePrecision: byte(viper.GetUint(flagPricePrecision)),
+		RangeLiquidity: true,
+	}
+	if msg.InitPrice, err = sdk.NewDecFromStr(viper.GetString(flagInitPrice)); err != nil {
+		return
+	}
+	return
+}
+
+func getAddRangeLiquidityMsg() (msg *types.MsgAddRangeLiquidity, err error) {
+	msg = &types.MsgAddRangeLiquidity{
+		Stock: viper.GetString(flagStock),
+		Money: viper.GetString(flagMoney),
+	}
+	if msg.LowerPrice, msg.UpperPrice, err = parsePriceRange(); err != nil {
+		return
+	}
+	if msg.StockIn, err = parseSdkInt(flagStockIn); err != nil {
+		return
+	}
+	if msg.MoneyIn, err = parseSdkInt(flagMoneyIn); err != nil {
+		return
+	}
+	return
+}
+
+func getRemoveRangeLiquidityMsg() (msg *types.MsgRemoveRangeLiquidity, err error) {
+	msg = &types.MsgRemoveRangeLiquidity{
+		Stock: viper.GetString(flagStock),
+		Money: viper.GetString(flagMoney),
+	}
+	if msg.LowerPrice, msg.UpperPrice, err = parsePriceRange(); err != nil {
+		return
+	}
+	if msg.Liquidity, err = parseSdkInt(flagLiquidity); err != nil {
+		return
+	}
+	if to := viper.GetString(flagTo); len(to) != 0 {
+		if msg.To, err = sdk.AccAddressFromBech32(to); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func getFundRewardProgramMsg() (msg *types.MsgFundRewardProgram, err error) {
+	msg = &types.MsgFundRewardProgram{
+		Stock: viper.GetString(flagStock),
+		Money: viper.GetString(flagMoney),
+		Denom: viper.GetString(flagDenom),
+	}
+	if msg.Amount, err = parseSdkInt(flagAmount); err != nil {
+		return
+	}
+	if msg.RewardPerBlock, err = parseOptionalSdkInt(flagRewardPerBlock); err != nil {
+		return
+	}
+	return
+}
+
+func getClaimRewardsMsg() (msg *types.MsgClaimRewards, err error) {
+	msg = &types.MsgClaimRewards{
+		Stock: viper.GetString(flagStock),
+		Money: viper.GetString(flagMoney),
+	}
+	if to := viper.GetString(flagTo); len(to) != 0 {
+		if msg.To, err = sdk.AccAddressFromBech32(to); err != nil {
+			return
+		}
+	}
+	return
+}
+
 func markRequiredFlags(cmd *cobra.Command, flagNames ...string) error {
 	for _, flagName := range flagNames {
 		if err := cmd.MarkFlagRequired(flagName); err != nil {
@@ -173,3 +639,19 @@ func parseSdkInt(flagName string) (val sdk.Int, err error) {
 	}
 	return
 }
+
+// an empty flag is taken as zero
+func parseOptionalSdkInt(flagName string) (sdk.Int, error) {
+	if len(viper.GetString(flagName)) == 0 {
+		return sdk.ZeroInt(), nil
+	}
+	return parseSdkInt(flagName)
+}
+
+func parsePriceRange() (lower, upper sdk.Dec, err error) {
+	if lower, err = sdk.NewDecFromStr(viper.GetString(flagLowerPrice)); err != nil {
+		return
+	}
+	upper, err = sdk.NewDecFromStr(viper.GetString(flagUpperPrice))
+	return
+}
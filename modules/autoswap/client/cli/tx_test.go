@@ -19,6 +19,8 @@ func TestAddLiquidityCmd(t *testing.T) {
 		"--money=bar",
 		"--stock-in=100000000",
 		"--money-in=200000000",
+		"--min-stock-in=99000000",
+		"--deadline=100",
 		"--to=" + fromAddr.String(),
 		"--from=" + fromAddr.String(),
 		"--generate-only",
@@ -34,6 +36,10 @@ func TestAddLiquidityCmd(t *testing.T) {
 		Money:   "bar",
 		StockIn: sdk.NewInt(100000000),
 		MoneyIn: sdk.NewInt(200000000),
+
+		MinStockIn: sdk.NewInt(99000000),
+		MinMoneyIn: sdk.ZeroInt(),
+		Deadline:   100,
 	}, resultMsg)
 }
 
@@ -58,5 +64,204 @@ func TestRemoveLiquidityCmd(t *testing.T) {
 		Stock:  "foo",
 		Money:  "bar",
 		Amount: sdk.NewInt(12345),
+
+		MinStockOut: sdk.ZeroInt(),
+		MinMoneyOut: sdk.ZeroInt(),
+	}, resultMsg)
+}
+
+func TestSwapExactInCmd(t *testing.T) {
+	txCmd := GetTxCmd(nil)
+	args := []string{
+		"swap-exact-in",
+		"--path=foo,cet,bar",
+		"--amount-in=100000000",
+		"--min-amount-out=90000000",
+		"--deadline=12345",
+		"--to=" + fromAddr.String(),
+		"--from=" + fromAddr.String(),
+		"--generate-only",
+	}
+	txCmd.SetArgs(args)
+	cliutil.SetViperWithArgs(args)
+	err := txCmd.Execute()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &types.MsgSwapExactIn{
+		Sender:       fromAddr,
+		To:           fromAddr,
+		Path:         []string{"foo", "cet", "bar"},
+		AmountIn:     sdk.NewInt(100000000),
+		MinAmountOut: sdk.NewInt(90000000),
+		Deadline:     12345,
+	}, resultMsg)
+}
+
+func TestTransferLiquidityCmd(t *testing.T) {
+	txCmd := GetTxCmd(nil)
+	args := []string{
+		"transfer-liquidity",
+		"--stock=foo",
+		"--money=bar",
+		"--amount=12345",
+		"--to=" + fromAddr.String(),
+		"--from=" + fromAddr.String(),
+		"--generate-only",
+	}
+	txCmd.SetArgs(args)
+	cliutil.SetViperWithArgs(args)
+	err := txCmd.Execute()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &types.MsgTransferLiquidity{
+		Sender: fromAddr,
+		To:     fromAddr,
+		Stock:  "foo",
+		Money:  "bar",
+		Amount: sdk.NewInt(12345),
+	}, resultMsg)
+}
+
+func TestRangeLiquidityCmds(t *testing.T) {
+	txCmd := GetTxCmd(nil)
+	args := []string{
+		"create-range-pair",
+		"--stock=foo",
+		"--money=bar",
+		"--price-precision=8",
+		"--init-price=1.5",
+		"--from=" + fromAddr.String(),
+		"--generate-only",
+	}
+	txCmd.SetArgs(args)
+	cliutil.SetViperWithArgs(args)
+	err := txCmd.Execute()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &types.MsgAutoSwapCreateTradingPair{
+		Creator:        fromAddr,
+		Stock:          "foo",
+		Money:          "bar",
+		PricePrecision: 8,
+		RangeLiquidity: true,
+		InitPrice:      sdk.MustNewDecFromStr("1.5"),
+	}, resultMsg)
+
+	txCmd = GetTxCmd(nil)
+	args = []string{
+		"add-range-liquidity",
+		"--stock=foo",
+		"--money=bar",
+		"--lower-price=1.2",
+		"--upper-price=1.8",
+		"--stock-in=100000000",
+		"--money-in=0",
+		"--from=" + fromAddr.String(),
+		"--generate-only",
+	}
+	txCmd.SetArgs(args)
+	cliutil.SetViperWithArgs(args)
+	err = txCmd.Execute()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &types.MsgAddRangeLiquidity{
+		Sender:     fromAddr,
+		Stock:      "foo",
+		Money:      "bar",
+		LowerPrice: sdk.MustNewDecFromStr("1.2"),
+		UpperPrice: sdk.MustNewDecFromStr("1.8"),
+		StockIn:    sdk.NewInt(100000000),
+		MoneyIn:    sdk.ZeroInt(),
+	}, resultMsg)
+
+	txCmd = GetTxCmd(nil)
+	args = []string{
+		"remove-range-liquidity",
+		"--stock=foo",
+		"--money=bar",
+		"--lower-price=1.2",
+		"--upper-price=1.8",
+		"--liquidity=12345",
+		"--from=" + fromAddr.String(),
+		"--generate-only",
+	}
+	txCmd.SetArgs(args)
+	cliutil.SetViperWithArgs(args)
+	err = txCmd.Execute()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &types.MsgRemoveRangeLiquidity{
+		Sender:     fromAddr,
+		Stock:      "foo",
+		Money:      "bar",
+		LowerPrice: sdk.MustNewDecFromStr("1.2"),
+		UpperPrice: sdk.MustNewDecFromStr("1.8"),
+		Liquidity:  sdk.NewInt(12345),
+	}, resultMsg)
+}
+
+func TestCreateStablePairCmd(t *testing.T) {
+	txCmd := GetTxCmd(nil)
+	args := []string{
+		"create-stable-pair",
+		"--stock=usdx",
+		"--money=usdy",
+		"--price-precision=8",
+		"--amplification=100",
+		"--from=" + fromAddr.String(),
+		"--generate-only",
+	}
+	txCmd.SetArgs(args)
+	cliutil.SetViperWithArgs(args)
+	err := txCmd.Execute()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &types.MsgAutoSwapCreateTradingPair{
+		Creator:        fromAddr,
+		Stock:          "usdx",
+		Money:          "usdy",
+		PricePrecision: 8,
+		CurveType:      types.CurveStableSwap,
+		Amplification:  100,
+	}, resultMsg)
+}
+
+func TestRewardCmds(t *testing.T) {
+	txCmd := GetTxCmd(nil)
+	args := []string{
+		"fund-reward-program",
+		"--stock=foo",
+		"--money=bar",
+		"--denom=cet",
+		"--amount=100000000",
+		"--reward-per-block=1000",
+		"--from=" + fromAddr.String(),
+		"--generate-only",
+	}
+	txCmd.SetArgs(args)
+	cliutil.SetViperWithArgs(args)
+	err := txCmd.Execute()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &types.MsgFundRewardProgram{
+		Sender:         fromAddr,
+		Stock:          "foo",
+		Money:          "bar",
+		Denom:          "cet",
+		Amount:         sdk.NewInt(100000000),
+		RewardPerBlock: sdk.NewInt(1000),
+	}, resultMsg)
+
+	txCmd = GetTxCmd(nil)
+	args = []string{
+		"claim-rewards",
+		"--stock=foo",
+		"--money=bar",
+		"--to=" + fromAddr.String(),
+		"--from=" + fromAddr.String(),
+		"--generate-only",
+	}
+	txCmd.SetArgs(args)
+	cliutil.SetViperWithArgs(args)
+	err = txCmd.Execute()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, &types.MsgClaimRewards{
+		Sender: fromAddr,
+		To:     fromAddr,
+		Stock:  "foo",
+		Money:  "bar",
 	}, resultMsg)
 }
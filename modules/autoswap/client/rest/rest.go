@@ -19,4 +19,11 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
 	r.HandleFunc("/market/add-liquidity", addLiquidityHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc("/market/remove-liquidity", removeLiquidityHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/transfer-liquidity", transferLiquidityHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/swap-exact-in", swapExactInHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/swap-exact-out", swapExactOutHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/add-range-liquidity", addRangeLiquidityHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/remove-range-liquidity", removeRangeLiquidityHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/fund-reward-program", fundRewardProgramHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/claim-rewards", claimRewardsHandlerFn(cdc, cliCtx)).Methods("POST")
 }
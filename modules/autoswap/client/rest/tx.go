@@ -22,6 +22,10 @@ type addLiquidityReq struct {
 	StockIn string       `json:"stock_in"`
 	MoneyIn string       `json:"money_in"`
 	To      string       `json:"to"`
+
+	MinStockIn string `json:"min_stock_in"`
+	MinMoneyIn string `json:"min_money_in"`
+	Deadline   int64  `json:"deadline"`
 }
 
 func (req *addLiquidityReq) New() restutil.RestReq {
@@ -34,9 +38,10 @@ func (req *addLiquidityReq) GetBaseReq() *rest.BaseReq {
 
 func (req *addLiquidityReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
 	msg := &types.MsgAddLiquidity{
-		Sender: sender,
-		Stock:  req.Stock,
-		Money:  req.Money,
+		Sender:   sender,
+		Stock:    req.Stock,
+		Money:    req.Money,
+		Deadline: req.Deadline,
 	}
 
 	var err error
@@ -46,6 +51,12 @@ func (req *addLiquidityReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (sdk.
 	if msg.MoneyIn, err = parseSdkInt("money_in", req.MoneyIn); err != nil {
 		return nil, err
 	}
+	if msg.MinStockIn, err = parseOptionalSdkInt("min_stock_in", req.MinStockIn); err != nil {
+		return nil, err
+	}
+	if msg.MinMoneyIn, err = parseOptionalSdkInt("min_money_in", req.MinMoneyIn); err != nil {
+		return nil, err
+	}
 	if msg.To, err = sdk.AccAddressFromBech32(req.To); err != nil {
 		return nil, err
 	}
@@ -61,6 +72,10 @@ type removeLiquidityReq struct {
 	Money   string       `json:"money"`
 	Amount  string       `json:"amount"`
 	To      string       `json:"to"`
+
+	MinStockOut string `json:"min_stock_out"`
+	MinMoneyOut string `json:"min_money_out"`
+	Deadline    int64  `json:"deadline"`
 }
 
 func (req *removeLiquidityReq) New() restutil.RestReq {
@@ -73,6 +88,49 @@ func (req *removeLiquidityReq) GetBaseReq() *rest.BaseReq {
 
 func (req *removeLiquidityReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
 	msg := &types.MsgRemoveLiquidity{
+		Sender:   sender,
+		Stock:    req.Stock,
+		Money:    req.Money,
+		Deadline: req.Deadline,
+	}
+
+	var err error
+	if msg.Amount, err = parseSdkInt("amount", req.Amount); err != nil {
+		return nil, err
+	}
+	if msg.MinStockOut, err = parseOptionalSdkInt("min_stock_out", req.MinStockOut); err != nil {
+		return nil, err
+	}
+	if msg.MinMoneyOut, err = parseOptionalSdkInt("min_money_out", req.MinMoneyOut); err != nil {
+		return nil, err
+	}
+	if msg.To, err = sdk.AccAddressFromBech32(req.To); err != nil {
+		return nil, err
+	}
+
+	return msg, err
+}
+
+/* transferLiquidityReq */
+
+type transferLiquidityReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Stock   string       `json:"stock"`
+	Money   string       `json:"money"`
+	Amount  string       `json:"amount"`
+	To      string       `json:"to"`
+}
+
+func (req *transferLiquidityReq) New() restutil.RestReq {
+	return new(transferLiquidityReq)
+}
+
+func (req *transferLiquidityReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+
+func (req *transferLiquidityReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgTransferLiquidity{
 		Sender: sender,
 		Stock:  req.Stock,
 		Money:  req.Money,
@@ -89,6 +147,248 @@ func (req *removeLiquidityReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (s
 	return msg, err
 }
 
+/* swapExactInReq */
+
+type swapExactInReq struct {
+	BaseReq      rest.BaseReq `json:"base_req"`
+	Path         []string     `json:"path"`
+	AmountIn     string       `json:"amount_in"`
+	MinAmountOut string       `json:"min_amount_out"`
+	Deadline     int64        `json:"deadline"`
+	To           string       `json:"to"`
+}
+
+func (req *swapExactInReq) New() restutil.RestReq {
+	return new(swapExactInReq)
+}
+
+func (req *swapExactInReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+
+func (req *swapExactInReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgSwapExactIn{
+		Sender:   sender,
+		Path:     req.Path,
+		Deadline: req.Deadline,
+	}
+
+	var err error
+	if msg.AmountIn, err = parseSdkInt("amount_in", req.AmountIn); err != nil {
+		return nil, err
+	}
+	if msg.MinAmountOut, err = parseSdkInt("min_amount_out", req.MinAmountOut); err != nil {
+		return nil, err
+	}
+	if msg.To, err = sdk.AccAddressFromBech32(req.To); err != nil {
+		return nil, err
+	}
+
+	return msg, err
+}
+
+/* swapExactOutReq */
+
+type swapExactOutReq struct {
+	BaseReq     rest.BaseReq `json:"base_req"`
+	Path        []string     `json:"path"`
+	AmountOut   string       `json:"amount_out"`
+	MaxAmountIn string       `json:"max_amount_in"`
+	Deadline    int64        `json:"deadline"`
+	To          string       `json:"to"`
+}
+
+func (req *swapExactOutReq) New() restutil.RestReq {
+	return new(swapExactOutReq)
+}
+
+func (req *swapExactOutReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+
+func (req *swapExactOutReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgSwapExactOut{
+		Sender:   sender,
+		Path:     req.Path,
+		Deadline: req.Deadline,
+	}
+
+	var err error
+	if msg.AmountOut, err = parseSdkInt("amount_out", req.AmountOut); err != nil {
+		return nil, err
+	}
+	if msg.MaxAmountIn, err = parseSdkInt("max_amount_in", req.MaxAmountIn); err != nil {
+		return nil, err
+	}
+	if msg.To, err = sdk.AccAddressFromBech32(req.To); err != nil {
+		return nil, err
+	}
+
+	return msg, err
+}
+
+/* addRangeLiquidityReq */
+
+type addRangeLiquidityReq struct {
+	BaseReq    rest.BaseReq `json:"base_req"`
+	Stock      string       `json:"stock"`
+	Money      string       `json:"money"`
+	LowerPrice string       `json:"lower_price"`
+	UpperPrice string       `json:"upper_price"`
+	StockIn    string       `json:"stock_in"`
+	MoneyIn    string       `json:"money_in"`
+}
+
+func (req *addRangeLiquidityReq) New() restutil.RestReq {
+	return new(addRangeLiquidityReq)
+}
+
+func (req *addRangeLiquidityReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+
+func (req *addRangeLiquidityReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgAddRangeLiquidity{
+		Sender: sender,
+		Stock:  req.Stock,
+		Money:  req.Money,
+	}
+
+	var err error
+	if msg.LowerPrice, err = sdk.NewDecFromStr(req.LowerPrice); err != nil {
+		return nil, err
+	}
+	if msg.UpperPrice, err = sdk.NewDecFromStr(req.UpperPrice); err != nil {
+		return nil, err
+	}
+	if msg.StockIn, err = parseSdkInt("stock_in", req.StockIn); err != nil {
+		return nil, err
+	}
+	if msg.MoneyIn, err = parseSdkInt("money_in", req.MoneyIn); err != nil {
+		return nil, err
+	}
+
+	return msg, err
+}
+
+/* removeRangeLiquidityReq */
+
+type removeRangeLiquidityReq struct {
+	BaseReq    rest.BaseReq `json:"base_req"`
+	Stock      string       `json:"stock"`
+	Money      string       `json:"money"`
+	LowerPrice string       `json:"lower_price"`
+	UpperPrice string       `json:"upper_price"`
+	Liquidity  string       `json:"liquidity"`
+	To         string       `json:"to"`
+}
+
+func (req *removeRangeLiquidityReq) New() restutil.RestReq {
+	return new(removeRangeLiquidityReq)
+}
+
+func (req *removeRangeLiquidityReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+
+func (req *removeRangeLiquidityReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgRemoveRangeLiquidity{
+		Sender: sender,
+		Stock:  req.Stock,
+		Money:  req.Money,
+	}
+
+	var err error
+	if msg.LowerPrice, err = sdk.NewDecFromStr(req.LowerPrice); err != nil {
+		return nil, err
+	}
+	if msg.UpperPrice, err = sdk.NewDecFromStr(req.UpperPrice); err != nil {
+		return nil, err
+	}
+	if msg.Liquidity, err = parseSdkInt("liquidity", req.Liquidity); err != nil {
+		return nil, err
+	}
+	if len(req.To) != 0 {
+		if msg.To, err = sdk.AccAddressFromBech32(req.To); err != nil {
+			return nil, err
+		}
+	}
+
+	return msg, err
+}
+
+/* fundRewardProgramReq */
+
+type fundRewardProgramReq struct {
+	BaseReq        rest.BaseReq `json:"base_req"`
+	Stock          string       `json:"stock"`
+	Money          string       `json:"money"`
+	Denom          string       `json:"denom"`
+	Amount         string       `json:"amount"`
+	RewardPerBlock string       `json:"reward_per_block"`
+}
+
+func (req *fundRewardProgramReq) New() restutil.RestReq {
+	return new(fundRewardProgramReq)
+}
+
+func (req *fundRewardProgramReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+
+func (req *fundRewardProgramReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgFundRewardProgram{
+		Sender: sender,
+		Stock:  req.Stock,
+		Money:  req.Money,
+		Denom:  req.Denom,
+	}
+
+	var err error
+	if msg.Amount, err = parseSdkInt("amount", req.Amount); err != nil {
+		return nil, err
+	}
+	if msg.RewardPerBlock, err = parseOptionalSdkInt("reward_per_block", req.RewardPerBlock); err != nil {
+		return nil, err
+	}
+
+	return msg, err
+}
+
+/* claimRewardsReq */
+
+type claimRewardsReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	Stock   string       `json:"stock"`
+	Money   string       `json:"money"`
+	To      string       `json:"to"`
+}
+
+func (req *claimRewardsReq) New() restutil.RestReq {
+	return new(claimRewardsReq)
+}
+
+func (req *claimRewardsReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+
+func (req *claimRewardsReq) GetMsg(_ *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgClaimRewards{
+		Sender: sender,
+		Stock:  req.Stock,
+		Money:  req.Money,
+	}
+
+	var err error
+	if len(req.To) != 0 {
+		if msg.To, err = sdk.AccAddressFromBech32(req.To); err != nil {
+			return nil, err
+		}
+	}
+
+	return msg, err
+}
+
 /* createHandlerFns */
 func addLiquidityHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	var req addLiquidityReq
@@ -98,6 +398,36 @@ func removeLiquidityHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.
 	var req removeLiquidityReq
 	return restutil.NewRestHandler(cdc, cliCtx, &req)
 }
+func transferLiquidityHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req transferLiquidityReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+func swapExactInHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req swapExactInReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+func swapExactOutHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req swapExactOutReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+
+func addRangeLiquidityHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req addRangeLiquidityReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+func removeRangeLiquidityHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req removeRangeLiquidityReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+
+func fundRewardProgramHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req fundRewardProgramReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+func claimRewardsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req claimRewardsReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
 
 /* helpers */
 
@@ -108,3 +438,11 @@ func parseSdkInt(name, s string) (val sdk.Int, err error) {
 	}
 	return
 }
+
+// an empty string is taken as zero
+func parseOptionalSdkInt(name, s string) (sdk.Int, error) {
+	if len(s) == 0 {
+		return sdk.ZeroInt(), nil
+	}
+	return parseSdkInt(name, s)
+}
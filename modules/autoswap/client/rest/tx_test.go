@@ -17,6 +17,9 @@ func TestAddLiquidityReq(t *testing.T) {
 		StockIn: "123",
 		MoneyIn: "456",
 		To:      addr.String(),
+
+		MinMoneyIn: "400",
+		Deadline:   100,
 	}
 	msg, err := req.GetMsg(nil, addr)
 	assert.NoError(t, err)
@@ -27,6 +30,10 @@ func TestAddLiquidityReq(t *testing.T) {
 		StockIn: sdk.NewInt(123),
 		MoneyIn: sdk.NewInt(456),
 		To:      addr,
+
+		MinStockIn: sdk.ZeroInt(),
+		MinMoneyIn: sdk.NewInt(400),
+		Deadline:   100,
 	}, msg)
 }
 
@@ -45,5 +52,71 @@ func TestRemoveLiquidityReq(t *testing.T) {
 		Money:  "bar",
 		Amount: sdk.NewInt(789),
 		To:     addr,
+
+		MinStockOut: sdk.ZeroInt(),
+		MinMoneyOut: sdk.ZeroInt(),
+	}, msg)
+}
+
+func TestSwapExactOutReq(t *testing.T) {
+	req := swapExactOutReq{
+		Path:        []string{"foo", "cet", "bar"},
+		AmountOut:   "123",
+		MaxAmountIn: "456",
+		Deadline:    789,
+		To:          addr.String(),
+	}
+	msg, err := req.GetMsg(nil, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.MsgSwapExactOut{
+		Sender:      addr,
+		Path:        []string{"foo", "cet", "bar"},
+		AmountOut:   sdk.NewInt(123),
+		MaxAmountIn: sdk.NewInt(456),
+		Deadline:    789,
+		To:          addr,
+	}, msg)
+}
+
+func TestRemoveRangeLiquidityReq(t *testing.T) {
+	req := removeRangeLiquidityReq{
+		Stock:      "foo",
+		Money:      "bar",
+		LowerPrice: "1.2",
+		UpperPrice: "1.8",
+		Liquidity:  "789",
+	}
+	msg, err := req.GetMsg(nil, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.MsgRemoveRangeLiquidity{
+		Sender:     addr,
+		Stock:      "foo",
+		Money:      "bar",
+		LowerPrice: sdk.MustNewDecFromStr("1.2"),
+		UpperPrice: sdk.MustNewDecFromStr("1.8"),
+		Liquidity:  sdk.NewInt(789),
+	}, msg)
+
+	req.UpperPrice = "x"
+	_, err = req.GetMsg(nil, addr)
+	assert.Error(t, err)
+}
+
+func TestFundRewardProgramReq(t *testing.T) {
+	req := fundRewardProgramReq{
+		Stock:  "foo",
+		Money:  "bar",
+		Denom:  "cet",
+		Amount: "1000",
+	}
+	msg, err := req.GetMsg(nil, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, &types.MsgFundRewardProgram{
+		Sender:         addr,
+		Stock:          "foo",
+		Money:          "bar",
+		Denom:          "cet",
+		Amount:         sdk.NewInt(1000),
+		RewardPerBlock: sdk.ZeroInt(),
 	}, msg)
 }
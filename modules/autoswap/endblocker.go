@@ -2,8 +2,30 @@ package autoswap
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
 )
 
 func EndBlocker(ctx sdk.Context, keeper *Keeper) {
 	keeper.ResetOrderIndexInOneBlock()
+	removeDelistedPairs(ctx, keeper)
+}
+
+// at the effective time of the delist requests, cancel the resting orders of the trading pairs,
+// return the reserves and the unclaimed rewards to the liquidity providers, refund the reward programs
+// to their funders and remove the pools
+func removeDelistedPairs(ctx sdk.Context, keeper *Keeper) {
+	currTime := ctx.BlockHeader().Time.UnixNano()
+	for _, symbol := range keeper.GetDelistSymbolsBeforeTime(ctx, currTime) {
+		if keeper.GetPoolInfo(ctx, symbol) == nil {
+			continue
+		}
+		keeper.CancelAllOrders(ctx, symbol, types.CancelOrderByDelist)
+		keeper.WithdrawAllLiquidity(ctx, symbol)
+		keeper.CloseRewardProgram(ctx, symbol)
+		keeper.ClearPoolInfo(ctx, symbol)
+		ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeKeyDelistPair,
+			sdk.NewAttribute(AttributeSymbol, symbol)))
+	}
+	keeper.RemoveDelistRequestsBeforeTime(ctx, currTime)
 }
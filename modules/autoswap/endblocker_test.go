@@ -0,0 +1,76 @@
+package autoswap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/coinexchain/cet-sdk/modules/asset"
+	"github.com/coinexchain/cet-sdk/modules/autoswap"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	"github.com/coinexchain/cet-sdk/modules/market"
+	"github.com/coinexchain/cet-sdk/testapp"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+func TestDelistTradingPair(t *testing.T) {
+	owner := sdk.AccAddress("owner")
+	lp := sdk.AccAddress("lp")
+	app := testapp.NewTestApp()
+	ctx := sdk.NewContext(app.Cms, abci.Header{Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+	app.SupplyKeeper.SetSupply(ctx, supply.Supply{Total: sdk.Coins{}})
+	app.AssetKeeper.SetParams(ctx, asset.DefaultParams())
+	app.AutoSwapKeeper.SetParams(ctx, types.DefaultParams())
+	app.AccountKeeper.SetAccount(ctx, app.AccountKeeper.NewAccount(ctx, supply.NewEmptyModuleAccount(autoswap.PoolModuleAcc)))
+	for _, sym := range []string{"btc0", "usd0"} {
+		require.NoError(t, app.AssetKeeper.IssueToken(ctx, sym, sym, sdk.NewInt(1e10), owner,
+			false, false, false, false, sym, sym, sym))
+		require.NoError(t, app.AssetKeeper.SendCoinsFromAssetModuleToAccount(ctx, owner, sdk.NewCoins(sdk.NewCoin(sym, sdk.NewInt(1e10)))))
+	}
+	symbol := dex.GetSymbol("btc0", "usd0")
+	k := app.AutoSwapKeeper
+	k.CreatePair(ctx, owner, symbol, 0)
+	coins := sdk.NewCoins(sdk.NewCoin("btc0", sdk.NewInt(10000)), sdk.NewCoin("usd0", sdk.NewInt(1000000)))
+	require.NoError(t, k.SendCoinsFromUserToPool(ctx, owner, coins))
+	_, err := k.Mint(ctx, symbol, sdk.NewInt(10000), sdk.NewInt(1000000), lp)
+	require.NoError(t, err)
+
+	handler := autoswap.NewHandler(k)
+	minTime := ctx.BlockHeader().Time.UnixNano() + int64(types.DefaultPairMinExpiredTime)
+	msg := market.MsgCancelTradingPair{Sender: owner, TradingPair: symbol, EffectiveTime: minTime - 1}
+	require.Equal(t, sdk.CodeType(types.CodeInvalidEffectTime), handler(ctx, msg).Code)
+	msg.EffectiveTime = minTime
+	msg.Sender = lp
+	require.Equal(t, sdk.CodeType(types.CodeNotStockOwner), handler(ctx, msg).Code)
+	msg.Sender = owner
+	msg.TradingPair = "usd0/btc0"
+	require.Equal(t, sdk.CodeType(types.CodePairIsNotExist), handler(ctx, msg).Code)
+	msg.TradingPair = symbol
+	require.True(t, handler(ctx, msg).IsOK())
+	require.Equal(t, sdk.CodeType(types.CodeDelistRequestExist), handler(ctx, msg).Code)
+
+	querier := keepers.NewQuerier(*k)
+	reqBytes := app.Cdc.MustMarshalJSON(market.QueryCancelMarkets{Time: minTime})
+	res, queryErr := querier(ctx, []string{market.QueryWaitCancelMarkets}, abci.RequestQuery{Data: reqBytes})
+	require.Nil(t, queryErr)
+	var markets []string
+	app.Cdc.MustUnmarshalJSON(res, &markets)
+	require.Equal(t, []string{symbol}, markets)
+
+	// the pair is kept before the effective time
+	autoswap.EndBlocker(ctx.WithBlockTime(time.Unix(0, minTime-1)), k)
+	require.NotNil(t, k.GetPoolInfo(ctx, symbol))
+
+	autoswap.EndBlocker(ctx.WithBlockTime(time.Unix(0, minTime)), k)
+	require.Nil(t, k.GetPoolInfo(ctx, symbol))
+	require.False(t, k.HasDelistRequest(ctx, symbol))
+	require.True(t, k.GetLiquidity(ctx, symbol, lp).IsZero())
+	require.Equal(t, coins, app.BankxKeeper.GetCoins(ctx, lp))
+}
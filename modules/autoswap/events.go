@@ -4,7 +4,28 @@ var (
 	AttributeValueCategory      = ModuleName
 	EventTypeKeyAddLiquidity    = "add_liquidity"
 	EventTypeKeyRemoveLiquidity = "remove_liquidity"
+	EventTypeKeyCancelPair      = "cancel_trading_pair"
+	EventTypeKeyDelistPair      = "delist_trading_pair"
+	EventTypeKeySwap            = "swap"
+	EventTypeKeyTransferLiq     = "transfer_liquidity"
 	AttributeSymbol             = "symbol"
+	AttributeEffectiveTime      = "effective_time"
+	AttributeSwapPath           = "path"
+	AttributeAmountIn           = "amount_in"
+	AttributeAmountOut          = "amount_out"
+	AttributeRecipient          = "recipient"
+	AttributeAmount             = "amount"
+
+	EventTypeKeyAddRangeLiq    = "add_range_liquidity"
+	EventTypeKeyRemoveRangeLiq = "remove_range_liquidity"
+	AttributeLowerPrice        = "lower_price"
+	AttributeUpperPrice        = "upper_price"
+
+	EventTypeKeyFundRewards  = "fund_reward_program"
+	EventTypeKeyClaimRewards = "claim_rewards"
+
+	EventTypeKeyFlashBorrow = "flash_borrow"
+	EventTypeKeyFlashRepay  = "flash_repay"
 
 	KafkaAddLiquidity    = "add_liquidity"
 	KafkaRemoveLiquidity = "remove_liquidity"
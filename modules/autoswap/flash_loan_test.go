@@ -0,0 +1,97 @@
+package autoswap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/authx"
+	"github.com/coinexchain/cet-sdk/modules/autoswap"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+var _ authx.MsgToBeClosed = types.MsgFlashBorrow{}
+
+func TestFlashLoan(t *testing.T) {
+	alice := sdk.AccAddress("alice_______________")
+	bob := sdk.AccAddress("bob_________________")
+	app, ctx := newAppWithTokens(t, alice, "foo0", "usd0")
+	k := app.AutoSwapKeeper
+	handler := autoswap.NewHandler(k)
+	symbol := dex.GetSymbol("foo0", "usd0")
+	require.True(t, handler(ctx, types.MsgAutoSwapCreateTradingPair{Stock: "foo0", Money: "usd0", Creator: alice, PricePrecision: 8}).IsOK())
+	require.True(t, handler(ctx, types.MsgAddLiquidity{Sender: alice, Stock: "foo0", Money: "usd0",
+		StockIn: sdk.NewInt(10000), MoneyIn: sdk.NewInt(10000), To: alice}).IsOK())
+
+	borrowMsg := types.MsgFlashBorrow{Sender: bob, Stock: "foo0", Money: "usd0", StockAmount: sdk.NewInt(10001), MoneyAmount: sdk.ZeroInt()}
+	repayMsg := types.MsgFlashRepay{Sender: bob, Stock: "foo0", Money: "usd0"}
+	require.True(t, borrowMsg.IsClosedBy(repayMsg))
+	require.False(t, borrowMsg.IsClosedBy(types.MsgFlashRepay{Sender: alice, Stock: "foo0", Money: "usd0"}))
+
+	// no more than the AMM reserves can be borrowed
+	require.Equal(t, sdk.CodeType(types.CodeInvalidFlashLoan), handler(ctx, borrowMsg).Code)
+	borrowMsg.StockAmount = sdk.NewInt(10000)
+	require.True(t, handler(ctx, borrowMsg).IsOK())
+	require.Equal(t, sdk.NewInt(10000), app.BankxKeeper.GetCoins(ctx, bob).AmountOf("foo0"))
+	info := k.GetPoolInfo(ctx, symbol)
+	require.Equal(t, sdk.NewInt(10000), info.StockAmmReserve)
+
+	// the fee of 0.5% can't be paid, so the loan is still open
+	require.False(t, handler(ctx, repayMsg).IsOK())
+	require.NotNil(t, k.GetFlashLoan(ctx, symbol, bob))
+	require.NoError(t, app.BankxKeeper.SendCoins(ctx, alice, bob, sdk.NewCoins(sdk.NewCoin("foo0", sdk.NewInt(50)))))
+	require.True(t, handler(ctx, repayMsg).IsOK())
+	require.True(t, app.BankxKeeper.GetCoins(ctx, bob).AmountOf("foo0").IsZero())
+	require.Nil(t, k.GetFlashLoan(ctx, symbol, bob))
+	info = k.GetPoolInfo(ctx, symbol)
+	require.Equal(t, sdk.NewInt(10050), info.StockAmmReserve)
+	require.Equal(t, sdk.NewInt(10000), info.MoneyAmmReserve)
+
+	require.Equal(t, sdk.CodeType(types.CodeInvalidFlashLoan), handler(ctx, repayMsg).Code)
+}
+
+func TestFlashLoanOfRangePool(t *testing.T) {
+	alice := sdk.AccAddress("alice_______________")
+	bob := sdk.AccAddress("bob_________________")
+	app, ctx := newAppWithTokens(t, alice, "foo0", "usd0")
+	k := app.AutoSwapKeeper
+	handler := autoswap.NewHandler(k)
+	symbol := dex.GetSymbol("foo0", "usd0")
+	require.True(t, handler(ctx, types.MsgAutoSwapCreateTradingPair{Stock: "foo0", Money: "usd0", Creator: alice,
+		PricePrecision: 8, RangeLiquidity: true, InitPrice: sdk.NewDec(100)}).IsOK())
+	lower, upper := sdk.NewDecWithPrec(1, 8), sdk.NewDec(1e8)
+	require.True(t, handler(ctx, types.MsgAddRangeLiquidity{Sender: alice, Stock: "foo0", Money: "usd0",
+		LowerPrice: lower, UpperPrice: upper, StockIn: sdk.NewInt(10000), MoneyIn: sdk.NewInt(1000000)}).IsOK())
+
+	// the reserves are more than the positions can pay out because of the rounding
+	info := k.GetPoolInfo(ctx, symbol)
+	stockLendable, moneyLendable := info.LendableAmounts()
+	require.True(t, stockLendable.IsPositive() && stockLendable.LT(info.StockAmmReserve))
+	require.True(t, moneyLendable.IsPositive() && moneyLendable.LT(info.MoneyAmmReserve))
+	borrowMsg := types.MsgFlashBorrow{Sender: bob, Stock: "foo0", Money: "usd0",
+		StockAmount: info.StockAmmReserve, MoneyAmount: sdk.ZeroInt()}
+	require.Equal(t, sdk.CodeType(types.CodeInvalidFlashLoan), handler(ctx, borrowMsg).Code)
+	borrowMsg.StockAmount = stockLendable
+	require.True(t, handler(ctx, borrowMsg).IsOK())
+
+	// the loan can't be repaid to a pool which doesn't exist
+	repayMsg := types.MsgFlashRepay{Sender: bob, Stock: "foo0", Money: "usd0"}
+	require.NoError(t, app.BankxKeeper.SendCoins(ctx, alice, bob, sdk.NewCoins(sdk.NewCoin("foo0", sdk.NewInt(100)))))
+	cacheCtx, _ := ctx.CacheContext()
+	k.ClearPoolInfo(cacheCtx, symbol)
+	require.Equal(t, sdk.CodeType(types.CodePairIsNotExist), handler(cacheCtx, repayMsg).Code)
+	require.NotNil(t, k.GetFlashLoan(cacheCtx, symbol, bob))
+	require.True(t, handler(ctx, repayMsg).IsOK())
+
+	// nothing can be lent when all the liquidity is removed, even if some dust is left in the reserves
+	pos := k.GetRangePosition(ctx, symbol, alice, lower, upper)
+	require.True(t, handler(ctx, types.MsgRemoveRangeLiquidity{Sender: alice, Stock: "foo0", Money: "usd0",
+		LowerPrice: lower, UpperPrice: upper, Liquidity: pos.Liquidity}).IsOK())
+	info = k.GetPoolInfo(ctx, symbol)
+	require.True(t, info.MoneyAmmReserve.IsPositive())
+	borrowMsg.StockAmount, borrowMsg.MoneyAmount = sdk.ZeroInt(), info.MoneyAmmReserve
+	require.Equal(t, sdk.CodeType(types.CodeInvalidFlashLoan), handler(ctx, borrowMsg).Code)
+}
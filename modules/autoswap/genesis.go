@@ -2,6 +2,8 @@ package autoswap
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 
 	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
 	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
@@ -13,33 +15,75 @@ type GenesisState struct {
 	Orders         []types.Order           `json:"orders"`
 	PoolInfos      []keepers.PoolInfo      `json:"pool_infos"`
 	LiquidityInfos []keepers.LiquidityInfo `json:"liquidity_infos"`
+
+	RangePositions []keepers.RangePosition `json:"range_positions"`
+	Ticks          []keepers.Tick          `json:"ticks"`
+
+	RewardPrograms   []keepers.RewardProgram   `json:"reward_programs"`
+	LiquidityRewards []keepers.LiquidityReward `json:"liquidity_rewards"`
 }
 
 // NewGenesisState - Create a new genesis state
-func NewGenesisState(params types.Params, orders []types.Order, infos []keepers.PoolInfo, liquidityInfos []keepers.LiquidityInfo) GenesisState {
+func NewGenesisState(params types.Params, orders []types.Order, infos []keepers.PoolInfo, liquidityInfos []keepers.LiquidityInfo,
+	rangePositions []keepers.RangePosition, ticks []keepers.Tick, rewardPrograms []keepers.RewardProgram, liquidityRewards []keepers.LiquidityReward) GenesisState {
 	return GenesisState{
-		Params:         params,
-		Orders:         orders,
-		PoolInfos:      infos,
-		LiquidityInfos: liquidityInfos,
+		Params:           params,
+		Orders:           orders,
+		PoolInfos:        infos,
+		LiquidityInfos:   liquidityInfos,
+		RangePositions:   rangePositions,
+		Ticks:            ticks,
+		RewardPrograms:   rewardPrograms,
+		LiquidityRewards: liquidityRewards,
 	}
 }
 
 func DefaultGenesisState() GenesisState {
-	return NewGenesisState(types.DefaultParams(), []types.Order{}, []keepers.PoolInfo{}, []keepers.LiquidityInfo{})
+	return NewGenesisState(types.DefaultParams(), []types.Order{}, []keepers.PoolInfo{}, []keepers.LiquidityInfo{}, []keepers.RangePosition{},
+		[]keepers.Tick{}, []keepers.RewardProgram{}, []keepers.LiquidityReward{})
 }
 
 func InitGenesis(ctx sdk.Context, k *keepers.Keeper, data GenesisState) {
 	k.SetParams(ctx, data.Params)
-	for _ = range data.Orders {
-		//todo: setOrders
-	}
+	// the reserves of the order books are rebuilt from the orders
+	reserves := getOrderBookReserves(data.Orders)
 	for _, info := range data.PoolInfos {
+		info.StockOrderBookReserve, info.MoneyOrderBookReserve = reserves.get(info.Symbol)
+		// KLast is missing in the genesis exported before it was added
+		if info.KLast == (sdk.Int{}) {
+			info.KLast = sdk.ZeroInt()
+		}
 		k.SetPoolInfo(ctx, info.Symbol, &info)
 	}
 	for _, li := range data.LiquidityInfos {
 		k.SetLiquidity(ctx, li.Symbol, li.Owner, li.Liquidity)
 	}
+	for _, pos := range data.RangePositions {
+		k.SetRangePosition(ctx, pos)
+	}
+	for _, tick := range data.Ticks {
+		k.SetTick(ctx, tick)
+	}
+	for _, prog := range data.RewardPrograms {
+		k.SetRewardProgram(ctx, prog)
+	}
+	for _, reward := range data.LiquidityRewards {
+		k.SetLiquidityReward(ctx, reward)
+	}
+	// the index in one block is reassigned by AddOrder, so the orders are added in
+	// their original sequence to keep the priority of the orders at the same price
+	orders := make([]types.Order, len(data.Orders))
+	copy(orders, data.Orders)
+	sort.SliceStable(orders, func(i, j int) bool {
+		if orders[i].Height != orders[j].Height {
+			return orders[i].Height < orders[j].Height
+		}
+		return orders[i].OrderIndexInOneBlock < orders[j].OrderIndexInOneBlock
+	})
+	for i := range orders {
+		k.AddOrder(ctx, &orders[i])
+	}
+	k.ResetOrderIndexInOneBlock()
 }
 
 func ExportGenesis(ctx sdk.Context, k keepers.Keeper) GenesisState {
@@ -48,14 +92,59 @@ func ExportGenesis(ctx sdk.Context, k keepers.Keeper) GenesisState {
 	g.PoolInfos = infos
 	g.Params = k.GetParams(ctx)
 	g.LiquidityInfos = k.GetAllLiquidityInfos(ctx)
+	g.RangePositions = make([]keepers.RangePosition, 0)
+	k.IterateAllRangePositions(ctx, func(pos keepers.RangePosition) {
+		g.RangePositions = append(g.RangePositions, pos)
+	})
+	g.Ticks = make([]keepers.Tick, 0)
+	k.IterateAllTicks(ctx, func(tick keepers.Tick) {
+		g.Ticks = append(g.Ticks, tick)
+	})
+	g.RewardPrograms = make([]keepers.RewardProgram, 0)
+	k.IterateRewardPrograms(ctx, func(prog keepers.RewardProgram) {
+		g.RewardPrograms = append(g.RewardPrograms, prog)
+	})
+	g.LiquidityRewards = make([]keepers.LiquidityReward, 0)
+	k.IterateAllLiquidityRewards(ctx, func(reward keepers.LiquidityReward) {
+		g.LiquidityRewards = append(g.LiquidityRewards, reward)
+	})
+	g.Orders = make([]types.Order, 0)
+	for _, info := range infos {
+		for _, order := range k.GetAllOrders(ctx, info.Symbol) {
+			g.Orders = append(g.Orders, *order)
+		}
+	}
 	return g
 }
 
+// the stock frozen by the sell orders and the money frozen by the buy orders of each pool
+type orderBookReserves map[string][2]sdk.Int
+
+func getOrderBookReserves(orders []types.Order) orderBookReserves {
+	reserves := make(orderBookReserves)
+	for _, order := range orders {
+		stock, money := reserves.get(order.TradingPair)
+		if order.IsBuy {
+			money = money.AddRaw(order.Freeze)
+		} else {
+			stock = stock.AddRaw(order.Freeze)
+		}
+		reserves[order.TradingPair] = [2]sdk.Int{stock, money}
+	}
+	return reserves
+}
+
+func (r orderBookReserves) get(symbol string) (stock, money sdk.Int) {
+	if reserve, ok := r[symbol]; ok {
+		return reserve[0], reserve[1]
+	}
+	return sdk.ZeroInt(), sdk.ZeroInt()
+}
+
 func (data GenesisState) Validate() error {
 	if err := data.Params.ValidateGenesis(); err != nil {
 		return err
 	}
-	//todo: check duplicate order with id
 	infos := make(map[string]struct{})
 	for _, info := range data.PoolInfos {
 		symbol := info.Symbol
@@ -63,6 +152,125 @@ func (data GenesisState) Validate() error {
 			return errors.New("duplicate pool found during autoswap genesis validate")
 		}
 		infos[symbol] = struct{}{}
+		for _, amount := range []sdk.Int{info.StockAmmReserve, info.MoneyAmmReserve,
+			info.StockOrderBookReserve, info.MoneyOrderBookReserve, info.TotalSupply} {
+			if amount == (sdk.Int{}) || amount.IsNegative() {
+				return fmt.Errorf("invalid reserves or supply of pool %s during autoswap genesis validate", symbol)
+			}
+		}
+		if info.KLast != (sdk.Int{}) && info.KLast.IsNegative() {
+			return fmt.Errorf("invalid kLast of pool %s during autoswap genesis validate", symbol)
+		}
+		if err := types.ValidateCurve(info.CurveType, info.Amplification, info.RangeLiquidity); err != nil {
+			return err
+		}
+	}
+	orderIDs := make(map[string]struct{})
+	for _, order := range data.Orders {
+		orderID := order.GetOrderID()
+		if _, exists := orderIDs[orderID]; exists {
+			return fmt.Errorf("duplicate order %s found during autoswap genesis validate", orderID)
+		}
+		orderIDs[orderID] = struct{}{}
+		if _, exists := infos[order.TradingPair]; !exists {
+			return fmt.Errorf("the pool of order %s is not found during autoswap genesis validate", orderID)
+		}
+		if order.Freeze < 0 || order.LeftStock < 0 {
+			return fmt.Errorf("invalid frozen or left amount of order %s during autoswap genesis validate", orderID)
+		}
+	}
+	reserves := getOrderBookReserves(data.Orders)
+	for _, info := range data.PoolInfos {
+		stock, money := reserves.get(info.Symbol)
+		if !stock.Equal(info.StockOrderBookReserve) || !money.Equal(info.MoneyOrderBookReserve) {
+			return fmt.Errorf("the order book reserves of pool %s do not match the orders during autoswap genesis validate", info.Symbol)
+		}
+	}
+	if err := data.validateRangePositions(); err != nil {
+		return err
+	}
+	return data.validateRewards()
+}
+
+// every liquidity reward must belong to a reward program
+func (data GenesisState) validateRewards() error {
+	programs := make(map[string]keepers.RewardProgram)
+	for _, prog := range data.RewardPrograms {
+		if _, exists := programs[prog.Symbol]; exists {
+			return fmt.Errorf("duplicate reward program of pool %s found during autoswap genesis validate", prog.Symbol)
+		}
+		programs[prog.Symbol] = prog
+		if len(prog.Denom) == 0 || prog.RewardPerBlock == (sdk.Int{}) || !prog.RewardPerBlock.IsPositive() ||
+			prog.Remaining == (sdk.Int{}) || prog.Remaining.IsNegative() || prog.RewardPerShare.IsNil() || prog.RewardPerShare.IsNegative() {
+			return fmt.Errorf("invalid reward program of pool %s during autoswap genesis validate", prog.Symbol)
+		}
+		for _, funder := range prog.Funders {
+			if funder.Address.Empty() || funder.Amount == (sdk.Int{}) || !funder.Amount.IsPositive() {
+				return fmt.Errorf("invalid funder of the reward program of pool %s during autoswap genesis validate", prog.Symbol)
+			}
+		}
+	}
+	for _, reward := range data.LiquidityRewards {
+		prog, exists := programs[reward.Symbol]
+		if !exists {
+			return fmt.Errorf("the reward program of pool %s is not found during autoswap genesis validate", reward.Symbol)
+		}
+		if reward.Unclaimed == (sdk.Int{}) || reward.Unclaimed.IsNegative() || reward.RewardPerShare.IsNil() ||
+			reward.RewardPerShare.IsNegative() || reward.RewardPerShare.GT(prog.RewardPerShare) {
+			return fmt.Errorf("invalid reward of %s in pool %s during autoswap genesis validate", reward.Owner, reward.Symbol)
+		}
+	}
+	return nil
+}
+
+// the liquidity of the ticks in each pool must be the sum of the range positions bounded by them
+func (data GenesisState) validateRangePositions() error {
+	pools := make(map[string]keepers.PoolInfo)
+	for _, info := range data.PoolInfos {
+		pools[info.Symbol] = info
+	}
+	// the net and gross liquidity of each tick, indexed by the pool and the price of the tick
+	ticks := make(map[string]map[string][2]sdk.Int)
+	addTick := func(symbol string, price sdk.Dec, net, gross sdk.Int) {
+		if ticks[symbol] == nil {
+			ticks[symbol] = make(map[string][2]sdk.Int)
+		}
+		tick, ok := ticks[symbol][price.String()]
+		if !ok {
+			tick = [2]sdk.Int{sdk.ZeroInt(), sdk.ZeroInt()}
+		}
+		ticks[symbol][price.String()] = [2]sdk.Int{tick[0].Add(net), tick[1].Add(gross)}
+	}
+	for _, pos := range data.RangePositions {
+		info, exists := pools[pos.Symbol]
+		if !exists || !info.RangeLiquidity {
+			return fmt.Errorf("the pool of range position %s %s is not found during autoswap genesis validate", pos.Symbol, pos.Owner)
+		}
+		if err := types.ValidatePriceRange(pos.LowerPrice, pos.UpperPrice); err != nil {
+			return err
+		}
+		if err := types.ValidateRangeTicks(pos.LowerPrice, pos.UpperPrice, info.PricePrecision); err != nil {
+			return err
+		}
+		if pos.Liquidity == (sdk.Int{}) || !pos.Liquidity.IsPositive() {
+			return fmt.Errorf("invalid liquidity of range position %s %s during autoswap genesis validate", pos.Symbol, pos.Owner)
+		}
+		addTick(pos.Symbol, pos.LowerPrice, pos.Liquidity, pos.Liquidity)
+		addTick(pos.Symbol, pos.UpperPrice, pos.Liquidity.Neg(), pos.Liquidity)
+	}
+	for _, tick := range data.Ticks {
+		expected, ok := ticks[tick.Symbol][tick.Price.String()]
+		if !ok || tick.LiquidityNet == (sdk.Int{}) || tick.LiquidityGross == (sdk.Int{}) ||
+			!expected[0].Equal(tick.LiquidityNet) || !expected[1].Equal(tick.LiquidityGross) {
+			return fmt.Errorf("the ticks of pool %s do not match the range positions during autoswap genesis validate", tick.Symbol)
+		}
+		// each tick matches one expected, so the ones left are missing
+		delete(ticks[tick.Symbol], tick.Price.String())
+	}
+	for symbol, missing := range ticks {
+		if len(missing) != 0 {
+			return fmt.Errorf("the ticks of pool %s do not match the range positions during autoswap genesis validate", symbol)
+		}
 	}
 	return nil
 }
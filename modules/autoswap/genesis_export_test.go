@@ -0,0 +1,61 @@
+package autoswap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	"github.com/coinexchain/cet-sdk/modules/market"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+func TestExportAndImportOrders(t *testing.T) {
+	owner := sdk.AccAddress("owner")
+	app, ctx := newAppWithTokens(t, owner, "foo0", "usd0")
+	k := app.AutoSwapKeeper
+	symbol := dex.GetSymbol("foo0", "usd0")
+	k.CreatePair(ctx, owner, symbol, 0)
+	coins := sdk.NewCoins(sdk.NewCoin("foo0", sdk.NewInt(10000)), sdk.NewCoin("usd0", sdk.NewInt(1000000)))
+	require.NoError(t, k.SendCoinsFromUserToPool(ctx, owner, coins))
+	_, err := k.Mint(ctx, symbol, sdk.NewInt(10000), sdk.NewInt(1000000), owner)
+	require.NoError(t, err)
+
+	// the orders are away from the price of the pool, so they rest in the order book
+	handler := autoswap.NewHandler(k)
+	for i, price := range []int64{200, 200, 50} {
+		msg := market.MsgCreateOrder{Sender: owner, Identify: byte(i), TradingPair: symbol,
+			Price: price, Quantity: 100, Side: market.SELL}
+		if price < 100 {
+			msg.Side = market.BUY
+		}
+		res := handler(ctx, msg)
+		require.True(t, res.IsOK(), res.Log)
+	}
+	info := k.GetPoolInfo(ctx, symbol)
+	require.Equal(t, sdk.NewInt(200), info.StockOrderBookReserve)
+	require.Equal(t, sdk.NewInt(5000), info.MoneyOrderBookReserve)
+
+	gene := autoswap.ExportGenesis(ctx, *k)
+	require.Equal(t, 3, len(gene.Orders))
+	require.NoError(t, gene.Validate())
+
+	// import into a new chain
+	app2, ctx2 := newAppWithTokens(t, owner)
+	k2 := app2.AutoSwapKeeper
+	autoswap.InitGenesis(ctx2, k2, gene)
+	require.Equal(t, gene, autoswap.ExportGenesis(ctx2, *k2))
+	asks := 0
+	k2.IterateOrdersFromBestPrice(ctx2, symbol, false, func(order *types.Order) bool {
+		require.Equal(t, byte(asks), order.Identify)
+		asks++
+		return true
+	})
+	require.Equal(t, 2, asks)
+
+	gene.PoolInfos[0].StockOrderBookReserve = sdk.NewInt(100)
+	require.Error(t, gene.Validate())
+}
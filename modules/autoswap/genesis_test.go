@@ -3,7 +3,11 @@ package autoswap
 import (
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
 )
 
 func TestX(t *testing.T) {
@@ -12,3 +16,65 @@ func TestX(t *testing.T) {
 	err := mb.ValidateGenesis(gene)
 	require.NoError(t, err)
 }
+
+func TestValidateGenesisPools(t *testing.T) {
+	info := keepers.PoolInfo{
+		Symbol:                "stock/money",
+		StockAmmReserve:       sdk.NewInt(100),
+		MoneyAmmReserve:       sdk.NewInt(100),
+		StockOrderBookReserve: sdk.ZeroInt(),
+		MoneyOrderBookReserve: sdk.ZeroInt(),
+		TotalSupply:           sdk.NewInt(100),
+		KLast:                 sdk.NewInt(10000),
+	}
+	gene := DefaultGenesisState()
+	gene.PoolInfos = []keepers.PoolInfo{info}
+	require.NoError(t, gene.Validate())
+
+	gene.PoolInfos = []keepers.PoolInfo{info, info}
+	require.Error(t, gene.Validate())
+
+	// the genesis exported before KLast was added has no KLast
+	info.KLast = sdk.Int{}
+	gene.PoolInfos = []keepers.PoolInfo{info}
+	require.NoError(t, gene.Validate())
+
+	info.KLast = sdk.NewInt(-1)
+	gene.PoolInfos = []keepers.PoolInfo{info}
+	require.Error(t, gene.Validate())
+
+	gene.PoolInfos = nil
+	gene.Params.FeeOn = true
+	require.Error(t, gene.Validate())
+	gene.Params.FeeReceiver = sdk.AccAddress("receiver")
+	require.NoError(t, gene.Validate())
+}
+
+func TestValidateGenesisOrders(t *testing.T) {
+	info := keepers.PoolInfo{
+		Symbol:                "stock/money",
+		StockAmmReserve:       sdk.NewInt(100),
+		MoneyAmmReserve:       sdk.NewInt(100),
+		StockOrderBookReserve: sdk.NewInt(10),
+		MoneyOrderBookReserve: sdk.NewInt(20),
+		TotalSupply:           sdk.NewInt(100),
+		KLast:                 sdk.ZeroInt(),
+	}
+	sender := sdk.AccAddress("sender")
+	sell := types.Order{TradingPair: "stock/money", Sender: sender, Sequence: 1, Identify: 1, LeftStock: 10, Freeze: 10}
+	buy := types.Order{TradingPair: "stock/money", Sender: sender, Sequence: 1, Identify: 2, IsBuy: true, LeftStock: 10, Freeze: 20}
+	gene := DefaultGenesisState()
+	gene.PoolInfos = []keepers.PoolInfo{info}
+	gene.Orders = []types.Order{sell, buy}
+	require.NoError(t, gene.Validate())
+
+	gene.Orders = []types.Order{sell, sell}
+	require.Error(t, gene.Validate())
+
+	gene.Orders = []types.Order{sell}
+	require.Error(t, gene.Validate())
+
+	buy.TradingPair = "money/stock"
+	gene.Orders = []types.Order{sell, buy}
+	require.Error(t, gene.Validate())
+}
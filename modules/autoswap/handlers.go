@@ -40,7 +40,10 @@ func convertMarketMsg(msg sdk.Msg) (msg2 sdk.Msg, ok bool) {
 	case market.MsgCancelOrder:
 		msg2 = convertMsgCancelOrder(msg)
 	// new messages
-	case types.MsgAddLiquidity, types.MsgRemoveLiquidity:
+	case types.MsgAddLiquidity, types.MsgRemoveLiquidity, types.MsgTransferLiquidity,
+		types.MsgSwapExactIn, types.MsgSwapExactOut, types.MsgAutoSwapCreateTradingPair,
+		types.MsgAddRangeLiquidity, types.MsgRemoveRangeLiquidity, types.MsgFundRewardProgram, types.MsgClaimRewards,
+		types.MsgFlashBorrow, types.MsgFlashRepay:
 		msg2 = msg
 	default:
 		ok = false
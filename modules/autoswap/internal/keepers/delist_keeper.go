@@ -0,0 +1,102 @@
+package keepers
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+// DelistKeeper schedules the delisting of trading pairs at their effective time
+type DelistKeeper struct {
+	storeKey sdk.StoreKey
+}
+
+func NewDelistKeeper(key sdk.StoreKey) *DelistKeeper {
+	return &DelistKeeper{
+		storeKey: key,
+	}
+}
+
+func int64ToBigEndianBytes(n int64) []byte {
+	var v = make([]byte, 8)
+	binary.BigEndian.PutUint64(v, uint64(n))
+	return v
+}
+
+// getDelistKey key = DelistKey | time | 0x0 | symbol
+// value = symbol
+func getDelistKey(time int64, symbol string) []byte {
+	return dex.ConcatKeys(
+		DelistKey,
+		int64ToBigEndianBytes(time),
+		[]byte{0x0},
+		[]byte(symbol),
+	)
+}
+
+func getDelistKeyRangeByTime(time int64) (start, end []byte) {
+	start = dex.ConcatKeys(DelistKey, int64ToBigEndianBytes(0), []byte{0x0})
+	end = dex.ConcatKeys(DelistKey, int64ToBigEndianBytes(time), []byte{0x1})
+	return
+}
+
+// getDelistRevKey key = DelistRevKey | symbol
+// value = time
+func getDelistRevKey(symbol string) []byte {
+	return append(append([]byte{}, DelistRevKey...), symbol...)
+}
+
+func (keeper *DelistKeeper) AddDelistRequest(ctx sdk.Context, time int64, symbol string) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(getDelistKey(time, symbol), []byte(symbol))
+	store.Set(getDelistRevKey(symbol), int64ToBigEndianBytes(time))
+}
+
+func (keeper *DelistKeeper) HasDelistRequest(ctx sdk.Context, symbol string) bool {
+	store := ctx.KVStore(keeper.storeKey)
+	return store.Has(getDelistRevKey(symbol))
+}
+
+// Return the time of the pending delist request of a trading pair, and zero if there is no request
+func (keeper *DelistKeeper) GetDelistRequestTime(ctx sdk.Context, symbol string) int64 {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(getDelistRevKey(symbol))
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// Return the trading pairs whose delist requests take effect no later than time
+func (keeper *DelistKeeper) GetDelistSymbolsBeforeTime(ctx sdk.Context, time int64) []string {
+	store := ctx.KVStore(keeper.storeKey)
+	start, end := getDelistKeyRangeByTime(time)
+	var result []string
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		result = append(result, string(iter.Value()))
+	}
+	return result
+}
+
+func (keeper *DelistKeeper) RemoveDelistRequestsBeforeTime(ctx sdk.Context, time int64) {
+	store := ctx.KVStore(keeper.storeKey)
+	start, end := getDelistKeyRangeByTime(time)
+	keys := make([][]byte, 0, 100)
+	symbols := make([][]byte, 0, 100)
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+		symbols = append(symbols, iter.Value())
+	}
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	for _, symbol := range symbols {
+		store.Delete(getDelistRevKey(string(symbol)))
+	}
+}
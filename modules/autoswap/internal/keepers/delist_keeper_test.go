@@ -0,0 +1,82 @@
+package keepers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	"github.com/coinexchain/cet-sdk/testapp"
+)
+
+func TestRemoveDeListRequestsBeforeTime(t *testing.T) {
+	app := testapp.NewTestApp()
+	ctx := app.NewCtx()
+	keeper := app.AutoSwapKeeper
+	keeper.AddDelistRequest(ctx, 100, "aaa/b")
+	keeper.AddDelistRequest(ctx, 200, "bbb/b")
+	keeper.AddDelistRequest(ctx, 300, "ccc/b")
+	s := keeper.GetDelistSymbolsBeforeTime(ctx, 200)
+	require.Equal(t, []string{"aaa/b", "bbb/b"}, s)
+	require.True(t, keeper.HasDelistRequest(ctx, "aaa/b"))
+	require.True(t, keeper.HasDelistRequest(ctx, "ccc/b"))
+	require.EqualValues(t, 300, keeper.GetDelistRequestTime(ctx, "ccc/b"))
+	keeper.RemoveDelistRequestsBeforeTime(ctx, 200)
+	s = keeper.GetDelistSymbolsBeforeTime(ctx, 300)
+	require.Equal(t, []string{"ccc/b"}, s)
+	require.False(t, keeper.HasDelistRequest(ctx, "aaa/b"))
+	require.False(t, keeper.HasDelistRequest(ctx, "bbb/b"))
+	require.EqualValues(t, 0, keeper.GetDelistRequestTime(ctx, "bbb/b"))
+	require.True(t, keeper.HasDelistRequest(ctx, "ccc/b"))
+}
+
+func TestCancelAllOrdersAndWithdrawAllLiquidity(t *testing.T) {
+	boss := sdk.AccAddress("boss")
+	maker := sdk.AccAddress("maker")
+	lp1 := sdk.AccAddress("lp1")
+	lp2 := sdk.AccAddress("lp2")
+	th := newTestHelper(t)
+	poolAddr := supply.NewModuleAddress(types.PoolModuleAcc)
+
+	btc := th.issueToken("btc0", 100000000000000, boss)
+	usd := th.issueToken("usd0", 100000000000000, boss)
+	pair := th.createPair(maker, btc.sym, usd.sym, 0)
+	pair.mint(10000, 1000000, lp1)
+	pair.mint(5000, 500000, lp2)
+	btc.transfer(poolAddr, 15000, boss)
+	usd.transfer(poolAddr, 1500000, boss)
+
+	// the orders are away from the price of the pool, so they rest in the order book
+	btc.transfer(maker, 1000, boss)
+	usd.transfer(maker, 10000, boss)
+	pair.addLimitOrder(false, maker, 100, 200, 1)
+	pair.addLimitOrder(true, maker, 100, 50, 2)
+	require.Equal(t, 2, len(th.app.AutoSwapKeeper.GetAllOrders(th.ctx, pair.sym)))
+	require.Equal(t, PairBooked{bookedStock: 100, bookedMoney: 5000}, pair.getBooked())
+
+	k := th.app.AutoSwapKeeper
+	k.CancelAllOrders(th.ctx, pair.sym, types.CancelOrderByDelist)
+	require.Equal(t, 0, len(k.GetAllOrders(th.ctx, pair.sym)))
+	require.Equal(t, PairBooked{}, pair.getBooked())
+	require.True(t, th.app.BankxKeeper.GetFrozenCoins(th.ctx, maker).IsZero())
+	require.Equal(t, 1000, btc.balanceOf(maker))
+	require.Equal(t, 10000, usd.balanceOf(maker))
+
+	k.WithdrawAllLiquidity(th.ctx, pair.sym)
+	require.Equal(t, PairReserves{}, pair.getReserves())
+	require.True(t, pair.getPoolInfo().TotalSupply.IsZero())
+	require.True(t, pair.getLiquidity(lp1).IsZero())
+	require.True(t, pair.getLiquidity(lp2).IsZero())
+	require.Equal(t, 10000, btc.balanceOf(lp1))
+	require.Equal(t, 1000000, usd.balanceOf(lp1))
+	require.Equal(t, 5000, btc.balanceOf(lp2))
+	require.Equal(t, 500000, usd.balanceOf(lp2))
+	require.Equal(t, 0, btc.balanceOf(poolAddr))
+	require.Equal(t, 0, usd.balanceOf(poolAddr))
+
+	k.ClearPoolInfo(th.ctx, pair.sym)
+	require.Nil(t, pair.getPoolInfo())
+}
@@ -2,12 +2,16 @@ package keepers
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
 )
 
 //var
 
 type FactoryInterface interface {
 	CreatePair(ctx sdk.Context, owner sdk.AccAddress, symbol string, pricePrecision byte)
+	CreateRangePair(ctx sdk.Context, owner sdk.AccAddress, symbol string, pricePrecision byte, initPrice sdk.Dec)
+	CreateStablePair(ctx sdk.Context, owner sdk.AccAddress, symbol string, pricePrecision byte, amplification int64)
 	QueryPair(ctx sdk.Context, marketSymbol string) *PoolInfo
 }
 
@@ -17,7 +21,30 @@ type FactoryKeeper struct {
 }
 
 func (f FactoryKeeper) CreatePair(ctx sdk.Context, owner sdk.AccAddress, symbol string, pricePrecision byte) {
-	p := &PoolInfo{
+	f.poolKeeper.SetPoolInfo(ctx, symbol, newPoolInfo(owner, symbol, pricePrecision))
+}
+
+// CreateRangePair creates a pool whose liquidity is provided within price ranges, starting at initPrice
+func (f FactoryKeeper) CreateRangePair(ctx sdk.Context, owner sdk.AccAddress, symbol string, pricePrecision byte, initPrice sdk.Dec) {
+	p := newPoolInfo(owner, symbol, pricePrecision)
+	p.RangeLiquidity = true
+	p.SqrtPrice = sqrtDec(initPrice)
+	p.ActiveLiquidity = sdk.ZeroInt()
+	p.FeeGrowthStock = sdk.ZeroDec()
+	p.FeeGrowthMoney = sdk.ZeroDec()
+	f.poolKeeper.SetPoolInfo(ctx, symbol, p)
+}
+
+// CreateStablePair creates a pool on the StableSwap curve, for the pairs of pegged assets
+func (f FactoryKeeper) CreateStablePair(ctx sdk.Context, owner sdk.AccAddress, symbol string, pricePrecision byte, amplification int64) {
+	p := newPoolInfo(owner, symbol, pricePrecision)
+	p.CurveType = types.CurveStableSwap
+	p.Amplification = amplification
+	f.poolKeeper.SetPoolInfo(ctx, symbol, p)
+}
+
+func newPoolInfo(owner sdk.AccAddress, symbol string, pricePrecision byte) *PoolInfo {
+	return &PoolInfo{
 		Owner:                 owner,
 		Symbol:                symbol,
 		StockAmmReserve:       sdk.ZeroInt(),
@@ -26,8 +53,8 @@ func (f FactoryKeeper) CreatePair(ctx sdk.Context, owner sdk.AccAddress, symbol
 		MoneyOrderBookReserve: sdk.ZeroInt(),
 		TotalSupply:           sdk.ZeroInt(),
 		PricePrecision:        pricePrecision,
+		KLast:                 sdk.ZeroInt(),
 	}
-	f.poolKeeper.SetPoolInfo(ctx, symbol, p)
 }
 
 func (f FactoryKeeper) QueryPair(ctx sdk.Context, marketSymbol string) *PoolInfo {
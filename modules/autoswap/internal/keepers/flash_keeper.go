@@ -0,0 +1,101 @@
+package keepers
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+)
+
+// FlashLoan is the amounts a borrower takes out of the AMM reserves of a pool within a tx, which must be
+// repaid with the fees by a later msg of the same tx. The reserves of the pool are not changed by the loan.
+type FlashLoan struct {
+	Symbol      string         `json:"symbol"`
+	Borrower    sdk.AccAddress `json:"borrower"`
+	StockAmount sdk.Int        `json:"stock_amount"`
+	MoneyAmount sdk.Int        `json:"money_amount"`
+	StockFee    sdk.Int        `json:"stock_fee"`
+	MoneyFee    sdk.Int        `json:"money_fee"`
+}
+
+// FlashBorrow lends stockAmount and moneyAmount out of the lendable AMM reserves of a pool to borrower,
+// charging the fee of dealing with the pool on them. The caller sends the amounts to the borrower.
+func (pk *PairKeeper) FlashBorrow(ctx sdk.Context, marketSymbol string, borrower sdk.AccAddress, stockAmount, moneyAmount sdk.Int) sdk.Error {
+	info := pk.GetPoolInfo(ctx, marketSymbol)
+	if info == nil {
+		return types.ErrPairIsNotExist()
+	}
+	loan := pk.GetFlashLoan(ctx, marketSymbol, borrower)
+	if loan == nil {
+		loan = &FlashLoan{
+			Symbol:      marketSymbol,
+			Borrower:    borrower,
+			StockAmount: sdk.ZeroInt(),
+			MoneyAmount: sdk.ZeroInt(),
+			StockFee:    sdk.ZeroInt(),
+			MoneyFee:    sdk.ZeroInt(),
+		}
+	}
+	loan.StockAmount = loan.StockAmount.Add(stockAmount)
+	loan.MoneyAmount = loan.MoneyAmount.Add(moneyAmount)
+	stockLendable, moneyLendable := info.LendableAmounts()
+	if loan.StockAmount.GT(stockLendable) || loan.MoneyAmount.GT(moneyLendable) {
+		return types.ErrInvalidFlashLoan("the pool doesn't have enough reserves")
+	}
+	feeRate := pk.GetParams(ctx).DealWithPoolFeeRate
+	loan.StockFee = calDealWithPoolFee(loan.StockAmount, feeRate)
+	loan.MoneyFee = calDealWithPoolFee(loan.MoneyAmount, feeRate)
+	pk.setFlashLoan(ctx, *loan)
+	return nil
+}
+
+// FlashRepay closes the flash loan of borrower from a pool, and returns the amounts to repay, which
+// include the fees. The fees are added to the pool, and the caller sends the amounts to the pool.
+func (pk *PairKeeper) FlashRepay(ctx sdk.Context, marketSymbol string, borrower sdk.AccAddress) (stockIn, moneyIn sdk.Int, err sdk.Error) {
+	loan := pk.GetFlashLoan(ctx, marketSymbol, borrower)
+	if loan == nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), types.ErrInvalidFlashLoan("nothing is borrowed from pool " + marketSymbol)
+	}
+	info := pk.GetPoolInfo(ctx, marketSymbol)
+	if info == nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), types.ErrPairIsNotExist()
+	}
+	pk.clearFlashLoan(ctx, marketSymbol, borrower)
+	if err = pk.addFeeToPool(ctx, info, loan.StockFee, loan.MoneyFee); err != nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), err
+	}
+	pk.SetPoolInfo(ctx, marketSymbol, info)
+	return loan.StockAmount.Add(loan.StockFee), loan.MoneyAmount.Add(loan.MoneyFee), nil
+}
+
+// LendableAmounts returns the most stock and money which can be lent out of a pool. The reserves of
+// a pool with range liquidity can only be lent as much as its range positions can pay out by trading.
+func (p PoolInfo) LendableAmounts() (stock, money sdk.Int) {
+	if !p.RangeLiquidity {
+		return p.StockAmmReserve, p.MoneyAmmReserve
+	}
+	stock = sdk.MinInt(p.rangeSwap(sdk.Int{}, true, sdk.Dec{}).amountOut, p.StockAmmReserve)
+	money = sdk.MinInt(p.rangeSwap(sdk.Int{}, false, sdk.Dec{}).amountOut, p.MoneyAmmReserve)
+	return stock, money
+}
+
+func (pk *PairKeeper) setFlashLoan(ctx sdk.Context, loan FlashLoan) {
+	store := ctx.KVStore(pk.storeKey)
+	store.Set(getFlashLoanKey(loan.Symbol, loan.Borrower), pk.codec.MustMarshalBinaryBare(loan))
+}
+
+func (pk *PairKeeper) clearFlashLoan(ctx sdk.Context, marketSymbol string, borrower sdk.AccAddress) {
+	store := ctx.KVStore(pk.storeKey)
+	store.Delete(getFlashLoanKey(marketSymbol, borrower))
+}
+
+// GetFlashLoan returns the flash loan of borrower from a pool, which is nil out of the tx of the loan
+func (pk *PairKeeper) GetFlashLoan(ctx sdk.Context, marketSymbol string, borrower sdk.AccAddress) *FlashLoan {
+	store := ctx.KVStore(pk.storeKey)
+	bytes := store.Get(getFlashLoanKey(marketSymbol, borrower))
+	if bytes == nil {
+		return nil
+	}
+	loan := &FlashLoan{}
+	pk.codec.MustUnmarshalBinaryBare(bytes, loan)
+	return loan
+}
@@ -16,18 +16,23 @@ type Keeper struct {
 	sk       types.SupplyKeeper
 	FactoryInterface
 	IPairKeeper
+	*DelistKeeper
 	types.ExpectedAssetKeeper
 	msgProducer msgqueue.MsgSender
 }
 
 func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey,
 	paramSubspace params.Subspace, bk types.ExpectedBankKeeper, accK types.ExpectedAccountKeeper,
-	accxK types.ExpectedAuthXKeeper, sk types.SupplyKeeper, assetK types.ExpectedAssetKeeper) *Keeper {
+	accxK types.ExpectedAuthXKeeper, sk types.SupplyKeeper, assetK types.ExpectedAssetKeeper,
+	oracleK types.ExpectedOracleKeeper) *Keeper {
 
+	paramSubspace = paramSubspace.WithKeyTable(types.ParamKeyTable())
 	poolK := PoolKeeper{
 		key:          storeKey,
 		codec:        cdc,
 		SupplyKeeper: sk,
+		oracle:       oracleK,
+		subspace:     paramSubspace,
 	}
 
 	factoryK := FactoryKeeper{
@@ -40,6 +45,7 @@ func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey,
 		storeKey:            storeKey,
 		sk:                  sk,
 		FactoryInterface:    factoryK,
+		DelistKeeper:        NewDelistKeeper(storeKey),
 		ExpectedAssetKeeper: assetK,
 	}
 	k.IPairKeeper = NewPairKeeper(poolK, sk, bk, accK, accxK, cdc, storeKey, paramSubspace)
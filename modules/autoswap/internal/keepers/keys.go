@@ -17,6 +17,17 @@ var (
 	BidOrderKey         = []byte{0x07}
 	AskOrderKey         = []byte{0x08}
 	OrderMarketKey      = []byte{0x09}
+	DelistKey           = []byte{0x0A}
+	DelistRevKey        = []byte{0x0B}
+	RangePositionKey    = []byte{0x0C}
+	RangePositionEndKey = []byte{0x0D}
+	RewardProgramKey    = []byte{0x0E}
+	RewardProgramEndKey = []byte{0x0F}
+	RewardKey           = []byte{0x10}
+	RewardEndKey        = []byte{0x11}
+	FlashLoanKey        = []byte{0x12}
+	TickKey             = []byte{0x13}
+	TickEndKey          = []byte{0x14}
 )
 
 var (
@@ -28,6 +39,53 @@ func getLiquidityKey(marketSymbol string, address sdk.AccAddress) []byte {
 	return append(append(PoolLiquidityKey, marketSymbol...), address.Bytes()...)
 }
 
+// getRangePositionKey key = prefix | Symbol | 0x0 | owner | lowerPrice | upperPrice
+// value = RangePosition
+func getRangePositionKey(symbol string, owner sdk.AccAddress, lowerPrice, upperPrice sdk.Dec) []byte {
+	return append(append(append(getRangePositionsBegin(symbol), owner...),
+		market.DecToBigEndianBytes(lowerPrice)...), market.DecToBigEndianBytes(upperPrice)...)
+}
+
+func getRangePositionsBegin(symbol string) []byte {
+	return append(append(RangePositionKey, symbol...), 0x0)
+}
+
+func getRangePositionsEnd(symbol string) []byte {
+	return append(append(RangePositionKey, symbol...), 0x1)
+}
+
+// getTickKey key = prefix | Symbol | 0x0 | price
+// value = Tick
+func getTickKey(symbol string, price sdk.Dec) []byte {
+	return append(getTicksBegin(symbol), market.DecToBigEndianBytes(price)...)
+}
+
+func getTicksBegin(symbol string) []byte {
+	return append(append(TickKey, symbol...), 0x0)
+}
+
+func getTicksEnd(symbol string) []byte {
+	return append(append(TickKey, symbol...), 0x1)
+}
+
+// getRewardProgramKey key = prefix | Symbol
+// value = RewardProgram
+func getRewardProgramKey(symbol string) []byte {
+	return append(RewardProgramKey, symbol...)
+}
+
+// getRewardKey key = prefix | Symbol | 0x0 | owner
+// value = LiquidityReward
+func getRewardKey(symbol string, owner sdk.AccAddress) []byte {
+	return append(append(append(RewardKey, symbol...), 0x0), owner...)
+}
+
+// getFlashLoanKey key = prefix | Symbol | 0x0 | borrower
+// value = FlashLoan
+func getFlashLoanKey(symbol string, borrower sdk.AccAddress) []byte {
+	return append(append(append(FlashLoanKey, symbol...), 0x0), borrower...)
+}
+
 // getPairKey key = prefix | Symbol
 // value = PoolInfo
 func getPairKey(symbol string) []byte {
@@ -22,6 +22,7 @@ type IOrderBookKeeper interface {
 	GetOrder(sdk.Context, *market.QueryOrderParam) *types.Order
 	GetBestPrice(ctx sdk.Context, market string, isBuy bool) sdk.Dec
 	GetMatchedOrder(ctx sdk.Context, order *types.Order) []*types.Order
+	IterateOrdersFromBestPrice(ctx sdk.Context, tradingPair string, isBuy bool, process func(order *types.Order) bool)
 	OrderIndexInOneBlock() int32
 	ResetOrderIndexInOneBlock()
 }
@@ -211,6 +212,27 @@ func (o OrderKeeper) GetMatchedOrder(ctx sdk.Context, order *types.Order) []*typ
 	return inChronologicalOrders(order, oppositeOrders)
 }
 
+// Iterate the bid queue or the ask queue from the best price to the worst, until process returns false
+func (o OrderKeeper) IterateOrdersFromBestPrice(ctx sdk.Context, tradingPair string, isBuy bool, process func(order *types.Order) bool) {
+	var (
+		iter  sdk.Iterator
+		store = ctx.KVStore(o.storeKey)
+	)
+	begin, end := getBidOrAskQueueBeginEndKey(tradingPair, isBuy)
+	if isBuy {
+		iter = store.ReverseIterator(begin, end)
+	} else {
+		iter = store.Iterator(begin, end)
+	}
+	defer iter.Close()
+	orderIDPos := getOrderIDPos(tradingPair)
+	for ; iter.Valid(); iter.Next() {
+		if !process(o.getOrder(store, string(iter.Key()[orderIDPos:]))) {
+			return
+		}
+	}
+}
+
 func (o OrderKeeper) getOrder(store sdk.KVStore, orderID string) *types.Order {
 	order := types.Order{}
 	val := store.Get(getOrderBookKey(orderID))
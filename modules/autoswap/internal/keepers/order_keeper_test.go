@@ -319,3 +319,40 @@ func TestOrderKeeper_GetMatchedOrder(t *testing.T) {
 	orderKeeper.AddOrder(ctx, sellOrders[4])
 	require.EqualValues(t, 2, len(orderKeeper.GetMatchedOrder(ctx, sellOrders[4])), "should have 1 matched order")
 }
+
+func TestOrderKeeper_IterateOrdersFromBestPrice(t *testing.T) {
+	ctx, storeKey := newContextAndStoreKey(t)
+	orderKeeper := NewOrderKeeper(codec.New(), storeKey)
+	tradingPair := "abc/def"
+	prices := []int64{30, 10, 20}
+	for i, price := range prices {
+		for _, isBuy := range []bool{true, false} {
+			order := types.Order{
+				TradingPair: tradingPair,
+				Sender:      supply.NewModuleAddress("aass"),
+				Sequence:    int64(i),
+				Identify:    1,
+				Price:       sdk.NewDec(price),
+				Quantity:    100,
+				IsBuy:       isBuy,
+				LeftStock:   100,
+			}
+			if !isBuy {
+				order.Identify = 2
+			}
+			orderKeeper.AddOrder(ctx, &order)
+		}
+	}
+
+	collect := func(isBuy bool, limit int) []sdk.Dec {
+		res := make([]sdk.Dec, 0)
+		orderKeeper.IterateOrdersFromBestPrice(ctx, tradingPair, isBuy, func(order *types.Order) bool {
+			res = append(res, order.Price)
+			return len(res) < limit
+		})
+		return res
+	}
+	require.Equal(t, []sdk.Dec{sdk.NewDec(30), sdk.NewDec(20), sdk.NewDec(10)}, collect(true, 10))
+	require.Equal(t, []sdk.Dec{sdk.NewDec(10), sdk.NewDec(20), sdk.NewDec(30)}, collect(false, 10))
+	require.Equal(t, []sdk.Dec{sdk.NewDec(30), sdk.NewDec(20)}, collect(true, 2))
+}
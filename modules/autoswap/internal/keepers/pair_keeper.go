@@ -7,6 +7,7 @@ import (
 
 	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
 	"github.com/coinexchain/cet-sdk/msgqueue"
+	dex "github.com/coinexchain/cet-sdk/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -22,11 +23,23 @@ type Pair struct {
 type IPairKeeper interface {
 	IPoolKeeper
 	AddLimitOrder(ctx sdk.Context, order *types.Order) sdk.Error
+	AddOrder(ctx sdk.Context, order *types.Order)
 	DeleteOrder(ctx sdk.Context, order types.MsgAutoSwapCancelOrder) sdk.Error
 	HasOrder(ctx sdk.Context, orderID string) bool
 	GetOrder(ctx sdk.Context, orderID string) *types.Order
 	GetAllOrders(ctx sdk.Context, market string) []*types.Order
 	GetOrdersFromUser(ctx sdk.Context, user string) []string
+	IterateOrdersFromBestPrice(ctx sdk.Context, tradingPair string, isBuy bool, process func(order *types.Order) bool)
+	CancelAllOrders(ctx sdk.Context, tradingPair string, delReason string)
+	WithdrawAllLiquidity(ctx sdk.Context, tradingPair string)
+	CloseRewardProgram(ctx sdk.Context, tradingPair string)
+	GetAmountsOut(ctx sdk.Context, path []string, amountIn sdk.Int) ([]sdk.Int, sdk.Error)
+	GetAmountsIn(ctx sdk.Context, path []string, amountOut sdk.Int) ([]sdk.Int, sdk.Error)
+	SwapExactIn(ctx sdk.Context, sender, to sdk.AccAddress, path []string, amountIn, minAmountOut sdk.Int) ([]sdk.Int, sdk.Error)
+	SwapExactOut(ctx sdk.Context, sender, to sdk.AccAddress, path []string, amountOut, maxAmountIn sdk.Int) ([]sdk.Int, sdk.Error)
+	FlashBorrow(ctx sdk.Context, marketSymbol string, borrower sdk.AccAddress, stockAmount, moneyAmount sdk.Int) sdk.Error
+	FlashRepay(ctx sdk.Context, marketSymbol string, borrower sdk.AccAddress) (stockIn, moneyIn sdk.Int, err sdk.Error)
+	GetFlashLoan(ctx sdk.Context, marketSymbol string, borrower sdk.AccAddress) *FlashLoan
 
 	SetParams(ctx sdk.Context, params types.Params)
 	GetParams(ctx sdk.Context) types.Params
@@ -63,7 +76,7 @@ func NewPairKeeper(poolKeeper IPoolKeeper, supplyK types.SupplyKeeper, bnk types
 		ExpectedBankKeeper:    bnk,
 		ExpectedAccountKeeper: accK,
 		ExpectedAuthXKeeper:   accxK,
-		subspace:              paramSubspace.WithKeyTable(types.ParamKeyTable()),
+		subspace:              paramSubspace,
 		IOrderBookKeeper: &OrderKeeper{
 			codec:    codec,
 			storeKey: storeKey,
@@ -308,7 +321,16 @@ func (pk PairKeeper) tryDealInPool(dealInfo *types.DealInfo, dealPrice sdk.Dec,
 	return false
 }
 
+// IntoPoolAmountTillPrice returns the amount to trade with the pool for moving its price to dealPrice.
+// The ticks on the way are crossed in a pool with range liquidity.
+// The price moves along the StableSwap curve in a pool of pegged assets.
 func IntoPoolAmountTillPrice(dealPrice sdk.Dec, isBuy bool, info *PoolInfo) sdk.Int {
+	if info.RangeLiquidity {
+		return info.rangeSwap(sdk.Int{}, isBuy, sqrtDec(dealPrice)).amountIn
+	}
+	if info.CurveType == types.CurveStableSwap {
+		return info.stableAmountTillPrice(dealPrice, isBuy)
+	}
 	if isBuy {
 		root := dealPrice.Mul(sdk.NewDecFromInt(info.StockAmmReserve)).Mul(sdk.NewDecFromInt(info.MoneyAmmReserve)).MulInt64(int64(math.Pow10(10)))
 		root = sdk.NewDecFromBigInt(sdk.NewDec(0).Sqrt(root.TruncateInt().BigInt()))
@@ -332,6 +354,12 @@ func IntoPoolAmountTillPrice(dealPrice sdk.Dec, isBuy bool, info *PoolInfo) sdk.
 }
 
 func GetAmountOutInPool(amountIn sdk.Int, poolInfo *PoolInfo, isBuy bool) sdk.Int {
+	if poolInfo.RangeLiquidity {
+		return poolInfo.rangeSwap(amountIn, isBuy, sdk.Dec{}).amountOut
+	}
+	if poolInfo.CurveType == types.CurveStableSwap {
+		return poolInfo.stableAmountOut(amountIn, isBuy)
+	}
 	outPoolTokenReserve, inPoolTokenReserve := poolInfo.MoneyAmmReserve, poolInfo.StockAmmReserve
 	if isBuy {
 		outPoolTokenReserve, inPoolTokenReserve = poolInfo.StockAmmReserve, poolInfo.MoneyAmmReserve
@@ -339,6 +367,25 @@ func GetAmountOutInPool(amountIn sdk.Int, poolInfo *PoolInfo, isBuy bool) sdk.In
 	return outPoolTokenReserve.Mul(amountIn).Quo(inPoolTokenReserve.Add(amountIn))
 }
 
+// getAmountInInPool returns the smallest amount in which makes GetAmountOutInPool not less than amountOut,
+// ok is false if the pool doesn't have enough liquidity
+func getAmountInInPool(amountOut sdk.Int, poolInfo *PoolInfo, isBuy bool) (amountIn sdk.Int, ok bool) {
+	if poolInfo.RangeLiquidity {
+		return poolInfo.rangeAmountIn(amountOut, isBuy)
+	}
+	if poolInfo.CurveType == types.CurveStableSwap {
+		return poolInfo.stableAmountIn(amountOut, isBuy)
+	}
+	outPoolTokenReserve, inPoolTokenReserve := poolInfo.MoneyAmmReserve, poolInfo.StockAmmReserve
+	if isBuy {
+		outPoolTokenReserve, inPoolTokenReserve = poolInfo.StockAmmReserve, poolInfo.MoneyAmmReserve
+	}
+	if !inPoolTokenReserve.IsPositive() || amountOut.GTE(outPoolTokenReserve) {
+		return sdk.ZeroInt(), false
+	}
+	return inPoolTokenReserve.Mul(amountOut).Quo(outPoolTokenReserve.Sub(amountOut)).AddRaw(1), true
+}
+
 func (pk PairKeeper) dealInOrderBook(ctx sdk.Context, currOrder,
 	orderInBook *types.Order, poolInfo *PoolInfo, dealInfo *types.DealInfo, isPoolExists bool) {
 	if currOrder.LeftStock == 0 {
@@ -558,27 +605,36 @@ func (pk PairKeeper) finalDealWithPool(ctx sdk.Context, order *types.Order, deal
 	if dealInfo.AmountInToPool.IsPositive() {
 		pk.sendDealInfoWithPool(ctx, dealInfo, order, fee.Int64(), poolToUser.Int64(), poolInfo, rebateAmount, referenceAddr)
 	}
-	poolInfo.MoneyAmmReserve = poolInfo.MoneyAmmReserve.Add(dealInfo.FeeToMoneyReserve)
-	poolInfo.StockAmmReserve = poolInfo.StockAmmReserve.Add(dealInfo.FeeToStockReserve)
+	if err := pk.addFeeToPool(ctx, poolInfo, dealInfo.FeeToStockReserve, dealInfo.FeeToMoneyReserve); err != nil {
+		panic(err)
+	}
+}
+
+// addFeeToPool adds the fees paid to a pool, and the fees which no liquidity of the pool
+// can earn are sent to the fee collector
+func (pk PairKeeper) addFeeToPool(ctx sdk.Context, info *PoolInfo, stockFee, moneyFee sdk.Int) sdk.Error {
+	stockLeft, moneyLeft := info.addFeeToPool(stockFee, moneyFee)
+	stock, money := dex.SplitSymbol(info.Symbol)
+	coins := newCoins(stock, stockLeft).Add(newCoins(money, moneyLeft))
+	if coins.IsZero() {
+		return nil
+	}
+	return pk.SendCoinsFromModuleToModule(ctx, types.PoolModuleAcc, auth.FeeCollectorName, coins)
 }
 
 func (pk PairKeeper) dealWithPoolAndCollectFee(ctx sdk.Context, order *types.Order, dealInfo *types.DealInfo, poolInfo *PoolInfo) (rebateAmount sdk.Int, referenceAddr sdk.AccAddress, fee sdk.Int, poolToUser sdk.Int) {
-	outAmount := sdk.ZeroInt()
-	if dealInfo.AmountInToPool.IsPositive() {
-		outAmount = GetAmountOutInPool(dealInfo.AmountInToPool, poolInfo, order.IsBuy)
-	} else {
+	if !dealInfo.AmountInToPool.IsPositive() {
 		return sdk.ZeroInt(), nil, sdk.ZeroInt(), sdk.ZeroInt()
 	}
+	outAmount, usedAmount := poolInfo.dealWithAmm(dealInfo.AmountInToPool, order.IsBuy)
+	if unused := dealInfo.AmountInToPool.Sub(usedAmount); unused.IsPositive() {
+		// the liquidity of the pool runs out, the unused amount stays in the order
+		pk.returnUnusedToOrder(order, poolInfo, unused)
+		dealInfo.AmountInToPool = usedAmount
+	}
 	// add fee calculate
 	feeRate := pk.GetParams(ctx).DealWithPoolFeeRate
-	fee = outAmount.Mul(sdk.NewInt(feeRate)).Add(sdk.NewInt(9999)).Quo(sdk.NewInt(types.DefaultFeePrecision))
-	if order.IsBuy {
-		poolInfo.MoneyAmmReserve = poolInfo.MoneyAmmReserve.Add(dealInfo.AmountInToPool)
-		poolInfo.StockAmmReserve = poolInfo.StockAmmReserve.Sub(outAmount)
-	} else {
-		poolInfo.StockAmmReserve = poolInfo.StockAmmReserve.Add(dealInfo.AmountInToPool)
-		poolInfo.MoneyAmmReserve = poolInfo.MoneyAmmReserve.Sub(outAmount)
-	}
+	fee = calDealWithPoolFee(outAmount, feeRate)
 	var (
 		err         sdk.Error
 		stockToPool sdk.Int
@@ -592,7 +648,9 @@ func (pk PairKeeper) dealWithPoolAndCollectFee(ctx sdk.Context, order *types.Ord
 		if err != nil {
 			panic(err)
 		}
-		dealInfo.FeeToStockReserve = dealInfo.FeeToStockReserve.Add(stockToPool)
+		if err := pk.addFeeToPool(ctx, poolInfo, stockToPool, sdk.ZeroInt()); err != nil {
+			panic(err)
+		}
 		if err := pk.UnFreezeCoins(ctx, order.Sender, newCoins(order.Money(), dealInfo.AmountInToPool)); err != nil {
 			panic(err)
 		}
@@ -607,7 +665,9 @@ func (pk PairKeeper) dealWithPoolAndCollectFee(ctx sdk.Context, order *types.Ord
 		if err != nil {
 			panic(err)
 		}
-		dealInfo.FeeToMoneyReserve = dealInfo.FeeToMoneyReserve.Add(moneyToPool)
+		if err := pk.addFeeToPool(ctx, poolInfo, sdk.ZeroInt(), moneyToPool); err != nil {
+			panic(err)
+		}
 		if err := pk.UnFreezeCoins(ctx, order.Sender, newCoins(order.Stock(), dealInfo.AmountInToPool)); err != nil {
 			panic(err)
 		}
@@ -618,6 +678,20 @@ func (pk PairKeeper) dealWithPoolAndCollectFee(ctx sdk.Context, order *types.Ord
 	return rebateAmount, referenceAddr, fee, outAmount
 }
 
+// returnUnusedToOrder gives back the part of the amount to trade with the pool which the pool can't take,
+// and keeps it in the order book
+func (pk PairKeeper) returnUnusedToOrder(order *types.Order, poolInfo *PoolInfo, unused sdk.Int) {
+	order.Freeze += unused.Int64()
+	if order.IsBuy {
+		order.DealMoney -= unused.Int64()
+		poolInfo.MoneyOrderBookReserve = poolInfo.MoneyOrderBookReserve.Add(unused)
+	} else {
+		order.LeftStock += unused.Int64()
+		order.DealStock -= unused.Int64()
+		poolInfo.StockOrderBookReserve = poolInfo.StockOrderBookReserve.Add(unused)
+	}
+}
+
 func (pk PairKeeper) sendDealInfoWithPool(ctx sdk.Context, dealInfo *types.DealInfo,
 	order *types.Order, commission, poolAmount int64, poolInfo *PoolInfo, rebateAmount sdk.Int, referenceAddr sdk.AccAddress) {
 	if pk.msgProducer == nil || pk.msgProducer.IsSubscribed(types.ModuleName) {
@@ -704,6 +778,71 @@ func (pk *PairKeeper) DeleteOrder(ctx sdk.Context, msg types.MsgAutoSwapCancelOr
 	return nil
 }
 
+// CancelAllOrders removes all the resting orders of a trading pair and unfreezes their coins
+func (pk *PairKeeper) CancelAllOrders(ctx sdk.Context, tradingPair string, delReason string) {
+	for _, order := range pk.GetAllOrders(ctx, tradingPair) {
+		pk.IOrderBookKeeper.DelOrder(ctx, order)
+		if err := pk.updateOrderBookReserveByOrderDel(ctx, order); err != nil {
+			ctx.Logger().Error(err.Error())
+		}
+		pk.sendDelOrderInfo(ctx, order, delReason)
+	}
+}
+
+// WithdrawAllLiquidity burns all the liquidity of a trading pair and sends the reserves to the
+// liquidity providers pro rata. The last provider burns all the remaining supply, so no dust is left.
+// The range positions are removed with their fees sent to the owners.
+func (pk *PairKeeper) WithdrawAllLiquidity(ctx sdk.Context, tradingPair string) {
+	stock, money := dex.SplitSymbol(tradingPair)
+	for _, pos := range pk.GetRangePositions(ctx, tradingPair) {
+		stockOut, moneyOut, err := pk.RemoveRangeLiquidity(ctx, tradingPair, pos.Owner, pos.LowerPrice, pos.UpperPrice, pos.Liquidity)
+		if err != nil {
+			ctx.Logger().Error(err.Error())
+			continue
+		}
+		coins := newCoins(stock, stockOut).Add(newCoins(money, moneyOut))
+		if err := pk.SendCoinsFromModuleToAccount(ctx, types.PoolModuleAcc, pos.Owner, coins); err != nil {
+			ctx.Logger().Error(err.Error())
+		}
+	}
+	// the first burn may mint the protocol fee to the fee receiver, who is paid in the second round
+	for round := 0; round < 2; round++ {
+		var infos []LiquidityInfo
+		pk.IterateAllLiquidityInfo(ctx, func(li LiquidityInfo) {
+			if li.Symbol == tradingPair {
+				infos = append(infos, li)
+			}
+		})
+		for _, li := range infos {
+			stockOut, moneyOut, err := pk.Burn(ctx, tradingPair, li.Owner, li.Liquidity)
+			if err != nil {
+				ctx.Logger().Error(err.Error())
+				continue
+			}
+			coins := newCoins(stock, stockOut).Add(newCoins(money, moneyOut))
+			if err := pk.SendCoinsFromModuleToAccount(ctx, types.PoolModuleAcc, li.Owner, coins); err != nil {
+				ctx.Logger().Error(err.Error())
+			}
+		}
+	}
+}
+
+// CloseRewardProgram removes the reward program of a trading pair, sends the rewards not claimed yet to
+// the liquidity providers and refunds what remains of the program to the funders pro rata.
+func (pk *PairKeeper) CloseRewardProgram(ctx sdk.Context, tradingPair string) {
+	denom, rewards, refunds := pk.RemoveRewardProgram(ctx, tradingPair)
+	for _, reward := range rewards {
+		if err := pk.SendCoinsFromModuleToAccount(ctx, types.PoolModuleAcc, reward.Owner, newCoins(denom, reward.Unclaimed)); err != nil {
+			ctx.Logger().Error(err.Error())
+		}
+	}
+	for _, refund := range refunds {
+		if err := pk.SendCoinsFromModuleToAccount(ctx, types.PoolModuleAcc, refund.Address, newCoins(denom, refund.Amount)); err != nil {
+			ctx.Logger().Error(err.Error())
+		}
+	}
+}
+
 func (pk PairKeeper) updateOrderBookReserveByOrderDel(ctx sdk.Context, delOrder *types.Order) sdk.Error {
 	info := pk.GetPoolInfo(ctx, delOrder.TradingPair)
 	if delOrder.IsBuy {
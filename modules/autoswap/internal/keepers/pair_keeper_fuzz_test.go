@@ -88,7 +88,8 @@ func TestLiquidity(t *testing.T) {
 		market = fmt.Sprintf("%s/%s", stockSymbol, moneySymbol)
 	)
 	app := prepareTestApp(t)
-	app.AutoSwapKeeper.SetPoolInfo(app.ctx, market, &keepers.PoolInfo{Symbol: market, PricePrecision: 18})
+	app.AutoSwapKeeper.SetPoolInfo(app.ctx, market, &keepers.PoolInfo{Symbol: market, PricePrecision: 18,
+		StockAmmReserve: sdk.ZeroInt(), MoneyAmmReserve: sdk.ZeroInt()})
 	mintLiquidityTest(t, app, market)
 	burnLiquidityTest(t, app, market)
 	addLimitOrderTest(t, app, market)
@@ -7,21 +7,44 @@ import (
 	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 type IPoolKeeper interface {
 	SetPoolInfo(ctx sdk.Context, marketSymbol string, info *PoolInfo)
 	GetPoolInfo(ctx sdk.Context, marketSymbol string) *PoolInfo
 	GetPoolInfos(ctx sdk.Context) (infos []PoolInfo)
+	ClearPoolInfo(ctx sdk.Context, marketSymbol string)
 	SetLiquidity(ctx sdk.Context, marketSymbol string, address sdk.AccAddress, liquidity sdk.Int)
 	GetLiquidity(ctx sdk.Context, marketSymbol string, address sdk.AccAddress) sdk.Int
 	ClearLiquidity(ctx sdk.Context, marketSymbol string, address sdk.AccAddress)
 	GetAllLiquidityInfos(ctx sdk.Context) (infos []LiquidityInfo)
+	IterateAllLiquidityInfo(ctx sdk.Context, liquidityProc func(li LiquidityInfo))
 	Mint(ctx sdk.Context, marketSymbol string, stockAmountIn, moneyAmountIn sdk.Int, to sdk.AccAddress) (sdk.Int, sdk.Error)
 	Burn(ctx sdk.Context, marketSymbol string, from sdk.AccAddress, liquidity sdk.Int) (stockOut, moneyOut sdk.Int, err sdk.Error)
+	TransferLiquidity(ctx sdk.Context, marketSymbol string, from, to sdk.AccAddress, liquidity sdk.Int) sdk.Error
+	AddRangeLiquidity(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress, lowerPrice, upperPrice sdk.Dec,
+		stockAmount, moneyAmount sdk.Int) (liquidity, stockIn, moneyIn sdk.Int, err sdk.Error)
+	RemoveRangeLiquidity(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress, lowerPrice, upperPrice sdk.Dec,
+		liquidity sdk.Int) (stockOut, moneyOut sdk.Int, err sdk.Error)
+	SetRangePosition(ctx sdk.Context, pos RangePosition)
+	GetRangePosition(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress, lowerPrice, upperPrice sdk.Dec) *RangePosition
+	GetRangePositions(ctx sdk.Context, marketSymbol string) []RangePosition
+	IterateAllRangePositions(ctx sdk.Context, positionProc func(pos RangePosition))
+	SetTick(ctx sdk.Context, tick Tick)
+	GetTicks(ctx sdk.Context, marketSymbol string) []Tick
+	IterateAllTicks(ctx sdk.Context, tickProc func(tick Tick))
+	FundRewardProgram(ctx sdk.Context, marketSymbol string, funder sdk.AccAddress, denom string, amount, rewardPerBlock sdk.Int) sdk.Error
+	ClaimRewards(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress) (sdk.Coins, sdk.Error)
+	GetUnclaimedRewards(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress) sdk.Int
+	SetRewardProgram(ctx sdk.Context, prog RewardProgram)
+	GetRewardProgram(ctx sdk.Context, marketSymbol string) *RewardProgram
+	RemoveRewardProgram(ctx sdk.Context, marketSymbol string) (denom string, rewards []LiquidityReward, refunds []RewardFunder)
+	IterateRewardPrograms(ctx sdk.Context, progProc func(prog RewardProgram))
+	SetLiquidityReward(ctx sdk.Context, reward LiquidityReward)
+	IterateAllLiquidityRewards(ctx sdk.Context, rewardProc func(reward LiquidityReward))
 }
 
-//todo: _mintFee is not support
 type LiquidityInfo struct {
 	Symbol    string         `json:"symbol"`
 	Owner     sdk.AccAddress `json:"owner"`
@@ -32,6 +55,42 @@ type PoolKeeper struct {
 	key   sdk.StoreKey
 	codec *codec.Codec
 	types.SupplyKeeper
+	oracle   types.ExpectedOracleKeeper
+	subspace params.Subspace
+}
+
+// mintFee mints the protocol fee to the fee receiver as liquidity, which is 1/6 of the growth of
+// sqrt(k) since the last liquidity event, and returns whether the protocol fee is on.
+// k is the invariant of the curve, see PoolInfo.invariant
+func (p PoolKeeper) mintFee(ctx sdk.Context, marketSymbol string, info *PoolInfo) bool {
+	var param types.Params
+	p.subspace.GetIfExists(ctx, types.KeyFeeOn, &param.FeeOn)
+	info.setDefaultKLast()
+	if !param.FeeOn {
+		info.KLast = sdk.ZeroInt()
+		return false
+	}
+	p.subspace.Get(ctx, types.KeyFeeReceiver, &param.FeeReceiver)
+	if info.KLast.IsPositive() {
+		rootK := sqrtInt(info.invariant())
+		rootKLast := sqrtInt(info.KLast)
+		if rootK.GT(rootKLast) {
+			numerator := info.TotalSupply.Mul(rootK.Sub(rootKLast))
+			denominator := rootK.MulRaw(5).Add(rootKLast)
+			liquidity := numerator.Quo(denominator)
+			if liquidity.IsPositive() {
+				p.settleRewards(ctx, marketSymbol, info.TotalSupply, param.FeeReceiver)
+				info.TotalSupply = info.TotalSupply.Add(liquidity)
+				totalLiq := p.GetLiquidity(ctx, marketSymbol, param.FeeReceiver)
+				p.SetLiquidity(ctx, marketSymbol, param.FeeReceiver, totalLiq.Add(liquidity))
+			}
+		}
+	}
+	return true
+}
+
+func sqrtInt(x sdk.Int) sdk.Int {
+	return sdk.NewIntFromBigInt((&big.Int{}).Sqrt(x.BigInt()))
 }
 
 func (p PoolKeeper) Mint(ctx sdk.Context, marketSymbol string, stockAmountIn, moneyAmountIn sdk.Int, to sdk.AccAddress) (sdk.Int, sdk.Error) {
@@ -39,10 +98,16 @@ func (p PoolKeeper) Mint(ctx sdk.Context, marketSymbol string, stockAmountIn, mo
 	if info == nil {
 		return sdk.ZeroInt(), types.ErrPairIsNotExist()
 	}
+	if info.RangeLiquidity {
+		return sdk.ZeroInt(), types.ErrInvalidPoolType(marketSymbol, true)
+	}
+	p.settleRewards(ctx, marketSymbol, info.TotalSupply, to)
+	feeOn := p.mintFee(ctx, marketSymbol, info)
 	liquidity := sdk.ZeroInt()
 	if info.TotalSupply.IsZero() {
-		value, _ := (&big.Int{}).SetString(stockAmountIn.Mul(moneyAmountIn).String(), 10)
-		liquidity = sdk.NewIntFromBigInt(value.Sqrt(value))
+		first := *info
+		first.StockAmmReserve, first.MoneyAmmReserve = stockAmountIn, moneyAmountIn
+		liquidity = sqrtInt(first.invariant())
 	} else {
 		liquidity = stockAmountIn.Mul(info.TotalSupply).Quo(info.StockAmmReserve)
 		another := moneyAmountIn.Mul(info.TotalSupply).Quo(info.MoneyAmmReserve)
@@ -59,6 +124,9 @@ func (p PoolKeeper) Mint(ctx sdk.Context, marketSymbol string, stockAmountIn, mo
 	p.SetLiquidity(ctx, marketSymbol, to, totalLiq)
 	info.StockAmmReserve = info.StockAmmReserve.Add(stockAmountIn)
 	info.MoneyAmmReserve = info.MoneyAmmReserve.Add(moneyAmountIn)
+	if feeOn {
+		info.KLast = info.invariant()
+	}
 	p.SetPoolInfo(ctx, marketSymbol, info)
 	return liquidity, nil
 }
@@ -68,15 +136,21 @@ func (p PoolKeeper) Burn(ctx sdk.Context, marketSymbol string, from sdk.AccAddre
 	if info == nil {
 		return sdk.ZeroInt(), sdk.ZeroInt(), types.ErrPairIsNotExist()
 	}
+	if p.GetLiquidity(ctx, marketSymbol, from).LT(liquidity) {
+		return sdk.ZeroInt(), sdk.ZeroInt(), types.ErrInvalidLiquidityAmount()
+	}
+	p.settleRewards(ctx, marketSymbol, info.TotalSupply, from)
+	feeOn := p.mintFee(ctx, marketSymbol, info)
 	stockAmount := liquidity.Mul(info.StockAmmReserve).Quo(info.TotalSupply)
 	moneyAmount := liquidity.Mul(info.MoneyAmmReserve).Quo(info.TotalSupply)
 	info.StockAmmReserve = info.StockAmmReserve.Sub(stockAmount)
 	info.MoneyAmmReserve = info.MoneyAmmReserve.Sub(moneyAmount)
 	info.TotalSupply = info.TotalSupply.Sub(liquidity)
-	l := p.GetLiquidity(ctx, marketSymbol, from)
-	if l.LT(liquidity) {
-		return sdk.ZeroInt(), sdk.ZeroInt(), types.ErrInvalidLiquidityAmount()
+	if feeOn {
+		info.KLast = info.invariant()
 	}
+	// read again, because the fee receiver may be the one who burns
+	l := p.GetLiquidity(ctx, marketSymbol, from)
 	l = l.Sub(liquidity)
 	if l.IsZero() {
 		p.ClearLiquidity(ctx, marketSymbol, from)
@@ -87,6 +161,30 @@ func (p PoolKeeper) Burn(ctx sdk.Context, marketSymbol string, from sdk.AccAddre
 	return stockAmount, moneyAmount, nil
 }
 
+// TransferLiquidity moves the LP shares of a pool from one account to another, the pool itself is unchanged.
+// The rewards earned before the transfer stay with the sender.
+func (p PoolKeeper) TransferLiquidity(ctx sdk.Context, marketSymbol string, from, to sdk.AccAddress, liquidity sdk.Int) sdk.Error {
+	info := p.GetPoolInfo(ctx, marketSymbol)
+	if info == nil {
+		return types.ErrPairIsNotExist()
+	}
+	fromLiquidity := p.GetLiquidity(ctx, marketSymbol, from)
+	if !liquidity.IsPositive() || fromLiquidity.LT(liquidity) {
+		return types.ErrInvalidLiquidityAmount()
+	}
+	if from.Equals(to) {
+		return nil
+	}
+	p.settleRewards(ctx, marketSymbol, info.TotalSupply, from, to)
+	if fromLiquidity.Equal(liquidity) {
+		p.ClearLiquidity(ctx, marketSymbol, from)
+	} else {
+		p.SetLiquidity(ctx, marketSymbol, from, fromLiquidity.Sub(liquidity))
+	}
+	p.SetLiquidity(ctx, marketSymbol, to, p.GetLiquidity(ctx, marketSymbol, to).Add(liquidity))
+	return nil
+}
+
 func (p PoolKeeper) ClearLiquidity(ctx sdk.Context, marketSymbol string, address sdk.AccAddress) {
 	store := ctx.KVStore(p.key)
 	store.Delete(getLiquidityKey(marketSymbol, address))
@@ -137,6 +235,17 @@ func (p PoolKeeper) SetPoolInfo(ctx sdk.Context, marketSymbol string, info *Pool
 	store := ctx.KVStore(p.key)
 	bytes := p.codec.MustMarshalBinaryBare(info)
 	store.Set(getPairKey(marketSymbol), bytes)
+	p.recordPrice(ctx, marketSymbol, info)
+}
+
+// feed the price implied by the reserves of the pool to the oracle
+func (p PoolKeeper) recordPrice(ctx sdk.Context, marketSymbol string, info *PoolInfo) {
+	if p.oracle == nil {
+		return
+	}
+	if price := info.ammPrice(); price.IsPositive() {
+		p.oracle.RecordPrice(ctx, marketSymbol, price)
+	}
 }
 
 func (p PoolKeeper) ClearPoolInfo(ctx sdk.Context, marketSymbol string) {
@@ -151,12 +260,16 @@ func (p PoolKeeper) IteratePoolInfo(ctx sdk.Context, poolInfoProc func(info *Poo
 	for ; iter.Valid(); iter.Next() {
 		bi := &PoolInfo{}
 		p.codec.MustUnmarshalBinaryBare(iter.Value(), bi)
+		bi.setDefaultKLast()
+		bi.ticks = p.getTickStore(ctx, bi.Symbol)
 		poolInfoProc(bi)
 	}
 }
 
+// GetPoolInfos returns the pools as they are stored, which have no access to their ticks
 func (p PoolKeeper) GetPoolInfos(ctx sdk.Context) (infos []PoolInfo) {
 	proc := func(info *PoolInfo) {
+		info.ticks = nil
 		infos = append(infos, *info)
 	}
 	p.IteratePoolInfo(ctx, proc)
@@ -171,9 +284,15 @@ func (p PoolKeeper) GetPoolInfo(ctx sdk.Context, marketSymbol string) *PoolInfo
 		return nil
 	}
 	p.codec.MustUnmarshalBinaryBare(bytes, &info)
+	info.setDefaultKLast()
+	info.ticks = p.getTickStore(ctx, marketSymbol)
 	return &info
 }
 
+func (p PoolKeeper) getTickStore(ctx sdk.Context, marketSymbol string) *tickStore {
+	return &tickStore{store: ctx.KVStore(p.key), codec: p.codec, symbol: marketSymbol}
+}
+
 var _ IPoolKeeper = PoolKeeper{}
 
 type PoolInfo struct {
@@ -186,6 +305,103 @@ type PoolInfo struct {
 	TotalSupply           sdk.Int        `json:"total_supply"`
 	PricePrecision        byte           `json:"price_precision"`
 	LastExecutedPrice     sdk.Dec        `json:"last_executed_price"`
+
+	// The product of the AMM reserves after the last liquidity event, zero if the protocol fee is off
+	KLast sdk.Int `json:"k_last"`
+
+	// A pool with range liquidity has no LP shares. Its liquidity is provided by the positions within
+	// price ranges, and the AMM reserves are the tokens held by the positions.
+	RangeLiquidity bool    `json:"range_liquidity"`
+	SqrtPrice      sdk.Dec `json:"sqrt_price"`
+	// the liquidity of the positions whose range contains the current price
+	ActiveLiquidity sdk.Int `json:"active_liquidity"`
+	// the fees paid to the pool per active liquidity, which are kept out of the AMM reserves
+	FeeGrowthStock sdk.Dec `json:"fee_growth_stock"`
+	FeeGrowthMoney sdk.Dec `json:"fee_growth_money"`
+
+	// the curve of the AMM, and the amplification of a StableSwap curve
+	CurveType     byte  `json:"curve_type"`
+	Amplification int64 `json:"amplification"`
+
+	// the bounds of the range positions, which are stored under their own keys
+	ticks *tickStore
+	// the steps of the last trade with the range positions, whose fees are not added yet
+	rangeSteps []rangeStep
+}
+
+// KLast is nil in the pools stored before it was added, which is the same as zero
+func (p *PoolInfo) setDefaultKLast() {
+	if p.KLast == (sdk.Int{}) {
+		p.KLast = sdk.ZeroInt()
+	}
+}
+
+// dealWithAmm trades amountIn with the AMM of the pool, and returns the amount paid out and the amount
+// of amountIn used. Only a pool with range liquidity may not use all of amountIn, when its liquidity runs out.
+func (p *PoolInfo) dealWithAmm(amountIn sdk.Int, isBuy bool) (amountOut, amountUsed sdk.Int) {
+	if p.RangeLiquidity {
+		amountOut, amountUsed = p.dealWithRanges(amountIn, isBuy)
+	} else {
+		amountOut, amountUsed = GetAmountOutInPool(amountIn, p, isBuy), amountIn
+	}
+	if isBuy {
+		p.MoneyAmmReserve = p.MoneyAmmReserve.Add(amountUsed)
+		p.StockAmmReserve = p.StockAmmReserve.Sub(amountOut)
+	} else {
+		p.StockAmmReserve = p.StockAmmReserve.Add(amountUsed)
+		p.MoneyAmmReserve = p.MoneyAmmReserve.Sub(amountOut)
+	}
+	return amountOut, amountUsed
+}
+
+// addFeeToPool adds the fees paid to the pool into the AMM reserves. In a pool with range
+// liquidity, the fees are kept out of the reserves and earned by the positions the last trade
+// went through, see addRangeFee. The fees which no liquidity can earn are returned.
+func (p *PoolInfo) addFeeToPool(stockFee, moneyFee sdk.Int) (stockLeft, moneyLeft sdk.Int) {
+	if !p.RangeLiquidity {
+		p.StockAmmReserve = p.StockAmmReserve.Add(stockFee)
+		p.MoneyAmmReserve = p.MoneyAmmReserve.Add(moneyFee)
+		return sdk.ZeroInt(), sdk.ZeroInt()
+	}
+	steps := p.rangeSteps
+	p.rangeSteps = nil
+	return p.addRangeFee(steps, stockFee, moneyFee)
+}
+
+// canDealWithAmm returns whether the AMM of the pool has enough liquidity to take amountIn
+func (p PoolInfo) canDealWithAmm(amountIn sdk.Int, isBuy bool) bool {
+	if p.RangeLiquidity {
+		return p.rangeSwap(amountIn, isBuy, sdk.Dec{}).amountIn.Equal(amountIn)
+	}
+	return p.StockAmmReserve.IsPositive() && p.MoneyAmmReserve.IsPositive()
+}
+
+// the current price of the AMM, which is zero if the AMM has no liquidity
+func (p PoolInfo) ammPrice() sdk.Dec {
+	if p.RangeLiquidity {
+		if !p.ActiveLiquidity.IsPositive() {
+			return sdk.ZeroDec()
+		}
+		return p.SqrtPrice.Mul(p.SqrtPrice)
+	}
+	if !p.StockAmmReserve.IsPositive() || !p.MoneyAmmReserve.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	if p.CurveType == types.CurveStableSwap {
+		stock, money := p.StockAmmReserve.BigInt(), p.MoneyAmmReserve.BigInt()
+		return stablePrice(stock, money, stableD(stock, money, p.Amplification), p.Amplification)
+	}
+	return sdk.NewDecFromInt(p.MoneyAmmReserve).QuoInt(p.StockAmmReserve)
+}
+
+// invariant returns x*y of the AMM reserves, or (D/2)^2 on a StableSwap curve, which equals x*y
+// when the reserves are balanced
+func (p PoolInfo) invariant() sdk.Int {
+	if p.CurveType == types.CurveStableSwap {
+		half := new(big.Int).Rsh(stableD(p.StockAmmReserve.BigInt(), p.MoneyAmmReserve.BigInt(), p.Amplification), 1)
+		return sdk.NewIntFromBigInt(half.Mul(half, half))
+	}
+	return p.StockAmmReserve.Mul(p.MoneyAmmReserve)
 }
 
 func (p PoolInfo) GetLiquidityAmountIn(amountStockIn, amountMoneyIn sdk.Int) (amountStockOut, amountMoneyOut sdk.Int) {
@@ -212,7 +428,12 @@ func (p PoolInfo) String() string {
 		p.MoneyOrderBookReserve, p.TotalSupply)
 }
 
+// IsNoReservePool returns whether the pool can't share the fees of the deals, a pool with range
+// liquidity can share them only if some liquidity is active
 func (p PoolInfo) IsNoReservePool() bool {
+	if p.RangeLiquidity {
+		return !p.ActiveLiquidity.IsPositive()
+	}
 	return p.MoneyAmmReserve.IsZero() && p.StockAmmReserve.IsZero()
 }
 
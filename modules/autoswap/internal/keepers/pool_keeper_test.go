@@ -6,10 +6,15 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
 
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdkstore "github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 
 	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
 	"github.com/coinexchain/cet-sdk/testapp"
 )
 
@@ -33,6 +38,9 @@ func TestPoolKeeper_Pool(t *testing.T) {
 	require.Equal(t, info.Symbol, marketKey)
 	require.Equal(t, info.StockAmmReserve.Int64(), int64(100))
 	require.Equal(t, info.MoneyAmmReserve.Int64(), int64(10000))
+	price, oracleErr := app.OracleKeeper.GetTWAP(ctx, marketKey, 60)
+	require.Nil(t, oracleErr)
+	require.Equal(t, sdk.NewDec(100), price)
 	//step2: set pool info with swap close
 	k.SetPoolInfo(ctx, marketKey, &poolInfo)
 	info = k.GetPoolInfo(ctx, marketKey)
@@ -105,3 +113,138 @@ func TestPoolKeeper_Pool(t *testing.T) {
 	require.Equal(t, int64(1), stockR.Int64())
 	require.Equal(t, int64(100), moneyR.Int64())
 }
+
+func TestPoolKeeper_MintFee(t *testing.T) {
+	app := testapp.NewTestApp()
+	ctx := app.NewCtx()
+	k := app.AutoSwapKeeper
+	lp1 := sdk.AccAddress("lp1")
+	lp2 := sdk.AccAddress("lp2")
+	receiver := sdk.AccAddress("receiver____________")
+	params := types.DefaultParams()
+	params.FeeOn = true
+	params.FeeReceiver = receiver
+	k.SetParams(ctx, params)
+	marketKey := "stock/money"
+	k.CreatePair(ctx, lp1, marketKey, 0)
+
+	_, err := k.Mint(ctx, marketKey, sdk.NewInt(10000), sdk.NewInt(1000000), lp1)
+	require.Nil(t, err)
+	info := k.GetPoolInfo(ctx, marketKey)
+	require.Equal(t, sdk.NewInt(10000*1000000), info.KLast)
+	require.True(t, k.GetLiquidity(ctx, marketKey, receiver).IsZero())
+
+	// the fees of the swaps grow sqrt(k) from 100000 to 400000
+	info.StockAmmReserve = sdk.NewInt(40000)
+	info.MoneyAmmReserve = sdk.NewInt(4000000)
+	k.SetPoolInfo(ctx, marketKey, info)
+	liquidity, err := k.Mint(ctx, marketKey, sdk.NewInt(40000), sdk.NewInt(4000000), lp2)
+	require.Nil(t, err)
+	// 100000 * (400000 - 100000) / (400000 * 5 + 100000)
+	require.Equal(t, sdk.NewInt(14285), k.GetLiquidity(ctx, marketKey, receiver))
+	require.Equal(t, sdk.NewInt(114285), liquidity)
+	info = k.GetPoolInfo(ctx, marketKey)
+	require.Equal(t, sdk.NewInt(228570), info.TotalSupply)
+	require.Equal(t, sdk.NewInt(80000*8000000), info.KLast)
+
+	// no fee without the growth of k
+	stockOut, moneyOut, err := k.Burn(ctx, marketKey, receiver, sdk.NewInt(14285))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(4999), stockOut)
+	require.Equal(t, sdk.NewInt(499978), moneyOut)
+	require.True(t, k.GetLiquidity(ctx, marketKey, receiver).IsZero())
+	info = k.GetPoolInfo(ctx, marketKey)
+	require.Equal(t, sdk.NewInt(214285), info.TotalSupply)
+
+	// kLast is cleared once the fee is off
+	params.FeeOn = false
+	k.SetParams(ctx, params)
+	_, _, err = k.Burn(ctx, marketKey, lp1, sdk.NewInt(1000))
+	require.Nil(t, err)
+	require.True(t, k.GetPoolInfo(ctx, marketKey).KLast.IsZero())
+	require.True(t, k.GetLiquidity(ctx, marketKey, receiver).IsZero())
+}
+
+// the pool stored before KLast was added
+type poolInfoWithoutKLast struct {
+	Owner                 sdk.AccAddress `json:"owner"`
+	Symbol                string         `json:"symbol"`
+	StockAmmReserve       sdk.Int        `json:"stock_amm_reserve"`
+	MoneyAmmReserve       sdk.Int        `json:"money_amm_reserve"`
+	StockOrderBookReserve sdk.Int        `json:"stock_order_book_reserve"`
+	MoneyOrderBookReserve sdk.Int        `json:"money_order_book_reserve"`
+	TotalSupply           sdk.Int        `json:"total_supply"`
+	PricePrecision        byte           `json:"price_precision"`
+	LastExecutedPrice     sdk.Dec        `json:"last_executed_price"`
+}
+
+func TestPoolKeeper_MintFeeWithoutKLast(t *testing.T) {
+	db := dbm.NewMemDB()
+	ms := sdkstore.NewCommitMultiStore(db)
+	key := sdk.NewKVStoreKey(types.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	cdc := codec.New()
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+	k := keepers.NewKeeper(cdc, key, paramsKeeper.Subspace(types.StoreKey), nil, nil, nil, nil, nil, nil)
+
+	marketKey := "stock/money"
+	lp := sdk.AccAddress("lp")
+	ctx.KVStore(key).Set(append(keepers.MarketKey, []byte(marketKey)...), cdc.MustMarshalBinaryBare(poolInfoWithoutKLast{
+		Symbol:                marketKey,
+		StockAmmReserve:       sdk.NewInt(10000),
+		MoneyAmmReserve:       sdk.NewInt(1000000),
+		StockOrderBookReserve: sdk.ZeroInt(),
+		MoneyOrderBookReserve: sdk.ZeroInt(),
+		TotalSupply:           sdk.NewInt(100000),
+		LastExecutedPrice:     sdk.ZeroDec(),
+	}))
+	k.SetLiquidity(ctx, marketKey, lp, sdk.NewInt(100000))
+	require.True(t, k.GetPoolInfo(ctx, marketKey).KLast.IsZero())
+
+	// the first mint after the fee is on takes no fee, and records kLast
+	params := types.DefaultParams()
+	params.FeeOn = true
+	params.FeeReceiver = sdk.AccAddress("receiver____________")
+	k.SetParams(ctx, params)
+	liquidity, err := k.Mint(ctx, marketKey, sdk.NewInt(10000), sdk.NewInt(1000000), lp)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(100000), liquidity)
+	require.True(t, k.GetLiquidity(ctx, marketKey, params.FeeReceiver).IsZero())
+	require.Equal(t, sdk.NewInt(20000*2000000), k.GetPoolInfo(ctx, marketKey).KLast)
+}
+
+func TestPoolKeeper_TransferLiquidity(t *testing.T) {
+	app := testapp.NewTestApp()
+	ctx := app.NewCtx()
+	k := app.AutoSwapKeeper
+	from := sdk.AccAddress("from")
+	to := sdk.AccAddress("to")
+	marketKey := "stock/money"
+	require.Equal(t, types.CodePairIsNotExist, int(k.TransferLiquidity(ctx, marketKey, from, to, sdk.NewInt(1)).Code()))
+
+	k.CreatePair(ctx, from, marketKey, 0)
+	_, err := k.Mint(ctx, marketKey, sdk.NewInt(10000), sdk.NewInt(1000000), from)
+	require.Nil(t, err)
+	require.Equal(t, types.CodeInvalidLiquidityAmount, int(k.TransferLiquidity(ctx, marketKey, from, to, sdk.NewInt(100001)).Code()))
+	require.Equal(t, types.CodeInvalidLiquidityAmount, int(k.TransferLiquidity(ctx, marketKey, from, to, sdk.ZeroInt()).Code()))
+
+	require.Nil(t, k.TransferLiquidity(ctx, marketKey, from, to, sdk.NewInt(40000)))
+	require.Equal(t, sdk.NewInt(60000), k.GetLiquidity(ctx, marketKey, from))
+	require.Equal(t, sdk.NewInt(40000), k.GetLiquidity(ctx, marketKey, to))
+	require.Nil(t, k.TransferLiquidity(ctx, marketKey, from, to, sdk.NewInt(60000)))
+	require.True(t, k.GetLiquidity(ctx, marketKey, from).IsZero())
+	require.Equal(t, sdk.NewInt(100000), k.GetLiquidity(ctx, marketKey, to))
+	require.Equal(t, 1, len(k.GetAllLiquidityInfos(ctx)))
+	require.Equal(t, sdk.NewInt(100000), k.GetPoolInfo(ctx, marketKey).TotalSupply)
+
+	stockOut, moneyOut, err := k.Burn(ctx, marketKey, to, sdk.NewInt(100000))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt(10000), stockOut)
+	require.Equal(t, sdk.NewInt(1000000), moneyOut)
+}
@@ -31,6 +31,18 @@ func NewQuerier(mk Keeper) sdk.Querier {
 			return queryUserOrderList(ctx, req, mk)
 		case market.QueryWaitCancelMarkets:
 			return queryWaitCancelMarkets(ctx, req, mk)
+		case market.QueryDepth:
+			return queryDepth(ctx, req, mk)
+		case types.QuerySwapQuote:
+			return querySwapQuote(ctx, req, mk)
+		case types.QueryLiquidity:
+			return queryLiquidity(ctx, req, mk)
+		case types.QueryPoolReserves:
+			return queryPoolReserves(ctx, req, mk)
+		case types.QueryRangePositions:
+			return queryRangePositions(ctx, req, mk)
+		case types.QueryRewards:
+			return queryRewards(ctx, req, mk)
 		default:
 			return nil, sdk.ErrUnknownRequest("query symbol : " + path[0])
 		}
@@ -79,14 +91,50 @@ func queryMarketList(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte,
 	}
 	return bz, nil
 }
+
+// pageBounds returns the range [start, end) of a page in total results, which starts from 1.
+// All the results are selected if limit is not positive.
+func pageBounds(total, page, limit int) (start, end int) {
+	if limit <= 0 {
+		return 0, total
+	}
+	if page < 1 {
+		page = 1
+	}
+	start = (page - 1) * limit
+	if start > total || start < 0 {
+		return total, total
+	}
+	end = start + limit
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// QueryOrdersInMarketParam is compatible with market.QueryMarketParam
+type QueryOrdersInMarketParam struct {
+	TradingPair string
+	Page        int
+	Limit       int
+}
+
+// QueryUserOrderListParam is compatible with market.QueryUserOrderList
+type QueryUserOrderListParam struct {
+	User  string
+	Page  int
+	Limit int
+}
+
 func queryOrdersInMarket(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
-	var param market.QueryMarketParam
+	var param QueryOrdersInMarketParam
 	if err := k.cdc.UnmarshalJSON(req.Data, &param); err != nil {
 		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse param: %s", err))
 	}
 
-	var orders []*types.Order // TODO
-	orders = k.GetAllOrders(ctx, param.TradingPair)
+	orders := k.GetAllOrders(ctx, param.TradingPair)
+	start, end := pageBounds(len(orders), param.Page, param.Limit)
+	orders = orders[start:end]
 	rs := make([]*market.ResOrder, len(orders))
 	for i, order := range orders {
 		rs[i] = toResOrder(order)
@@ -115,13 +163,14 @@ func queryOrder(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.E
 	return bz, nil
 }
 func queryUserOrderList(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
-	var param market.QueryUserOrderList
+	var param QueryUserOrderListParam
 	if err := k.cdc.UnmarshalJSON(req.Data, &param); err != nil {
 		return nil, types.ErrMarshalFailed()
 	}
 
-	var orders []string // TODO
-	orders = k.GetOrdersFromUser(ctx, param.User)
+	orders := k.GetOrdersFromUser(ctx, param.User)
+	start, end := pageBounds(len(orders), param.Page, param.Limit)
+	orders = orders[start:end]
 	bz, err := codec.MarshalJSONIndent(k.cdc, orders)
 	if err != nil {
 		return nil, types.ErrMarshalFailed()
@@ -130,8 +179,12 @@ func queryUserOrderList(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byt
 	return bz, nil
 }
 func queryWaitCancelMarkets(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
-	// unsupported, return empty list
-	var markets []string
+	var param market.QueryCancelMarkets
+	if err := k.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse param: %s", err))
+	}
+
+	markets := k.GetDelistSymbolsBeforeTime(ctx, param.Time)
 	bz, err := codec.MarshalJSONIndent(k.cdc, markets)
 	if err != nil {
 		return nil, types.ErrMarshalFailed()
@@ -159,7 +212,7 @@ func toResOrder(order *types.Order) *market.ResOrder {
 	return &market.ResOrder{
 		OrderID:     order.GetOrderID(),
 		Sender:      order.Sender,
-		Sequence:    0, // TODO
+		Sequence:    uint64(order.Sequence),
 		Identify:    order.Identify,
 		TradingPair: order.TradingPair,
 		OrderType:   market.LimitOrder,
@@ -179,3 +232,229 @@ func toResOrder(order *types.Order) *market.ResOrder {
 		//FrozenFee:        order.FrozenFee,
 	}
 }
+
+func queryDepth(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var param market.QueryDepthParam
+	if err := k.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse param: %s", err))
+	}
+	info := k.GetPoolInfo(ctx, param.TradingPair)
+	if info == nil {
+		return nil, types.ErrInvalidMarket("Maybe the market have been deleted or not exist")
+	}
+	precision, count, sdkErr := param.GetPrecisionAndCount(info.PricePrecision)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	res := market.QueryDepthResult{}
+	for _, isBuy := range []bool{true, false} {
+		aggregator := market.NewDepthAggregator(isBuy, precision, count)
+		k.IterateOrdersFromBestPrice(ctx, param.TradingPair, isBuy, func(order *types.Order) bool {
+			return aggregator.Add(order.Price, order.LeftStock)
+		})
+		if isBuy {
+			res.Bids = aggregator.Levels()
+		} else {
+			res.Asks = aggregator.Levels()
+		}
+	}
+	bz, err := codec.MarshalJSONIndent(k.cdc, res)
+	if err != nil {
+		return nil, types.ErrMarshalFailed()
+	}
+	return bz, nil
+}
+
+type QuerySwapQuoteParam struct {
+	Path []string `json:"path"`
+	// the amount of the first token in path, or the amount of the last one if ExactOut is true
+	Amount   sdk.Int `json:"amount"`
+	ExactOut bool    `json:"exact_out"`
+}
+
+type QuerySwapQuoteResponse struct {
+	Path    []string  `json:"path"`
+	Amounts []sdk.Int `json:"amounts"`
+}
+
+func querySwapQuote(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var param QuerySwapQuoteParam
+	if err := k.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse param: %s", err))
+	}
+	if param.Amount == (sdk.Int{}) || !param.Amount.IsPositive() {
+		return nil, types.ErrInvalidAmount(param.Amount)
+	}
+
+	var (
+		amounts []sdk.Int
+		err     sdk.Error
+	)
+	if param.ExactOut {
+		amounts, err = k.GetAmountsIn(ctx, param.Path, param.Amount)
+	} else {
+		amounts, err = k.GetAmountsOut(ctx, param.Path, param.Amount)
+	}
+	if err != nil {
+		return nil, err
+	}
+	bz, mErr := codec.MarshalJSONIndent(k.cdc, QuerySwapQuoteResponse{Path: param.Path, Amounts: amounts})
+	if mErr != nil {
+		return nil, types.ErrMarshalFailed()
+	}
+	return bz, nil
+}
+
+type QueryLiquidityParam struct {
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+// LiquidityPosition is the liquidity of an owner in a pool, with the reserves it can be burnt for
+type LiquidityPosition struct {
+	Symbol      string  `json:"symbol"`
+	Liquidity   sdk.Int `json:"liquidity"`
+	StockAmount sdk.Int `json:"stock_amount"`
+	MoneyAmount sdk.Int `json:"money_amount"`
+}
+
+func queryLiquidity(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var param QueryLiquidityParam
+	if err := k.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse param: %s", err))
+	}
+
+	// look up the owner in each pool instead of walking through the liquidity of all the owners.
+	// the pools with range liquidity have no shares, their positions are queried by QueryRangePositions
+	positions := make([]LiquidityPosition, 0)
+	for _, info := range k.GetPoolInfos(ctx) {
+		if info.RangeLiquidity {
+			continue
+		}
+		liquidity := k.GetLiquidity(ctx, info.Symbol, param.Owner)
+		if !liquidity.IsPositive() {
+			continue
+		}
+		position := LiquidityPosition{
+			Symbol:      info.Symbol,
+			Liquidity:   liquidity,
+			StockAmount: sdk.ZeroInt(),
+			MoneyAmount: sdk.ZeroInt(),
+		}
+		if info.TotalSupply.IsPositive() {
+			position.StockAmount = liquidity.Mul(info.StockAmmReserve).Quo(info.TotalSupply)
+			position.MoneyAmount = liquidity.Mul(info.MoneyAmmReserve).Quo(info.TotalSupply)
+		}
+		positions = append(positions, position)
+	}
+	bz, err := codec.MarshalJSONIndent(k.cdc, positions)
+	if err != nil {
+		return nil, types.ErrMarshalFailed()
+	}
+	return bz, nil
+}
+
+// RangeLiquidityPosition is a range position of an owner, with the reserves it holds at the current price
+type RangeLiquidityPosition struct {
+	Symbol      string  `json:"symbol"`
+	LowerPrice  sdk.Dec `json:"lower_price"`
+	UpperPrice  sdk.Dec `json:"upper_price"`
+	Liquidity   sdk.Int `json:"liquidity"`
+	StockAmount sdk.Int `json:"stock_amount"`
+	MoneyAmount sdk.Int `json:"money_amount"`
+}
+
+func queryRangePositions(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var param QueryLiquidityParam
+	if err := k.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse param: %s", err))
+	}
+
+	positions := make([]RangeLiquidityPosition, 0)
+	k.IterateAllRangePositions(ctx, func(pos RangePosition) {
+		if !pos.Owner.Equals(param.Owner) {
+			return
+		}
+		position := RangeLiquidityPosition{
+			Symbol:      pos.Symbol,
+			LowerPrice:  pos.LowerPrice,
+			UpperPrice:  pos.UpperPrice,
+			Liquidity:   pos.Liquidity,
+			StockAmount: sdk.ZeroInt(),
+			MoneyAmount: sdk.ZeroInt(),
+		}
+		if info := k.GetPoolInfo(ctx, pos.Symbol); info != nil {
+			position.StockAmount, position.MoneyAmount = info.rangeAmounts(pos.Liquidity,
+				sqrtDec(pos.LowerPrice), sqrtDec(pos.UpperPrice), false)
+		}
+		positions = append(positions, position)
+	})
+	bz, err := codec.MarshalJSONIndent(k.cdc, positions)
+	if err != nil {
+		return nil, types.ErrMarshalFailed()
+	}
+	return bz, nil
+}
+
+type PoolReserves struct {
+	Symbol                string  `json:"symbol"`
+	StockAmmReserve       sdk.Int `json:"stock_amm_reserve"`
+	MoneyAmmReserve       sdk.Int `json:"money_amm_reserve"`
+	StockOrderBookReserve sdk.Int `json:"stock_order_book_reserve"`
+	MoneyOrderBookReserve sdk.Int `json:"money_order_book_reserve"`
+	TotalSupply           sdk.Int `json:"total_supply"`
+}
+
+func queryPoolReserves(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var param market.QueryMarketParam
+	if err := k.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse param: %s", err))
+	}
+
+	info := k.GetPoolInfo(ctx, param.TradingPair)
+	if info == nil {
+		return nil, types.ErrPairIsNotExist()
+	}
+	bz, err := codec.MarshalJSONIndent(k.cdc, PoolReserves{
+		Symbol:                info.Symbol,
+		StockAmmReserve:       info.StockAmmReserve,
+		MoneyAmmReserve:       info.MoneyAmmReserve,
+		StockOrderBookReserve: info.StockOrderBookReserve,
+		MoneyOrderBookReserve: info.MoneyOrderBookReserve,
+		TotalSupply:           info.TotalSupply,
+	})
+	if err != nil {
+		return nil, types.ErrMarshalFailed()
+	}
+	return bz, nil
+}
+
+// PoolReward is the reward program of a pool till the current block, with the rewards an owner can claim
+type PoolReward struct {
+	Program   RewardProgram `json:"program"`
+	Unclaimed sdk.Int       `json:"unclaimed"`
+}
+
+func queryRewards(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var param QueryLiquidityParam
+	if err := k.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse param: %s", err))
+	}
+
+	rewards := make([]PoolReward, 0)
+	k.IterateRewardPrograms(ctx, func(prog RewardProgram) {
+		if info := k.GetPoolInfo(ctx, prog.Symbol); info != nil {
+			prog.update(ctx.BlockHeight(), info.TotalSupply)
+		}
+		reward := PoolReward{Program: prog, Unclaimed: sdk.ZeroInt()}
+		if !param.Owner.Empty() {
+			reward.Unclaimed = k.GetUnclaimedRewards(ctx, prog.Symbol, param.Owner)
+		}
+		rewards = append(rewards, reward)
+	})
+	bz, err := codec.MarshalJSONIndent(k.cdc, rewards)
+	if err != nil {
+		return nil, types.ErrMarshalFailed()
+	}
+	return bz, nil
+}
@@ -0,0 +1,88 @@
+package keepers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	"github.com/coinexchain/cet-sdk/modules/market"
+)
+
+func TestQueryOrdersAndLiquidity(t *testing.T) {
+	// the addresses are unmarshalled from the query results, so they must be of the valid length
+	boss := sdk.AccAddress("boss________________")
+	maker := sdk.AccAddress("maker_______________")
+	lp1 := sdk.AccAddress("lp1_________________")
+	lp2 := sdk.AccAddress("lp2_________________")
+	th := newTestHelper(t)
+	k := th.app.AutoSwapKeeper
+	querier := keepers.NewQuerier(*k)
+	query := func(path string, param interface{}, result interface{}) sdk.Error {
+		res, err := querier(th.ctx, []string{path}, abci.RequestQuery{Data: th.app.Cdc.MustMarshalJSON(param)})
+		if err == nil {
+			th.app.Cdc.MustUnmarshalJSON(res, result)
+		}
+		return err
+	}
+
+	btc := th.issueToken("btc0", 100000000000000, boss)
+	usd := th.issueToken("usd0", 100000000000000, boss)
+	pair := th.createPair(maker, btc.sym, usd.sym, 0)
+	pair.mint(10000, 1000000, lp1)
+	pair.mint(5000, 500000, lp2)
+	// lp2 has no liquidity in the other pool
+	eth := th.issueToken("eth0", 100000000000000, boss)
+	th.createPair(maker, eth.sym, usd.sym, 0).mint(10000, 1000000, lp1)
+	btc.transfer(maker, 1000, boss)
+	usd.transfer(maker, 10000, boss)
+	acc := th.app.AccountKeeper.GetAccount(th.ctx, maker)
+	require.NoError(t, acc.SetSequence(5))
+	th.app.AccountKeeper.SetAccount(th.ctx, acc)
+	pair.addLimitOrder(false, maker, 100, 200, 1)
+	pair.addLimitOrder(false, maker, 100, 300, 2)
+	pair.addLimitOrder(true, maker, 100, 50, 3)
+
+	var orders []market.ResOrder
+	require.Nil(t, query(market.QueryOrdersInMarket, keepers.QueryOrdersInMarketParam{TradingPair: pair.sym}, &orders))
+	require.Equal(t, 3, len(orders))
+	require.EqualValues(t, 5, orders[0].Sequence)
+	require.Equal(t, market.AssemblyOrderID(maker.String(), 5, orders[0].Identify), orders[0].OrderID)
+	for page, size := range []int{2, 1, 0} {
+		param := keepers.QueryOrdersInMarketParam{TradingPair: pair.sym, Page: page + 1, Limit: 2}
+		orders = nil
+		require.Nil(t, query(market.QueryOrdersInMarket, param, &orders))
+		require.Equal(t, size, len(orders))
+	}
+
+	var orderIDs []string
+	require.Nil(t, query(market.QueryUserOrders, keepers.QueryUserOrderListParam{User: maker.String()}, &orderIDs))
+	require.Equal(t, 3, len(orderIDs))
+	require.Nil(t, query(market.QueryUserOrders, keepers.QueryUserOrderListParam{User: maker.String(), Page: 2, Limit: 2}, &orderIDs))
+	require.Equal(t, 1, len(orderIDs))
+
+	var positions []keepers.LiquidityPosition
+	require.Nil(t, query(types.QueryLiquidity, keepers.QueryLiquidityParam{Owner: lp2}, &positions))
+	require.Equal(t, []keepers.LiquidityPosition{{
+		Symbol:      pair.sym,
+		Liquidity:   sdk.NewInt(50000),
+		StockAmount: sdk.NewInt(5000),
+		MoneyAmount: sdk.NewInt(500000),
+	}}, positions)
+
+	var reserves keepers.PoolReserves
+	require.Nil(t, query(types.QueryPoolReserves, market.QueryMarketParam{TradingPair: pair.sym}, &reserves))
+	require.Equal(t, keepers.PoolReserves{
+		Symbol:                pair.sym,
+		StockAmmReserve:       sdk.NewInt(15000),
+		MoneyAmmReserve:       sdk.NewInt(1500000),
+		StockOrderBookReserve: sdk.NewInt(200),
+		MoneyOrderBookReserve: sdk.NewInt(5000),
+		TotalSupply:           sdk.NewInt(150000),
+	}, reserves)
+	require.Equal(t, types.CodePairIsNotExist, int(query(types.QueryPoolReserves, market.QueryMarketParam{TradingPair: "usd0/btc0"}, &reserves).Code()))
+}
@@ -0,0 +1,184 @@
+package keepers
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+)
+
+// RangePosition is the liquidity an owner provides to a pool within [LowerPrice, UpperPrice)
+type RangePosition struct {
+	Symbol     string         `json:"symbol"`
+	Owner      sdk.AccAddress `json:"owner"`
+	LowerPrice sdk.Dec        `json:"lower_price"`
+	UpperPrice sdk.Dec        `json:"upper_price"`
+	Liquidity  sdk.Int        `json:"liquidity"`
+	// the fee growth inside the range when the fees of this position were last settled
+	FeeGrowthInsideStock sdk.Dec `json:"fee_growth_inside_stock"`
+	FeeGrowthInsideMoney sdk.Dec `json:"fee_growth_inside_money"`
+	// the fees earned and not taken out yet
+	FeeStockOwed sdk.Int `json:"fee_stock_owed"`
+	FeeMoneyOwed sdk.Int `json:"fee_money_owed"`
+}
+
+func NewRangePosition(symbol string, owner sdk.AccAddress, lowerPrice, upperPrice sdk.Dec) RangePosition {
+	return RangePosition{
+		Symbol:               symbol,
+		Owner:                owner,
+		LowerPrice:           lowerPrice,
+		UpperPrice:           upperPrice,
+		Liquidity:            sdk.ZeroInt(),
+		FeeGrowthInsideStock: sdk.ZeroDec(),
+		FeeGrowthInsideMoney: sdk.ZeroDec(),
+		FeeStockOwed:         sdk.ZeroInt(),
+		FeeMoneyOwed:         sdk.ZeroInt(),
+	}
+}
+
+// AddRangeLiquidity provides liquidity to a pool with range liquidity within [lowerPrice, upperPrice).
+// The liquidity is the largest one which stockAmount and moneyAmount can provide at the current price,
+// and the amounts needed by it are returned. The caller sends these amounts to the pool.
+func (p PoolKeeper) AddRangeLiquidity(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress, lowerPrice, upperPrice sdk.Dec,
+	stockAmount, moneyAmount sdk.Int) (liquidity, stockIn, moneyIn sdk.Int, err sdk.Error) {
+	info := p.GetPoolInfo(ctx, marketSymbol)
+	if info == nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroInt(), types.ErrPairIsNotExist()
+	}
+	if !info.RangeLiquidity {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroInt(), types.ErrInvalidPoolType(marketSymbol, false)
+	}
+	if err = types.ValidatePriceRange(lowerPrice, upperPrice); err != nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroInt(), err
+	}
+	if err = types.ValidateRangeTicks(lowerPrice, upperPrice, info.PricePrecision); err != nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroInt(), err
+	}
+	sqrtLower, sqrtUpper := sqrtDec(lowerPrice), sqrtDec(upperPrice)
+	liquidity = info.rangeLiquidityForAmounts(stockAmount, moneyAmount, sqrtLower, sqrtUpper)
+	if !liquidity.IsPositive() {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroInt(), types.ErrInvalidLiquidityAmount()
+	}
+	pos := p.GetRangePosition(ctx, marketSymbol, owner, lowerPrice, upperPrice)
+	if pos == nil {
+		newPos := NewRangePosition(marketSymbol, owner, lowerPrice, upperPrice)
+		pos = &newPos
+	}
+	if !isValidRangeLiquidity(pos.Liquidity.Add(liquidity)) {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdk.ZeroInt(), types.ErrInvalidLiquidityAmount()
+	}
+	// the amounts are rounded up, but never more than the ones given
+	stockIn, moneyIn = info.rangeAmounts(liquidity, sqrtLower, sqrtUpper, true)
+	stockIn, moneyIn = sdk.MinInt(stockIn, stockAmount), sdk.MinInt(moneyIn, moneyAmount)
+	info.modifyRangePosition(pos, liquidity)
+	info.StockAmmReserve = info.StockAmmReserve.Add(stockIn)
+	info.MoneyAmmReserve = info.MoneyAmmReserve.Add(moneyIn)
+	p.SetRangePosition(ctx, *pos)
+	p.SetPoolInfo(ctx, marketSymbol, info)
+	return liquidity, stockIn, moneyIn, nil
+}
+
+// RemoveRangeLiquidity takes liquidity out of a range position, and returns the tokens held by the
+// liquidity together with all the fees earned by the position. The caller sends them to the owner.
+// A liquidity of zero only takes the fees out.
+func (p PoolKeeper) RemoveRangeLiquidity(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress, lowerPrice, upperPrice sdk.Dec,
+	liquidity sdk.Int) (stockOut, moneyOut sdk.Int, err sdk.Error) {
+	info := p.GetPoolInfo(ctx, marketSymbol)
+	if info == nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), types.ErrPairIsNotExist()
+	}
+	pos := p.GetRangePosition(ctx, marketSymbol, owner, lowerPrice, upperPrice)
+	if pos == nil || liquidity.IsNegative() || pos.Liquidity.LT(liquidity) || !isValidRangeLiquidity(pos.Liquidity.Sub(liquidity)) {
+		return sdk.ZeroInt(), sdk.ZeroInt(), types.ErrInvalidLiquidityAmount()
+	}
+	info.modifyRangePosition(pos, liquidity.Neg())
+	stockOut, moneyOut = info.rangeAmounts(liquidity, sqrtDec(lowerPrice), sqrtDec(upperPrice), false)
+	stockOut, moneyOut = sdk.MinInt(stockOut, info.StockAmmReserve), sdk.MinInt(moneyOut, info.MoneyAmmReserve)
+	info.StockAmmReserve = info.StockAmmReserve.Sub(stockOut)
+	info.MoneyAmmReserve = info.MoneyAmmReserve.Sub(moneyOut)
+	stockOut, moneyOut = stockOut.Add(pos.FeeStockOwed), moneyOut.Add(pos.FeeMoneyOwed)
+	pos.FeeStockOwed, pos.FeeMoneyOwed = sdk.ZeroInt(), sdk.ZeroInt()
+	if pos.Liquidity.IsZero() {
+		p.ClearRangePosition(ctx, *pos)
+	} else {
+		p.SetRangePosition(ctx, *pos)
+	}
+	p.SetPoolInfo(ctx, marketSymbol, info)
+	return stockOut, moneyOut, nil
+}
+
+// a range position can't be left with so little liquidity that its ticks cost more than it provides
+func isValidRangeLiquidity(liquidity sdk.Int) bool {
+	return liquidity.IsZero() || liquidity.GTE(sdk.NewInt(types.MinRangeLiquidity))
+}
+
+func (p PoolKeeper) SetRangePosition(ctx sdk.Context, pos RangePosition) {
+	store := ctx.KVStore(p.key)
+	bytes := p.codec.MustMarshalBinaryBare(pos)
+	store.Set(getRangePositionKey(pos.Symbol, pos.Owner, pos.LowerPrice, pos.UpperPrice), bytes)
+}
+
+func (p PoolKeeper) ClearRangePosition(ctx sdk.Context, pos RangePosition) {
+	store := ctx.KVStore(p.key)
+	store.Delete(getRangePositionKey(pos.Symbol, pos.Owner, pos.LowerPrice, pos.UpperPrice))
+}
+
+func (p PoolKeeper) GetRangePosition(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress, lowerPrice, upperPrice sdk.Dec) *RangePosition {
+	store := ctx.KVStore(p.key)
+	bytes := store.Get(getRangePositionKey(marketSymbol, owner, lowerPrice, upperPrice))
+	if bytes == nil {
+		return nil
+	}
+	pos := &RangePosition{}
+	p.codec.MustUnmarshalBinaryBare(bytes, pos)
+	return pos
+}
+
+// GetRangePositions returns all the range positions of a pool
+func (p PoolKeeper) GetRangePositions(ctx sdk.Context, marketSymbol string) (positions []RangePosition) {
+	p.iterateRangePositions(ctx, getRangePositionsBegin(marketSymbol), getRangePositionsEnd(marketSymbol), func(pos RangePosition) {
+		positions = append(positions, pos)
+	})
+	return
+}
+
+func (p PoolKeeper) IterateAllRangePositions(ctx sdk.Context, positionProc func(pos RangePosition)) {
+	p.iterateRangePositions(ctx, RangePositionKey, RangePositionEndKey, positionProc)
+}
+
+func (p PoolKeeper) iterateRangePositions(ctx sdk.Context, start, end []byte, positionProc func(pos RangePosition)) {
+	store := ctx.KVStore(p.key)
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		pos := RangePosition{}
+		p.codec.MustUnmarshalBinaryBare(iter.Value(), &pos)
+		positionProc(pos)
+	}
+}
+
+func (p PoolKeeper) SetTick(ctx sdk.Context, tick Tick) {
+	p.getTickStore(ctx, tick.Symbol).set(tick)
+}
+
+// GetTicks returns all the ticks of a pool, in ascending order of price
+func (p PoolKeeper) GetTicks(ctx sdk.Context, marketSymbol string) (ticks []Tick) {
+	p.iterateTicks(ctx, getTicksBegin(marketSymbol), getTicksEnd(marketSymbol), func(tick Tick) {
+		ticks = append(ticks, tick)
+	})
+	return
+}
+
+func (p PoolKeeper) IterateAllTicks(ctx sdk.Context, tickProc func(tick Tick)) {
+	p.iterateTicks(ctx, TickKey, TickEndKey, tickProc)
+}
+
+func (p PoolKeeper) iterateTicks(ctx sdk.Context, start, end []byte, tickProc func(tick Tick)) {
+	store := ctx.KVStore(p.key)
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		tick := Tick{}
+		p.codec.MustUnmarshalBinaryBare(iter.Value(), &tick)
+		tickProc(tick)
+	}
+}
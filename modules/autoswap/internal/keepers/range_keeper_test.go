@@ -0,0 +1,45 @@
+package keepers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	"github.com/coinexchain/cet-sdk/testapp"
+)
+
+func TestRangeKeeper_WideRangeLikeConstantProduct(t *testing.T) {
+	app := testapp.NewTestApp()
+	ctx := sdk.NewContext(app.Cms, abci.Header{}, false, log.NewNopLogger())
+	k := app.AutoSwapKeeper
+	owner := sdk.AccAddress("owner")
+	marketKey := "stock/money"
+	k.CreateRangePair(ctx, owner, marketKey, 8, sdk.NewDec(100))
+
+	_, _, _, err := k.AddRangeLiquidity(ctx, marketKey, owner, sdk.NewDec(2), sdk.NewDec(1), sdk.NewInt(100), sdk.NewInt(100))
+	require.Equal(t, sdk.CodeType(types.CodeInvalidPriceRange), err.Code())
+	// the prices must be on the ticks of the pool
+	_, _, _, err = k.AddRangeLiquidity(ctx, marketKey, owner, sdk.NewDecWithPrec(1, 9), sdk.NewDec(1e8), sdk.NewInt(10000), sdk.NewInt(1000000))
+	require.Equal(t, sdk.CodeType(types.CodeInvalidPriceRange), err.Code())
+	// a range wide enough works like the constant product
+	liquidity, stockIn, moneyIn, err := k.AddRangeLiquidity(ctx, marketKey, owner,
+		sdk.NewDecWithPrec(1, 8), sdk.NewDec(1e8), sdk.NewInt(10000), sdk.NewInt(1000000))
+	require.NoError(t, err)
+	require.True(t, liquidity.IsPositive())
+	require.Equal(t, sdk.NewInt(1000000), moneyIn)
+	require.True(t, stockIn.LTE(sdk.NewInt(10000)) && stockIn.GT(sdk.NewInt(9990)))
+
+	// the results are within the rounding of a pool holding 10000 stock and 1000000 money
+	info := k.GetPoolInfo(ctx, marketKey)
+	cp := &keepers.PoolInfo{MoneyAmmReserve: sdk.NewInt(1000000), StockAmmReserve: sdk.NewInt(10000)}
+	require.True(t, keepers.IntoPoolAmountTillPrice(sdk.NewDec(110), true, info).Sub(sdk.NewInt(48808)).LTE(sdk.NewInt(2)))
+	require.True(t, keepers.IntoPoolAmountTillPrice(sdk.NewDec(90), false, info).Sub(sdk.NewInt(540)).LTE(sdk.NewInt(1)))
+	require.Equal(t, keepers.GetAmountOutInPool(sdk.NewInt(48808), cp, true), keepers.GetAmountOutInPool(sdk.NewInt(48808), info, true))
+	require.Equal(t, keepers.GetAmountOutInPool(sdk.NewInt(540), cp, false), keepers.GetAmountOutInPool(sdk.NewInt(540), info, false))
+}
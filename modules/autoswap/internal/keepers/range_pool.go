@@ -0,0 +1,376 @@
+package keepers
+
+import (
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Tick is a price bound of the range positions in a pool. A position is active when its lower
+// price is not above the current price and its upper price is above the current price.
+// The ticks are stored under their own keys, in ascending order of price.
+type Tick struct {
+	Symbol    string  `json:"symbol"`
+	Price     sdk.Dec `json:"price"`
+	SqrtPrice sdk.Dec `json:"sqrt_price"`
+	// the liquidity added when the price crosses this tick upward, and removed when it crosses downward
+	LiquidityNet sdk.Int `json:"liquidity_net"`
+	// the total liquidity of the positions bounded by this tick, the tick is removed when it is zero
+	LiquidityGross sdk.Int `json:"liquidity_gross"`
+	// the fee growth per liquidity on the other side of this tick from the current price
+	FeeGrowthOutsideStock sdk.Dec `json:"fee_growth_outside_stock"`
+	FeeGrowthOutsideMoney sdk.Dec `json:"fee_growth_outside_money"`
+}
+
+// tickStore reads and writes the ticks of a pool, which is attached to the PoolInfo read from the store
+type tickStore struct {
+	store  sdk.KVStore
+	codec  *codec.Codec
+	symbol string
+}
+
+func (ts *tickStore) get(price sdk.Dec) *Tick {
+	if ts == nil {
+		return nil
+	}
+	bytes := ts.store.Get(getTickKey(ts.symbol, price))
+	if bytes == nil {
+		return nil
+	}
+	tick := &Tick{}
+	ts.codec.MustUnmarshalBinaryBare(bytes, tick)
+	return tick
+}
+
+func (ts *tickStore) set(tick Tick) {
+	ts.store.Set(getTickKey(ts.symbol, tick.Price), ts.codec.MustMarshalBinaryBare(tick))
+}
+
+func (ts *tickStore) delete(price sdk.Dec) {
+	ts.store.Delete(getTickKey(ts.symbol, price))
+}
+
+// seek returns the first tick beyond sqrtPrice in the direction of a trade, which is the lowest one above
+// sqrtPrice for a buy and the highest one below it for a sell. A tick at sqrtPrice is also returned if inclusive.
+func (ts *tickStore) seek(sqrtPrice sdk.Dec, isBuy, inclusive bool) (Tick, bool) {
+	if ts == nil {
+		return Tick{}, false
+	}
+	// the square root of a tick's price is rounded down, so the ticks whose square root
+	// equals sqrtPrice have prices in [sqrtPrice^2, (sqrtPrice+ulp)^2)
+	price := sqrtPrice.Mul(sqrtPrice)
+	var iter sdk.Iterator
+	if isBuy {
+		iter = ts.store.Iterator(getTickKey(ts.symbol, sdk.MaxDec(price.Sub(sdk.SmallestDec()), sdk.ZeroDec())), getTicksEnd(ts.symbol))
+	} else {
+		next := sqrtPrice.Add(sdk.SmallestDec())
+		iter = ts.store.ReverseIterator(getTicksBegin(ts.symbol), getTickKey(ts.symbol, next.Mul(next).Add(sdk.SmallestDec())))
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		tick := Tick{}
+		ts.codec.MustUnmarshalBinaryBare(iter.Value(), &tick)
+		if inclusive && tick.SqrtPrice.Equal(sqrtPrice) ||
+			isBuy && tick.SqrtPrice.GT(sqrtPrice) || !isBuy && tick.SqrtPrice.LT(sqrtPrice) {
+			return tick, true
+		}
+	}
+	return Tick{}, false
+}
+
+var decPrecisionMultiplier = new(big.Int).Exp(big.NewInt(10), big.NewInt(sdk.Precision), nil)
+
+// sqrtDec returns the square root of a non-negative decimal, rounded down
+func sqrtDec(d sdk.Dec) sdk.Dec {
+	root := new(big.Int).Mul(d.Int, decPrecisionMultiplier)
+	return sdk.NewDecFromBigIntWithPrec(root.Sqrt(root), sdk.Precision)
+}
+
+func ceilInt(d sdk.Dec) sdk.Int {
+	return d.Ceil().TruncateInt()
+}
+
+// rangeStep is a step of a trade with the range positions, which either trades within a range
+// or crosses a tick
+type rangeStep struct {
+	liquidity sdk.Int
+	amountOut sdk.Int
+	crossed   *Tick
+}
+
+// rangeSwapResult is the outcome of trading with the range positions of a pool
+type rangeSwapResult struct {
+	amountIn  sdk.Int
+	amountOut sdk.Int
+	sqrtPrice sdk.Dec
+	// the active liquidity at the new price
+	liquidity sdk.Int
+	// the ranges traded and the ticks crossed on the way, in order
+	steps []rangeStep
+}
+
+// rangeSwap trades at most amountIn with the range positions of the pool, moving the price towards
+// sqrtLimit and crossing the ticks on the way. A nil amountIn has no limit, and a nil sqrtLimit stops
+// the trade only when no liquidity is left. The amount in is rounded up and the amount out is rounded
+// down in each range. The pool itself is not changed.
+func (p PoolInfo) rangeSwap(amountIn sdk.Int, isBuy bool, sqrtLimit sdk.Dec) rangeSwapResult {
+	res := rangeSwapResult{amountIn: sdk.ZeroInt(), amountOut: sdk.ZeroInt(), sqrtPrice: p.SqrtPrice}
+	// the positions bounded below by the tick at the current price are active,
+	// so a sell crosses that tick only when the price moves below it
+	liquidity := p.ActiveLiquidity
+	var pending *Tick
+	if tick, ok := p.ticks.seek(p.SqrtPrice, false, true); ok && tick.SqrtPrice.Equal(p.SqrtPrice) && !isBuy {
+		pending = &tick
+	}
+	unlimited := amountIn == (sdk.Int{})
+	for unlimited || res.amountIn.LT(amountIn) {
+		curr := res.sqrtPrice
+		tick, ok := p.ticks.seek(curr, isBuy, false)
+		if !ok {
+			break
+		}
+		end := tick.SqrtPrice
+		if !sqrtLimit.IsNil() && (isBuy && sqrtLimit.LT(end) || !isBuy && sqrtLimit.GT(end)) {
+			end = sqrtLimit
+		}
+		if isBuy && !end.GT(curr) || !isBuy && !end.LT(curr) {
+			break
+		}
+		if pending != nil {
+			res.steps = append(res.steps, rangeStep{crossed: pending})
+			liquidity = liquidity.Sub(pending.LiquidityNet)
+			pending = nil
+		}
+		if liquidity.IsPositive() {
+			l := sdk.NewDecFromInt(liquidity)
+			need := ceilInt(l.Mul(end.Sub(curr)))
+			if !isBuy {
+				need = ceilInt(l.Mul(curr.Sub(end)).QuoRoundUp(curr.Mul(end)))
+			}
+			if !unlimited && amountIn.Sub(res.amountIn).LT(need) {
+				// the price stops inside this range
+				need = amountIn.Sub(res.amountIn)
+				if isBuy {
+					end = curr.Add(sdk.NewDecFromInt(need).QuoTruncate(l))
+				} else {
+					end = l.Mul(curr).QuoRoundUp(l.Add(sdk.NewDecFromInt(need).Mul(curr)))
+				}
+			}
+			out := l.Mul(curr.Sub(end)).TruncateInt()
+			if isBuy {
+				out = l.Mul(end.Sub(curr)).QuoTruncate(curr.Mul(end)).TruncateInt()
+			}
+			res.steps = append(res.steps, rangeStep{liquidity: liquidity, amountOut: out})
+			res.amountOut = res.amountOut.Add(out)
+			res.amountIn = res.amountIn.Add(need)
+		}
+		// no liquidity in this range, the price jumps to its end
+		res.sqrtPrice = end
+		if end.Equal(tick.SqrtPrice) {
+			if isBuy {
+				res.steps = append(res.steps, rangeStep{crossed: &tick})
+				liquidity = liquidity.Add(tick.LiquidityNet)
+			} else {
+				pending = &tick
+			}
+		}
+	}
+	res.liquidity = liquidity
+	return res
+}
+
+// dealWithRanges trades amountIn with the range positions and moves the price of the pool.
+// It returns the amount paid out and the amount of amountIn used, which is less than amountIn if
+// the liquidity runs out. The steps of the trade are kept until its fees are added to the pool.
+func (p *PoolInfo) dealWithRanges(amountIn sdk.Int, isBuy bool) (amountOut, amountUsed sdk.Int) {
+	res := p.rangeSwap(amountIn, isBuy, sdk.Dec{})
+	p.SqrtPrice = res.sqrtPrice
+	p.ActiveLiquidity = res.liquidity
+	p.rangeSteps = res.steps
+	return res.amountOut, res.amountIn
+}
+
+// addRangeFee shares the fees of a trade among the ranges it went through, in proportion to
+// the amounts paid out in each range, and flips the fee growth outside of the ticks crossed after
+// the ranges before them are credited. The fees of a trade without steps go to the active liquidity.
+// The fees which no liquidity can earn are returned.
+func (p *PoolInfo) addRangeFee(steps []rangeStep, stockFee, moneyFee sdk.Int) (stockLeft, moneyLeft sdk.Int) {
+	totalOut := sdk.ZeroInt()
+	for _, step := range steps {
+		if step.crossed == nil {
+			totalOut = totalOut.Add(step.amountOut)
+		}
+	}
+	if !totalOut.IsPositive() {
+		for _, step := range steps {
+			if step.crossed != nil {
+				p.crossTick(*step.crossed)
+			}
+		}
+		if !p.ActiveLiquidity.IsPositive() {
+			return stockFee, moneyFee
+		}
+		p.creditFee(p.ActiveLiquidity, stockFee, moneyFee)
+		return sdk.ZeroInt(), sdk.ZeroInt()
+	}
+	stockLeft, moneyLeft = stockFee, moneyFee
+	outLeft := totalOut
+	for _, step := range steps {
+		if step.crossed != nil {
+			p.crossTick(*step.crossed)
+			continue
+		}
+		if !step.amountOut.IsPositive() {
+			continue
+		}
+		// the last range paying out takes the rounding of the ones before
+		stock, money := stockLeft, moneyLeft
+		if outLeft.GT(step.amountOut) {
+			stock = stockFee.Mul(step.amountOut).Quo(totalOut)
+			money = moneyFee.Mul(step.amountOut).Quo(totalOut)
+		}
+		p.creditFee(step.liquidity, stock, money)
+		stockLeft, moneyLeft = stockLeft.Sub(stock), moneyLeft.Sub(money)
+		outLeft = outLeft.Sub(step.amountOut)
+	}
+	return stockLeft, moneyLeft
+}
+
+func (p *PoolInfo) creditFee(liquidity sdk.Int, stockFee, moneyFee sdk.Int) {
+	l := sdk.NewDecFromInt(liquidity)
+	p.FeeGrowthStock = p.FeeGrowthStock.Add(sdk.NewDecFromInt(stockFee).QuoTruncate(l))
+	p.FeeGrowthMoney = p.FeeGrowthMoney.Add(sdk.NewDecFromInt(moneyFee).QuoTruncate(l))
+}
+
+// crossTick flips the fee growth outside of a tick when the price crosses it
+func (p *PoolInfo) crossTick(tick Tick) {
+	tick.FeeGrowthOutsideStock = p.FeeGrowthStock.Sub(tick.FeeGrowthOutsideStock)
+	tick.FeeGrowthOutsideMoney = p.FeeGrowthMoney.Sub(tick.FeeGrowthOutsideMoney)
+	p.ticks.set(tick)
+}
+
+// rangeAmountIn returns the smallest amount in which makes the range positions pay out amountOut,
+// ok is false if there is not enough liquidity
+func (p PoolInfo) rangeAmountIn(amountOut sdk.Int, isBuy bool) (amountIn sdk.Int, ok bool) {
+	all := p.rangeSwap(sdk.Int{}, isBuy, sdk.Dec{})
+	if all.amountOut.LT(amountOut) {
+		return sdk.ZeroInt(), false
+	}
+	low, high := sdk.ZeroInt(), all.amountIn
+	for low.LT(high) {
+		mid := low.Add(high).QuoRaw(2)
+		if p.rangeSwap(mid, isBuy, sdk.Dec{}).amountOut.LT(amountOut) {
+			low = mid.AddRaw(1)
+		} else {
+			high = mid
+		}
+	}
+	return high, true
+}
+
+// rangeAmounts returns the stock and money held by liquidity within [sqrtLower, sqrtUpper) at the current price
+func (p PoolInfo) rangeAmounts(liquidity sdk.Int, sqrtLower, sqrtUpper sdk.Dec, roundUp bool) (stock, money sdk.Int) {
+	l := sdk.NewDecFromInt(liquidity)
+	stockDec, moneyDec := sdk.ZeroDec(), sdk.ZeroDec()
+	if p.SqrtPrice.LT(sqrtUpper) {
+		from := sdk.MaxDec(p.SqrtPrice, sqrtLower)
+		if roundUp {
+			stockDec = l.Mul(sqrtUpper.Sub(from)).QuoRoundUp(from.Mul(sqrtUpper))
+		} else {
+			stockDec = l.Mul(sqrtUpper.Sub(from)).QuoTruncate(from.Mul(sqrtUpper))
+		}
+	}
+	if p.SqrtPrice.GT(sqrtLower) {
+		moneyDec = l.Mul(sdk.MinDec(p.SqrtPrice, sqrtUpper).Sub(sqrtLower))
+	}
+	if roundUp {
+		return ceilInt(stockDec), ceilInt(moneyDec)
+	}
+	return stockDec.TruncateInt(), moneyDec.TruncateInt()
+}
+
+// rangeLiquidityForAmounts returns the largest liquidity within [sqrtLower, sqrtUpper) the amounts can provide at the current price
+func (p PoolInfo) rangeLiquidityForAmounts(stockAmount, moneyAmount sdk.Int, sqrtLower, sqrtUpper sdk.Dec) sdk.Int {
+	fromStock := func(from sdk.Dec) sdk.Dec {
+		return sdk.NewDecFromInt(stockAmount).Mul(from).Mul(sqrtUpper).QuoTruncate(sqrtUpper.Sub(from))
+	}
+	fromMoney := func(to sdk.Dec) sdk.Dec {
+		return sdk.NewDecFromInt(moneyAmount).QuoTruncate(to.Sub(sqrtLower))
+	}
+	switch {
+	case p.SqrtPrice.LTE(sqrtLower):
+		return fromStock(sqrtLower).TruncateInt()
+	case p.SqrtPrice.GTE(sqrtUpper):
+		return fromMoney(sqrtUpper).TruncateInt()
+	default:
+		return sdk.MinDec(fromStock(p.SqrtPrice), fromMoney(p.SqrtPrice)).TruncateInt()
+	}
+}
+
+// getOrNewTick returns the tick at price, or a new one if it doesn't exist. All the fee growth before
+// is assumed to happen below a new tick, so it is only counted by the positions still using the tick.
+func (p PoolInfo) getOrNewTick(price sdk.Dec) Tick {
+	if tick := p.ticks.get(price); tick != nil {
+		return *tick
+	}
+	tick := Tick{
+		Symbol:                p.Symbol,
+		Price:                 price,
+		SqrtPrice:             sqrtDec(price),
+		LiquidityNet:          sdk.ZeroInt(),
+		LiquidityGross:        sdk.ZeroInt(),
+		FeeGrowthOutsideStock: sdk.ZeroDec(),
+		FeeGrowthOutsideMoney: sdk.ZeroDec(),
+	}
+	if tick.SqrtPrice.LTE(p.SqrtPrice) {
+		tick.FeeGrowthOutsideStock = p.FeeGrowthStock
+		tick.FeeGrowthOutsideMoney = p.FeeGrowthMoney
+	}
+	return tick
+}
+
+// setOrDeleteTick stores a tick, or deletes it if no position is bounded by it
+func (p PoolInfo) setOrDeleteTick(tick Tick) {
+	if tick.LiquidityGross.IsPositive() {
+		p.ticks.set(tick)
+	} else {
+		p.ticks.delete(tick.Price)
+	}
+}
+
+// feeGrowthInside returns the fee growth per liquidity within the range of the two ticks
+func (p PoolInfo) feeGrowthInside(lower, upper Tick) (stock, money sdk.Dec) {
+	stock, money = p.FeeGrowthStock, p.FeeGrowthMoney
+	if lower.SqrtPrice.LTE(p.SqrtPrice) {
+		stock, money = stock.Sub(lower.FeeGrowthOutsideStock), money.Sub(lower.FeeGrowthOutsideMoney)
+	} else {
+		stock, money = lower.FeeGrowthOutsideStock, lower.FeeGrowthOutsideMoney
+	}
+	if upper.SqrtPrice.LTE(p.SqrtPrice) {
+		return stock.Sub(p.FeeGrowthStock).Add(upper.FeeGrowthOutsideStock), money.Sub(p.FeeGrowthMoney).Add(upper.FeeGrowthOutsideMoney)
+	}
+	return stock.Sub(upper.FeeGrowthOutsideStock), money.Sub(upper.FeeGrowthOutsideMoney)
+}
+
+// modifyRangePosition settles the fees earned by a position, then adds delta to its liquidity
+// and to the ticks bounding it
+func (p *PoolInfo) modifyRangePosition(pos *RangePosition, delta sdk.Int) {
+	lower, upper := p.getOrNewTick(pos.LowerPrice), p.getOrNewTick(pos.UpperPrice)
+	stockGrowth, moneyGrowth := p.feeGrowthInside(lower, upper)
+	l := sdk.NewDecFromInt(pos.Liquidity)
+	pos.FeeStockOwed = pos.FeeStockOwed.Add(l.Mul(stockGrowth.Sub(pos.FeeGrowthInsideStock)).TruncateInt())
+	pos.FeeMoneyOwed = pos.FeeMoneyOwed.Add(l.Mul(moneyGrowth.Sub(pos.FeeGrowthInsideMoney)).TruncateInt())
+	pos.FeeGrowthInsideStock, pos.FeeGrowthInsideMoney = stockGrowth, moneyGrowth
+	pos.Liquidity = pos.Liquidity.Add(delta)
+
+	lower.LiquidityNet = lower.LiquidityNet.Add(delta)
+	lower.LiquidityGross = lower.LiquidityGross.Add(delta)
+	upper.LiquidityNet = upper.LiquidityNet.Sub(delta)
+	upper.LiquidityGross = upper.LiquidityGross.Add(delta)
+	p.setOrDeleteTick(lower)
+	p.setOrDeleteTick(upper)
+	if lower.SqrtPrice.LTE(p.SqrtPrice) && p.SqrtPrice.LT(upper.SqrtPrice) {
+		p.ActiveLiquidity = p.ActiveLiquidity.Add(delta)
+	}
+}
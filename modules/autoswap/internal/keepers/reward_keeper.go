@@ -0,0 +1,285 @@
+package keepers
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+)
+
+// RewardProgram pays RewardPerBlock of Denom to the liquidity providers of a pool pro rata, till the
+// funded amount runs out. Nothing is paid for the blocks in which the pool has no liquidity.
+type RewardProgram struct {
+	Symbol         string  `json:"symbol"`
+	Denom          string  `json:"denom"`
+	RewardPerBlock sdk.Int `json:"reward_per_block"`
+	// the funded amount which is not paid yet
+	Remaining sdk.Int `json:"remaining"`
+	// the rewards paid per liquidity since the program was created
+	RewardPerShare   sdk.Dec `json:"reward_per_share"`
+	LastRewardHeight int64   `json:"last_reward_height"`
+
+	// the amount funded by each funder, by which what remains is refunded when the pool is delisted
+	Funders []RewardFunder `json:"funders"`
+}
+
+// RewardFunder is an address which funds a reward program and the amount it has funded
+type RewardFunder struct {
+	Address sdk.AccAddress `json:"address"`
+	Amount  sdk.Int        `json:"amount"`
+}
+
+// update pays the rewards of the blocks till height to totalSupply of liquidity
+func (r *RewardProgram) update(height int64, totalSupply sdk.Int) {
+	blocks := height - r.LastRewardHeight
+	if blocks <= 0 {
+		return
+	}
+	r.LastRewardHeight = height
+	if !totalSupply.IsPositive() {
+		return
+	}
+	reward := sdk.MinInt(r.RewardPerBlock.MulRaw(blocks), r.Remaining)
+	perShare := sdk.NewDecFromInt(reward).QuoTruncate(sdk.NewDecFromInt(totalSupply))
+	r.RewardPerShare = r.RewardPerShare.Add(perShare)
+	// only the amount which can be earned is paid, the rest is kept for the later blocks
+	r.Remaining = r.Remaining.Sub(perShare.MulInt(totalSupply).TruncateInt())
+}
+
+// LiquidityReward is the reward a liquidity provider earns from the reward program of a pool
+type LiquidityReward struct {
+	Symbol string         `json:"symbol"`
+	Owner  sdk.AccAddress `json:"owner"`
+	// RewardPerShare of the program when the reward was last settled
+	RewardPerShare sdk.Dec `json:"reward_per_share"`
+	// the reward earned and not claimed yet
+	Unclaimed sdk.Int `json:"unclaimed"`
+}
+
+func (r LiquidityReward) earned(prog *RewardProgram, liquidity sdk.Int) sdk.Int {
+	return r.Unclaimed.Add(prog.RewardPerShare.Sub(r.RewardPerShare).MulInt(liquidity).TruncateInt())
+}
+
+// FundRewardProgram adds amount of denom to the reward program of a pool, and creates the program
+// if it doesn't exist. The caller sends the amount from funder to the pool.
+func (p PoolKeeper) FundRewardProgram(ctx sdk.Context, marketSymbol string, funder sdk.AccAddress, denom string, amount, rewardPerBlock sdk.Int) sdk.Error {
+	info := p.GetPoolInfo(ctx, marketSymbol)
+	if info == nil {
+		return types.ErrPairIsNotExist()
+	}
+	// a pool with range liquidity has no LP shares to reward
+	if info.RangeLiquidity {
+		return types.ErrInvalidPoolType(marketSymbol, true)
+	}
+	prog := p.GetRewardProgram(ctx, marketSymbol)
+	if prog == nil {
+		if !rewardPerBlock.IsPositive() {
+			return types.ErrInvalidRewardProgram("the reward per block must be positive")
+		}
+		prog = &RewardProgram{
+			Symbol:           marketSymbol,
+			Denom:            denom,
+			RewardPerBlock:   rewardPerBlock,
+			Remaining:        sdk.ZeroInt(),
+			RewardPerShare:   sdk.ZeroDec(),
+			LastRewardHeight: ctx.BlockHeight(),
+		}
+	} else {
+		prog.update(ctx.BlockHeight(), info.TotalSupply)
+		if prog.Denom != denom {
+			return types.ErrInvalidRewardProgram(fmt.Sprintf("the rewards of pool %s are paid in %s", marketSymbol, prog.Denom))
+		}
+		if rewardPerBlock.IsPositive() && !rewardPerBlock.Equal(prog.RewardPerBlock) {
+			if prog.Remaining.IsPositive() {
+				return types.ErrInvalidRewardProgram("the reward per block can't be changed before the program runs out")
+			}
+			prog.RewardPerBlock = rewardPerBlock
+		}
+	}
+	prog.Remaining = prog.Remaining.Add(amount)
+	prog.addFunder(funder, amount)
+	p.SetRewardProgram(ctx, *prog)
+	return nil
+}
+
+func (r *RewardProgram) addFunder(funder sdk.AccAddress, amount sdk.Int) {
+	for i := range r.Funders {
+		if r.Funders[i].Address.Equals(funder) {
+			r.Funders[i].Amount = r.Funders[i].Amount.Add(amount)
+			return
+		}
+	}
+	r.Funders = append(r.Funders, RewardFunder{Address: funder, Amount: amount})
+}
+
+// refunds splits what remains of the program among the funders pro rata, and the last funder gets the dust
+func (r *RewardProgram) refunds() []RewardFunder {
+	total := sdk.ZeroInt()
+	for _, funder := range r.Funders {
+		total = total.Add(funder.Amount)
+	}
+	if !total.IsPositive() || !r.Remaining.IsPositive() {
+		return nil
+	}
+	refunds := make([]RewardFunder, 0, len(r.Funders))
+	left := r.Remaining
+	for i, funder := range r.Funders {
+		amount := left
+		if i != len(r.Funders)-1 {
+			amount = r.Remaining.Mul(funder.Amount).Quo(total)
+		}
+		left = left.Sub(amount)
+		if amount.IsPositive() {
+			refunds = append(refunds, RewardFunder{Address: funder.Address, Amount: amount})
+		}
+	}
+	return refunds
+}
+
+// RemoveRewardProgram removes the reward program of a pool and the rewards of its liquidity providers, so
+// that a pool created later under the same symbol starts without them. It returns the denom of the program,
+// the rewards not claimed yet and the refunds of the funders. The caller sends them from the pool.
+func (p PoolKeeper) RemoveRewardProgram(ctx sdk.Context, marketSymbol string) (denom string, rewards []LiquidityReward, refunds []RewardFunder) {
+	prog := p.GetRewardProgram(ctx, marketSymbol)
+	if prog == nil {
+		return "", nil, nil
+	}
+	prog.update(ctx.BlockHeight(), p.getTotalSupply(ctx, marketSymbol))
+	store := ctx.KVStore(p.key)
+	iter := sdk.KVStorePrefixIterator(store, getRewardKey(marketSymbol, nil))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		reward := LiquidityReward{}
+		p.codec.MustUnmarshalBinaryBare(iter.Value(), &reward)
+		reward.Unclaimed = reward.earned(prog, p.GetLiquidity(ctx, marketSymbol, reward.Owner))
+		reward.RewardPerShare = prog.RewardPerShare
+		if reward.Unclaimed.IsPositive() {
+			rewards = append(rewards, reward)
+		}
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	store.Delete(getRewardProgramKey(marketSymbol))
+	return prog.Denom, rewards, prog.refunds()
+}
+
+// ClaimRewards takes out all the rewards owner earned from the reward program of a pool, and returns
+// them. The caller sends them to the owner.
+func (p PoolKeeper) ClaimRewards(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress) (sdk.Coins, sdk.Error) {
+	prog := p.GetRewardProgram(ctx, marketSymbol)
+	if prog == nil {
+		return nil, types.ErrRewardProgramNotExist(marketSymbol)
+	}
+	p.settleRewards(ctx, marketSymbol, p.getTotalSupply(ctx, marketSymbol), owner)
+	reward := p.GetLiquidityReward(ctx, marketSymbol, owner)
+	amount := reward.Unclaimed
+	if p.GetLiquidity(ctx, marketSymbol, owner).IsZero() {
+		p.clearLiquidityReward(ctx, marketSymbol, owner)
+	} else {
+		reward.Unclaimed = sdk.ZeroInt()
+		p.SetLiquidityReward(ctx, reward)
+	}
+	return sdk.NewCoins(sdk.NewCoin(prog.Denom, amount)), nil
+}
+
+// GetUnclaimedRewards returns the rewards owner can claim from the reward program of a pool now
+func (p PoolKeeper) GetUnclaimedRewards(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress) sdk.Int {
+	prog := p.GetRewardProgram(ctx, marketSymbol)
+	if prog == nil {
+		return sdk.ZeroInt()
+	}
+	prog.update(ctx.BlockHeight(), p.getTotalSupply(ctx, marketSymbol))
+	return p.GetLiquidityReward(ctx, marketSymbol, owner).earned(prog, p.GetLiquidity(ctx, marketSymbol, owner))
+}
+
+// settleRewards pays the rewards of the reward program of a pool till the current block, and settles
+// the rewards earned by owners with their current liquidity. It must be called before the total supply
+// of the pool or the liquidity of owners is changed.
+func (p PoolKeeper) settleRewards(ctx sdk.Context, marketSymbol string, totalSupply sdk.Int, owners ...sdk.AccAddress) {
+	prog := p.GetRewardProgram(ctx, marketSymbol)
+	if prog == nil {
+		return
+	}
+	prog.update(ctx.BlockHeight(), totalSupply)
+	p.SetRewardProgram(ctx, *prog)
+	for _, owner := range owners {
+		reward := p.GetLiquidityReward(ctx, marketSymbol, owner)
+		reward.Unclaimed = reward.earned(prog, p.GetLiquidity(ctx, marketSymbol, owner))
+		reward.RewardPerShare = prog.RewardPerShare
+		p.SetLiquidityReward(ctx, reward)
+	}
+}
+
+func (p PoolKeeper) getTotalSupply(ctx sdk.Context, marketSymbol string) sdk.Int {
+	if info := p.GetPoolInfo(ctx, marketSymbol); info != nil {
+		return info.TotalSupply
+	}
+	return sdk.ZeroInt()
+}
+
+func (p PoolKeeper) SetRewardProgram(ctx sdk.Context, prog RewardProgram) {
+	store := ctx.KVStore(p.key)
+	store.Set(getRewardProgramKey(prog.Symbol), p.codec.MustMarshalBinaryBare(prog))
+}
+
+func (p PoolKeeper) GetRewardProgram(ctx sdk.Context, marketSymbol string) *RewardProgram {
+	store := ctx.KVStore(p.key)
+	bytes := store.Get(getRewardProgramKey(marketSymbol))
+	if bytes == nil {
+		return nil
+	}
+	prog := &RewardProgram{}
+	p.codec.MustUnmarshalBinaryBare(bytes, prog)
+	return prog
+}
+
+func (p PoolKeeper) IterateRewardPrograms(ctx sdk.Context, progProc func(prog RewardProgram)) {
+	store := ctx.KVStore(p.key)
+	iter := store.Iterator(RewardProgramKey, RewardProgramEndKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		prog := RewardProgram{}
+		p.codec.MustUnmarshalBinaryBare(iter.Value(), &prog)
+		progProc(prog)
+	}
+}
+
+func (p PoolKeeper) SetLiquidityReward(ctx sdk.Context, reward LiquidityReward) {
+	store := ctx.KVStore(p.key)
+	store.Set(getRewardKey(reward.Symbol, reward.Owner), p.codec.MustMarshalBinaryBare(reward))
+}
+
+func (p PoolKeeper) clearLiquidityReward(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress) {
+	store := ctx.KVStore(p.key)
+	store.Delete(getRewardKey(marketSymbol, owner))
+}
+
+// GetLiquidityReward returns the reward of owner in a pool, which has earned nothing if it isn't found
+func (p PoolKeeper) GetLiquidityReward(ctx sdk.Context, marketSymbol string, owner sdk.AccAddress) LiquidityReward {
+	store := ctx.KVStore(p.key)
+	reward := LiquidityReward{
+		Symbol:         marketSymbol,
+		Owner:          owner,
+		RewardPerShare: sdk.ZeroDec(),
+		Unclaimed:      sdk.ZeroInt(),
+	}
+	if bytes := store.Get(getRewardKey(marketSymbol, owner)); bytes != nil {
+		p.codec.MustUnmarshalBinaryBare(bytes, &reward)
+	}
+	return reward
+}
+
+func (p PoolKeeper) IterateAllLiquidityRewards(ctx sdk.Context, rewardProc func(reward LiquidityReward)) {
+	store := ctx.KVStore(p.key)
+	iter := store.Iterator(RewardKey, RewardEndKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		reward := LiquidityReward{}
+		p.codec.MustUnmarshalBinaryBare(iter.Value(), &reward)
+		rewardProc(reward)
+	}
+}
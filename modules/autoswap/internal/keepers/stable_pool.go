@@ -0,0 +1,181 @@
+package keepers
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// A pool on the StableSwap curve keeps the invariant D of its reserves x and y:
+//   4A(x+y) + D = 4AD + D^3/(4xy)
+// where A is the amplification. The curve is close to x+y=D around the balanced reserves, which
+// gives the pairs of pegged assets much more depth than x*y=k, and approaches x*y=k as A gets small.
+
+const stableMaxIterations = 255
+
+// stableD returns the invariant of the reserves x and y by Newton's method,
+// it is zero if any of the reserves is empty
+func stableD(x, y *big.Int, amp int64) *big.Int {
+	if x.Sign() <= 0 || y.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+	ann := big.NewInt(amp * 4)
+	sum := new(big.Int).Add(x, y)
+	d := new(big.Int).Set(sum)
+	for i := 0; i < stableMaxIterations; i++ {
+		// dP = D^3/(4xy)
+		dP := new(big.Int).Mul(d, d)
+		dP.Quo(dP, new(big.Int).Lsh(x, 1))
+		dP.Mul(dP, d)
+		dP.Quo(dP, new(big.Int).Lsh(y, 1))
+		// D = (Ann*S + 2*dP)*D / ((Ann-1)*D + 3*dP)
+		numerator := new(big.Int).Mul(ann, sum)
+		numerator.Add(numerator, new(big.Int).Lsh(dP, 1))
+		numerator.Mul(numerator, d)
+		denominator := new(big.Int).Sub(ann, big.NewInt(1))
+		denominator.Mul(denominator, d)
+		denominator.Add(denominator, new(big.Int).Mul(big.NewInt(3), dP))
+		prev := d
+		d = numerator.Quo(numerator, denominator)
+		if closeEnough(d, prev) {
+			break
+		}
+	}
+	return d
+}
+
+// stableY returns the reserve of one token which keeps the invariant d, when the reserve of the other
+// token is x. x must be positive.
+func stableY(x, d *big.Int, amp int64) *big.Int {
+	ann := big.NewInt(amp * 4)
+	// y^2 + (b-D)*y = c, where b = x + D/Ann and c = D^3/(4x*Ann)
+	c := new(big.Int).Mul(d, d)
+	c.Quo(c, new(big.Int).Lsh(x, 1))
+	c.Mul(c, d)
+	c.Quo(c, new(big.Int).Lsh(ann, 1))
+	b := new(big.Int).Quo(d, ann)
+	b.Add(b, x)
+	y := new(big.Int).Set(d)
+	for i := 0; i < stableMaxIterations; i++ {
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Lsh(y, 1)
+		denominator.Add(denominator, b)
+		denominator.Sub(denominator, d)
+		prev := y
+		y = numerator.Quo(numerator, denominator)
+		if closeEnough(y, prev) {
+			break
+		}
+	}
+	return y
+}
+
+func closeEnough(a, b *big.Int) bool {
+	diff := new(big.Int).Sub(a, b)
+	return diff.CmpAbs(big.NewInt(1)) <= 0
+}
+
+// stablePrice returns the marginal price of stock in money on the StableSwap curve, which is
+// (16A*x^2*y + D^3)*y / ((16A*x*y^2 + D^3)*x) for the stock reserve x and the money reserve y
+func stablePrice(stock, money, d *big.Int, amp int64) sdk.Dec {
+	d3 := new(big.Int).Mul(d, d)
+	d3.Mul(d3, d)
+	a16xy := new(big.Int).Mul(big.NewInt(amp*16), stock)
+	a16xy.Mul(a16xy, money)
+	numerator := new(big.Int).Mul(a16xy, stock)
+	numerator.Add(numerator, d3)
+	numerator.Mul(numerator, money)
+	numerator.Mul(numerator, decPrecisionMultiplier)
+	denominator := new(big.Int).Mul(a16xy, money)
+	denominator.Add(denominator, d3)
+	denominator.Mul(denominator, stock)
+	return sdk.NewDecFromBigIntWithPrec(numerator.Quo(numerator, denominator), sdk.Precision)
+}
+
+// the AMM reserves of the token paid in and the token paid out
+func (p PoolInfo) stableReserves(isBuy bool) (in, out *big.Int) {
+	if isBuy {
+		return p.MoneyAmmReserve.BigInt(), p.StockAmmReserve.BigInt()
+	}
+	return p.StockAmmReserve.BigInt(), p.MoneyAmmReserve.BigInt()
+}
+
+// stableAmountOut returns the amount paid out for amountIn on the StableSwap curve, rounded down
+func (p PoolInfo) stableAmountOut(amountIn sdk.Int, isBuy bool) sdk.Int {
+	in, out := p.stableReserves(isBuy)
+	if in.Sign() <= 0 || out.Sign() <= 0 || !amountIn.IsPositive() {
+		return sdk.ZeroInt()
+	}
+	d := stableD(in, out, p.Amplification)
+	y := stableY(in.Add(in, amountIn.BigInt()), d, p.Amplification)
+	// one more is kept in the pool against the rounding of y
+	amountOut := out.Sub(out, y)
+	amountOut.Sub(amountOut, big.NewInt(1))
+	if amountOut.Sign() <= 0 {
+		return sdk.ZeroInt()
+	}
+	return sdk.NewIntFromBigInt(amountOut)
+}
+
+// stableAmountIn returns the amount in for getting amountOut on the StableSwap curve,
+// ok is false if the pool doesn't have enough liquidity
+func (p PoolInfo) stableAmountIn(amountOut sdk.Int, isBuy bool) (amountIn sdk.Int, ok bool) {
+	in, out := p.stableReserves(isBuy)
+	if in.Sign() <= 0 || amountOut.AddRaw(1).BigInt().Cmp(out) >= 0 {
+		return sdk.ZeroInt(), false
+	}
+	// the curve is symmetric, so the reserve in is the one which keeps the invariant with the reserve out
+	d := stableD(in, out, p.Amplification)
+	x := stableY(out.Sub(out, amountOut.AddRaw(1).BigInt()), d, p.Amplification)
+	amountIn = sdk.MaxInt(sdk.NewIntFromBigInt(x.Sub(x, in)), sdk.OneInt())
+	// cover the rounding of the curve
+	for i := 0; i < stableMaxIterations; i++ {
+		if p.stableAmountOut(amountIn, isBuy).GTE(amountOut) {
+			return amountIn, true
+		}
+		amountIn = amountIn.AddRaw(1)
+	}
+	return sdk.ZeroInt(), false
+}
+
+// stableAmountTillPrice returns the largest amount in after which the price of the StableSwap curve
+// doesn't go beyond price, by binary search
+func (p PoolInfo) stableAmountTillPrice(price sdk.Dec, isBuy bool) sdk.Int {
+	stock, money := p.StockAmmReserve.BigInt(), p.MoneyAmmReserve.BigInt()
+	if stock.Sign() <= 0 || money.Sign() <= 0 {
+		return sdk.ZeroInt()
+	}
+	d := stableD(stock, money, p.Amplification)
+	beyond := func(amountIn *big.Int) bool {
+		if isBuy {
+			newMoney := new(big.Int).Add(money, amountIn)
+			newStock := stableY(newMoney, d, p.Amplification)
+			return newStock.Sign() <= 0 || stablePrice(newStock, newMoney, d, p.Amplification).GT(price)
+		}
+		newStock := new(big.Int).Add(stock, amountIn)
+		newMoney := stableY(newStock, d, p.Amplification)
+		return newMoney.Sign() <= 0 || stablePrice(newStock, newMoney, d, p.Amplification).LT(price)
+	}
+	low := big.NewInt(0)
+	if beyond(low) {
+		return sdk.ZeroInt()
+	}
+	high, _ := p.stableReserves(isBuy)
+	for i := 0; !beyond(high); i++ {
+		if i == stableMaxIterations {
+			return sdk.NewIntFromBigInt(high)
+		}
+		high.Lsh(high, 1)
+	}
+	for new(big.Int).Sub(high, low).Cmp(big.NewInt(1)) > 0 {
+		mid := new(big.Int).Add(low, high)
+		mid.Rsh(mid, 1)
+		if beyond(mid) {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return sdk.NewIntFromBigInt(low)
+}
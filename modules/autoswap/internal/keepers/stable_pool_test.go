@@ -0,0 +1,43 @@
+package keepers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+)
+
+func newStablePool(stock, money, amplification int64) *keepers.PoolInfo {
+	return &keepers.PoolInfo{StockAmmReserve: sdk.NewInt(stock), MoneyAmmReserve: sdk.NewInt(money),
+		CurveType: types.CurveStableSwap, Amplification: amplification}
+}
+
+func TestStablePool_AmountOut(t *testing.T) {
+	cp := &keepers.PoolInfo{StockAmmReserve: sdk.NewInt(1000000), MoneyAmmReserve: sdk.NewInt(1000000)}
+	require.Equal(t, sdk.NewInt(90909), keepers.GetAmountOutInPool(sdk.NewInt(100000), cp, true))
+	// much more depth than x*y=k, which grows with the amplification
+	require.Equal(t, sdk.NewInt(99949), keepers.GetAmountOutInPool(sdk.NewInt(100000), newStablePool(1000000, 1000000, 100), true))
+	require.Equal(t, sdk.NewInt(96760), keepers.GetAmountOutInPool(sdk.NewInt(100000), newStablePool(1000000, 1000000, 1), true))
+	// the curve is symmetric
+	require.Equal(t, sdk.NewInt(99949), keepers.GetAmountOutInPool(sdk.NewInt(100000), newStablePool(1000000, 1000000, 100), false))
+	require.True(t, keepers.GetAmountOutInPool(sdk.NewInt(100000), newStablePool(0, 1000000, 100), true).IsZero())
+}
+
+func TestStablePool_IntoPoolAmountTillPrice(t *testing.T) {
+	info := newStablePool(1000000, 1000000, 100)
+	price := sdk.MustNewDecFromStr("1.01")
+	amountIn := keepers.IntoPoolAmountTillPrice(price, true, info)
+	require.Equal(t, sdk.NewInt(527872), amountIn)
+	require.Equal(t, sdk.NewInt(529983), keepers.IntoPoolAmountTillPrice(sdk.MustNewDecFromStr("0.99"), false, info))
+	require.True(t, keepers.IntoPoolAmountTillPrice(sdk.MustNewDecFromStr("0.99"), true, info).IsZero())
+
+	// the price reaches 1.01 after the deal, so nothing more can be dealt till 1.01
+	amountOut := keepers.GetAmountOutInPool(amountIn, info, true)
+	after := newStablePool(1000000-amountOut.Int64(), 1000000+amountIn.Int64(), 100)
+	require.True(t, keepers.IntoPoolAmountTillPrice(price, true, after).IsZero())
+	require.True(t, keepers.IntoPoolAmountTillPrice(sdk.MustNewDecFromStr("1.02"), true, after).IsPositive())
+}
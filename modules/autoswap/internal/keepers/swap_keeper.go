@@ -0,0 +1,179 @@
+package keepers
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+// swapHop trades tokenIn for tokenOut against the AMM of one pool in a swap path
+type swapHop struct {
+	tokenIn  string
+	tokenOut string
+	symbol   string
+	// isBuy is true when tokenIn is the money of the pool
+	isBuy bool
+}
+
+// calDealWithPoolFee returns the fee charged on the amount which the pool pays out, rounded up
+func calDealWithPoolFee(amountOut sdk.Int, feeRate int64) sdk.Int {
+	return amountOut.Mul(sdk.NewInt(feeRate)).Add(sdk.NewInt(types.DefaultFeePrecision - 1)).Quo(sdk.NewInt(types.DefaultFeePrecision))
+}
+
+// the smallest amount the pool must pay out, so that amountOut is left after the fee
+func grossAmountOut(amountOut sdk.Int, feeRate int64) sdk.Int {
+	precision := sdk.NewInt(types.DefaultFeePrecision)
+	rest := precision.SubRaw(feeRate)
+	gross := amountOut.Mul(precision).Add(rest).SubRaw(1).Quo(rest)
+	for gross.Sub(calDealWithPoolFee(gross, feeRate)).LT(amountOut) {
+		gross = gross.AddRaw(1)
+	}
+	return gross
+}
+
+func (pk *PairKeeper) getSwapHops(ctx sdk.Context, path []string) ([]swapHop, sdk.Error) {
+	if err := types.ValidateSwapPath(path); err != nil {
+		return nil, err
+	}
+	hops := make([]swapHop, len(path)-1)
+	for i := range hops {
+		hop := swapHop{tokenIn: path[i], tokenOut: path[i+1]}
+		if symbol := dex.GetSymbol(hop.tokenOut, hop.tokenIn); pk.GetPoolInfo(ctx, symbol) != nil {
+			hop.symbol, hop.isBuy = symbol, true
+		} else if symbol := dex.GetSymbol(hop.tokenIn, hop.tokenOut); pk.GetPoolInfo(ctx, symbol) != nil {
+			hop.symbol = symbol
+		} else {
+			return nil, types.ErrInvalidSwapPath("no pool between " + hop.tokenIn + " and " + hop.tokenOut)
+		}
+		hops[i] = hop
+	}
+	return hops, nil
+}
+
+// GetAmountsOut returns the amounts of every token in path when swapping amountIn of path[0].
+// The fee of each hop is deducted from the amount the pool pays out.
+func (pk *PairKeeper) GetAmountsOut(ctx sdk.Context, path []string, amountIn sdk.Int) ([]sdk.Int, sdk.Error) {
+	hops, err := pk.getSwapHops(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return pk.getAmountsOut(ctx, hops, amountIn)
+}
+
+func (pk *PairKeeper) getAmountsOut(ctx sdk.Context, hops []swapHop, amountIn sdk.Int) ([]sdk.Int, sdk.Error) {
+	feeRate := pk.GetParams(ctx).DealWithPoolFeeRate
+	amounts := make([]sdk.Int, len(hops)+1)
+	amounts[0] = amountIn
+	for i, hop := range hops {
+		info := pk.GetPoolInfo(ctx, hop.symbol)
+		if !info.canDealWithAmm(amounts[i], hop.isBuy) {
+			return nil, types.ErrInvalidSwapPath("no enough liquidity in pool " + hop.symbol)
+		}
+		out := GetAmountOutInPool(amounts[i], info, hop.isBuy)
+		amounts[i+1] = out.Sub(calDealWithPoolFee(out, feeRate))
+		if !amounts[i+1].IsPositive() {
+			return nil, types.ErrAmountOutIsSmallerThanExpected(sdk.OneInt(), amounts[i+1])
+		}
+	}
+	return amounts, nil
+}
+
+// GetAmountsIn returns the amounts of every token in path needed to get amountOut of the last token
+func (pk *PairKeeper) GetAmountsIn(ctx sdk.Context, path []string, amountOut sdk.Int) ([]sdk.Int, sdk.Error) {
+	hops, err := pk.getSwapHops(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return pk.getAmountsIn(ctx, hops, amountOut)
+}
+
+func (pk *PairKeeper) getAmountsIn(ctx sdk.Context, hops []swapHop, amountOut sdk.Int) ([]sdk.Int, sdk.Error) {
+	feeRate := pk.GetParams(ctx).DealWithPoolFeeRate
+	amounts := make([]sdk.Int, len(hops)+1)
+	amounts[len(hops)] = amountOut
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := hops[i]
+		amountIn, ok := getAmountInInPool(grossAmountOut(amounts[i+1], feeRate), pk.GetPoolInfo(ctx, hop.symbol), hop.isBuy)
+		if !ok {
+			return nil, types.ErrInvalidSwapPath("no enough liquidity in pool " + hop.symbol)
+		}
+		amounts[i] = amountIn
+	}
+	return amounts, nil
+}
+
+// SwapExactIn swaps amountIn of path[0] for the last token of path, which is sent to `to`.
+// Nothing is executed if the final amount is less than minAmountOut.
+func (pk *PairKeeper) SwapExactIn(ctx sdk.Context, sender, to sdk.AccAddress, path []string, amountIn, minAmountOut sdk.Int) ([]sdk.Int, sdk.Error) {
+	hops, err := pk.getSwapHops(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := pk.getAmountsOut(ctx, hops, amountIn)
+	if err != nil {
+		return nil, err
+	}
+	if amountOut := amounts[len(hops)]; amountOut.LT(minAmountOut) {
+		return nil, types.ErrAmountOutIsSmallerThanExpected(minAmountOut, amountOut)
+	}
+	return pk.executeSwap(ctx, sender, to, hops, amountIn)
+}
+
+// SwapExactOut swaps the last token of path with at least amountOut from path[0], and sends it to `to`.
+// Nothing is executed if more than maxAmountIn of path[0] is needed.
+func (pk *PairKeeper) SwapExactOut(ctx sdk.Context, sender, to sdk.AccAddress, path []string, amountOut, maxAmountIn sdk.Int) ([]sdk.Int, sdk.Error) {
+	hops, err := pk.getSwapHops(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := pk.getAmountsIn(ctx, hops, amountOut)
+	if err != nil {
+		return nil, err
+	}
+	if amounts[0].GT(maxAmountIn) {
+		return nil, types.ErrAmountInIsLargerThanExpected(maxAmountIn, amounts[0])
+	}
+	return pk.executeSwap(ctx, sender, to, hops, amounts[0])
+}
+
+// executeSwap trades against the pools hop by hop, the output of one hop is the input of the next one
+func (pk *PairKeeper) executeSwap(ctx sdk.Context, sender, to sdk.AccAddress, hops []swapHop, amountIn sdk.Int) ([]sdk.Int, sdk.Error) {
+	feeRate := pk.GetParams(ctx).DealWithPoolFeeRate
+	amounts := make([]sdk.Int, len(hops)+1)
+	amounts[0] = amountIn
+	for i, hop := range hops {
+		info := pk.GetPoolInfo(ctx, hop.symbol)
+		// only the amount the pool takes is sent to it
+		out, used := info.dealWithAmm(amounts[i], hop.isBuy)
+		amounts[i] = used
+		fee := calDealWithPoolFee(out, feeRate)
+		if err := pk.SendCoinsFromAccountToModule(ctx, sender, types.PoolModuleAcc, newCoins(hop.tokenIn, used)); err != nil {
+			return nil, err
+		}
+		if err := pk.SendCoinsFromModuleToAccount(ctx, types.PoolModuleAcc, sender, newCoins(hop.tokenOut, out)); err != nil {
+			return nil, err
+		}
+		feeToPool, _, _, err := pk.AllocateFeeToValidatorAndPool(ctx, hop.tokenOut, fee, sender)
+		if err != nil {
+			return nil, err
+		}
+		if hop.isBuy {
+			err = pk.addFeeToPool(ctx, info, feeToPool, sdk.ZeroInt())
+		} else {
+			err = pk.addFeeToPool(ctx, info, sdk.ZeroInt(), feeToPool)
+		}
+		if err != nil {
+			return nil, err
+		}
+		pk.SetPoolInfo(ctx, hop.symbol, info)
+		amounts[i+1] = out.Sub(fee)
+	}
+	if !to.Empty() && !to.Equals(sender) {
+		last := hops[len(hops)-1].tokenOut
+		if err := pk.SendCoins(ctx, sender, to, newCoins(last, amounts[len(hops)])); err != nil {
+			return nil, err
+		}
+	}
+	return amounts, nil
+}
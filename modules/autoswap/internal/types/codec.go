@@ -17,7 +17,16 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgAutoSwapCreateTradingPair{}, "market/MsgAutoSwapCreateTradingPair", nil)
 	cdc.RegisterConcrete(MsgAddLiquidity{}, "market/MsgAddLiquidity", nil)
 	cdc.RegisterConcrete(MsgRemoveLiquidity{}, "market/MsgRemoveLiquidity", nil)
+	cdc.RegisterConcrete(MsgTransferLiquidity{}, "market/MsgTransferLiquidity", nil)
+	cdc.RegisterConcrete(MsgAddRangeLiquidity{}, "market/MsgAddRangeLiquidity", nil)
+	cdc.RegisterConcrete(MsgRemoveRangeLiquidity{}, "market/MsgRemoveRangeLiquidity", nil)
+	cdc.RegisterConcrete(MsgFundRewardProgram{}, "market/MsgFundRewardProgram", nil)
+	cdc.RegisterConcrete(MsgClaimRewards{}, "market/MsgClaimRewards", nil)
+	cdc.RegisterConcrete(MsgFlashBorrow{}, "market/MsgFlashBorrow", nil)
+	cdc.RegisterConcrete(MsgFlashRepay{}, "market/MsgFlashRepay", nil)
 	cdc.RegisterConcrete(MsgAutoSwapCreateOrder{}, "market/MsgAutoSwapCreateOrder", nil)
 	cdc.RegisterConcrete(MsgAutoSwapCancelOrder{}, "market/MsgAutoSwapCancelOrder", nil)
+	cdc.RegisterConcrete(MsgSwapExactIn{}, "market/MsgSwapExactIn", nil)
+	cdc.RegisterConcrete(MsgSwapExactOut{}, "market/MsgSwapExactOut", nil)
 	market.RegisterCodec(cdc)
 }
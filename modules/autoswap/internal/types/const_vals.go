@@ -13,3 +13,16 @@ const (
 	BID = 1
 	ASK = 2
 )
+
+// the curves of the AMM in a pool
+const (
+	// x*y=k
+	CurveConstantProduct = 0
+	// the StableSwap invariant for pegged assets, whose depth around the price of 1 grows with the amplification
+	CurveStableSwap = 1
+
+	MaxAmplification = 1000000
+
+	// a range position holds either no liquidity or at least this much
+	MinRangeLiquidity = 1000
+)
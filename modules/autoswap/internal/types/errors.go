@@ -32,6 +32,21 @@ const (
 	CodeInvalidPricePrecision  = 1220
 	CodeOrderAlreadyExist      = 1221
 	CodeInvalidOrderSide       = 1222
+	CodeDelistRequestExist     = 1223
+	CodeNotStockOwner          = 1224
+	CodeSwapExpired            = 1225
+	CodeAmountInIsLarge        = 1226
+	CodeStockInIsSmall         = 1227
+	CodeMoneyInIsSmall         = 1228
+	CodeStockOutIsSmall        = 1229
+	CodeMoneyOutIsSmall        = 1230
+	CodeInvalidPriceRange      = 1231
+	CodeInvalidPoolType        = 1232
+	CodeInvalidCurve           = 1233
+	CodeInvalidRewardProgram   = 1234
+	CodeRewardProgramNotExist  = 1235
+	CodeInvalidFlashLoan       = 1236
+	CodeLiquidityExpired       = 1237
 )
 
 func ErrInvalidPrice(price int64) sdk.Error {
@@ -121,3 +136,79 @@ func ErrOrderAlreadyExist(orderID string) sdk.Error {
 func ErrInvalidOrderSide(side byte) sdk.Error {
 	return sdk.NewError(CodeSpaceAutoSwap, CodeInvalidOrderSide, fmt.Sprintf("invalid order side: %d, expected BUY: %d, SELL: %d", side, BID, ASK))
 }
+
+func ErrDelistRequestExist(symbol string) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeDelistRequestExist, fmt.Sprintf("the delist request of %s already exist", symbol))
+}
+
+func ErrNotStockOwner(sender sdk.AccAddress) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeNotStockOwner, fmt.Sprintf("only the stock's owner can cancel a trading pair, got: %s", sender))
+}
+
+func ErrInvalidSwapPath(reason string) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeInvalidSwap, fmt.Sprintf("invalid swap path, reason: %s", reason))
+}
+
+func ErrSwapExpired(deadline, height int64) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeSwapExpired, fmt.Sprintf("the swap expired at height %d, current height: %d", deadline, height))
+}
+
+func ErrLiquidityExpired(deadline, height int64) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeLiquidityExpired, fmt.Sprintf("the liquidity change expired at height %d, current height: %d", deadline, height))
+}
+
+func ErrAmountInIsLargerThanExpected(expected, actual sdk.Int) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeAmountInIsLarge, fmt.Sprintf("amount in is larger than "+
+		"expected; actual:%s, expected: %s", actual.String(), expected.String()))
+}
+
+func ErrStockInIsSmallerThanExpected(expected, actual sdk.Int) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeStockInIsSmall, fmt.Sprintf("stock added to the pool is smaller than "+
+		"expected; actual:%s, expected: %s", actual.String(), expected.String()))
+}
+
+func ErrMoneyInIsSmallerThanExpected(expected, actual sdk.Int) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeMoneyInIsSmall, fmt.Sprintf("money added to the pool is smaller than "+
+		"expected; actual:%s, expected: %s", actual.String(), expected.String()))
+}
+
+func ErrStockOutIsSmallerThanExpected(expected, actual sdk.Int) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeStockOutIsSmall, fmt.Sprintf("stock removed from the pool is smaller than "+
+		"expected; actual:%s, expected: %s", actual.String(), expected.String()))
+}
+
+func ErrMoneyOutIsSmallerThanExpected(expected, actual sdk.Int) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeMoneyOutIsSmall, fmt.Sprintf("money removed from the pool is smaller than "+
+		"expected; actual:%s, expected: %s", actual.String(), expected.String()))
+}
+
+func ErrInvalidPriceRange(lowerPrice, upperPrice sdk.Dec) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeInvalidPriceRange, fmt.Sprintf("invalid price range: [%s, %s)", lowerPrice, upperPrice))
+}
+
+func ErrInvalidInitPrice(price sdk.Dec) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeInvalidPrice, fmt.Sprintf("invalid initial price of the pool: %s", price))
+}
+
+func ErrInvalidPoolType(symbol string, rangeLiquidity bool) sdk.Error {
+	if rangeLiquidity {
+		return sdk.NewError(CodeSpaceAutoSwap, CodeInvalidPoolType, fmt.Sprintf("pool %s only accepts liquidity within price ranges", symbol))
+	}
+	return sdk.NewError(CodeSpaceAutoSwap, CodeInvalidPoolType, fmt.Sprintf("pool %s doesn't accept liquidity within price ranges", symbol))
+}
+
+func ErrInvalidCurve(curveType byte, amplification int64) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeInvalidCurve, fmt.Sprintf("invalid curve type: %d, amplification: %d", curveType, amplification))
+}
+
+func ErrInvalidRewardProgram(msg string) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeInvalidRewardProgram, fmt.Sprintf("invalid reward program: %s", msg))
+}
+
+func ErrRewardProgramNotExist(symbol string) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeRewardProgramNotExist, fmt.Sprintf("pool %s has no reward program", symbol))
+}
+
+func ErrInvalidFlashLoan(msg string) sdk.Error {
+	return sdk.NewError(CodeSpaceAutoSwap, CodeInvalidFlashLoan, fmt.Sprintf("invalid flash loan: %s", msg))
+}
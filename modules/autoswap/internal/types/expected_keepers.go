@@ -21,6 +21,7 @@ type ExpectedAccountKeeper interface {
 type SupplyKeeper interface {
 	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) sdk.Error
 	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) sdk.Error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) sdk.Error
 	GetModuleAddress(moduleName string) sdk.AccAddress
 }
 
@@ -33,3 +34,8 @@ type ExpectedAuthXKeeper interface {
 type ExpectedAssetKeeper interface {
 	GetToken(ctx sdk.Context, symbol string) asset.Token
 }
+
+type ExpectedOracleKeeper interface {
+	RecordPrice(ctx sdk.Context, symbol string, price sdk.Dec)
+	GetTWAP(ctx sdk.Context, symbol string, window int64) (sdk.Dec, sdk.Error)
+}
@@ -21,4 +21,15 @@ const (
 
 	// Pool's module account
 	PoolModuleAcc = "autoswap-pool"
+
+	// Query the amounts of a swap path without executing it
+	QuerySwapQuote = "swap-quote"
+	// Query the liquidity positions of an owner
+	QueryLiquidity = "liquidity"
+	// Query the AMM and order book reserves of a pool
+	QueryPoolReserves = "pool-reserves"
+	// Query the range positions of an owner
+	QueryRangePositions = "range-positions"
+	// Query the reward programs of the pools, and the rewards an owner can claim
+	QueryRewards = "rewards"
 )
@@ -15,12 +15,28 @@ var _ sdk.Msg = MsgAutoSwapCancelOrder{}
 var _ sdk.Msg = MsgAddLiquidity{}
 var _ sdk.Msg = MsgRemoveLiquidity{}
 var _ sdk.Msg = MsgCancelTradingPair{}
+var _ sdk.Msg = MsgSwapExactIn{}
+var _ sdk.Msg = MsgSwapExactOut{}
+var _ sdk.Msg = MsgTransferLiquidity{}
+var _ sdk.Msg = MsgAddRangeLiquidity{}
+var _ sdk.Msg = MsgRemoveRangeLiquidity{}
+var _ sdk.Msg = MsgFundRewardProgram{}
+var _ sdk.Msg = MsgClaimRewards{}
+var _ sdk.Msg = MsgFlashBorrow{}
+var _ sdk.Msg = MsgFlashRepay{}
 
 type MsgAutoSwapCreateTradingPair struct {
 	Stock          string         `json:"stock"`
 	Money          string         `json:"money"`
 	Creator        sdk.AccAddress `json:"creator"`
 	PricePrecision byte           `json:"price_precision"`
+
+	// the liquidity of a pool with RangeLiquidity is provided within price ranges, and its price starts at InitPrice
+	RangeLiquidity bool    `json:"range_liquidity"`
+	InitPrice      sdk.Dec `json:"init_price"`
+	// the curve of the AMM, a StableSwap curve has an amplification in [1, MaxAmplification]
+	CurveType     byte  `json:"curve_type"`
+	Amplification int64 `json:"amplification"`
 }
 
 func (m MsgAutoSwapCreateTradingPair) Route() string {
@@ -38,7 +54,10 @@ func (m MsgAutoSwapCreateTradingPair) ValidateBasic() sdk.Error {
 	if len(m.Stock) == 0 || len(m.Money) == 0 {
 		return ErrInvalidToken("token is empty")
 	}
-	return nil
+	if m.RangeLiquidity && (m.InitPrice.IsNil() || !m.InitPrice.IsPositive()) {
+		return ErrInvalidInitPrice(m.InitPrice)
+	}
+	return ValidateCurve(m.CurveType, m.Amplification, m.RangeLiquidity)
 }
 
 func (m MsgAutoSwapCreateTradingPair) GetSignBytes() []byte {
@@ -49,6 +68,10 @@ func (m MsgAutoSwapCreateTradingPair) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{m.Creator}
 }
 
+func (m *MsgAutoSwapCreateTradingPair) SetAccAddress(address sdk.AccAddress) {
+	m.Creator = address
+}
+
 type MsgCancelTradingPair struct {
 	Sender        sdk.AccAddress `json:"sender"`
 	TradingPair   string         `json:"trading_pair"`
@@ -183,6 +206,12 @@ type MsgAddLiquidity struct {
 	StockIn sdk.Int        `json:"stock_in"`
 	MoneyIn sdk.Int        `json:"money_in"`
 	To      sdk.AccAddress `json:"to"`
+
+	// the minimum amounts actually put into the pool, no limit if not set
+	MinStockIn sdk.Int `json:"min_stock_in"`
+	MinMoneyIn sdk.Int `json:"min_money_in"`
+	// the last height this msg can be executed at, no limit if zero
+	Deadline int64 `json:"deadline"`
 }
 
 func (m MsgAddLiquidity) Route() string {
@@ -206,6 +235,15 @@ func (m MsgAddLiquidity) ValidateBasic() sdk.Error {
 	if !m.MoneyIn.IsPositive() {
 		return ErrInvalidAmount(m.MoneyIn)
 	}
+	if isNegative(m.MinStockIn) {
+		return ErrInvalidAmount(m.MinStockIn)
+	}
+	if isNegative(m.MinMoneyIn) {
+		return ErrInvalidAmount(m.MinMoneyIn)
+	}
+	if m.Deadline < 0 {
+		return ErrLiquidityExpired(m.Deadline, 0)
+	}
 	//if To is nil, Sender => To
 	return nil
 }
@@ -228,6 +266,12 @@ type MsgRemoveLiquidity struct {
 	Money  string         `json:"money"`
 	Amount sdk.Int        `json:"amount"`
 	To     sdk.AccAddress `json:"to"`
+
+	// the minimum amounts taken out of the pool, no limit if not set
+	MinStockOut sdk.Int `json:"min_stock_out"`
+	MinMoneyOut sdk.Int `json:"min_money_out"`
+	// the last height this msg can be executed at, no limit if zero
+	Deadline int64 `json:"deadline"`
 }
 
 func (m MsgRemoveLiquidity) Route() string {
@@ -248,6 +292,15 @@ func (m MsgRemoveLiquidity) ValidateBasic() sdk.Error {
 	if !m.Amount.IsPositive() {
 		return ErrInvalidAmount(m.Amount)
 	}
+	if isNegative(m.MinStockOut) {
+		return ErrInvalidAmount(m.MinStockOut)
+	}
+	if isNegative(m.MinMoneyOut) {
+		return ErrInvalidAmount(m.MinMoneyOut)
+	}
+	if m.Deadline < 0 {
+		return ErrLiquidityExpired(m.Deadline, 0)
+	}
 	//if To is nil, sender => To
 	return nil
 }
@@ -263,3 +316,491 @@ func (m MsgRemoveLiquidity) GetSigners() []sdk.AccAddress {
 func (m *MsgRemoveLiquidity) SetAccAddress(address sdk.AccAddress) {
 	m.Sender = address
 }
+
+type MsgTransferLiquidity struct {
+	Sender sdk.AccAddress `json:"sender"`
+	Stock  string         `json:"stock"`
+	Money  string         `json:"money"`
+	Amount sdk.Int        `json:"amount"`
+	To     sdk.AccAddress `json:"to"`
+}
+
+func (m MsgTransferLiquidity) Route() string {
+	return market.ModuleName
+}
+
+func (m MsgTransferLiquidity) Type() string {
+	return "transfer_liquidity"
+}
+
+func (m MsgTransferLiquidity) ValidateBasic() sdk.Error {
+	if m.Sender.Empty() {
+		return sdk.ErrInvalidAddress("missing sender address")
+	}
+	if m.To.Empty() {
+		return sdk.ErrInvalidAddress("missing receiver address")
+	}
+	if len(m.Stock) == 0 || len(m.Money) == 0 {
+		return ErrInvalidToken("token is empty")
+	}
+	if m.Amount == (sdk.Int{}) || !m.Amount.IsPositive() {
+		return ErrInvalidAmount(m.Amount)
+	}
+	return nil
+}
+
+func (m MsgTransferLiquidity) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+func (m MsgTransferLiquidity) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+
+func (m *MsgTransferLiquidity) SetAccAddress(address sdk.AccAddress) {
+	m.Sender = address
+}
+
+// MsgAddRangeLiquidity provides liquidity within [LowerPrice, UpperPrice) to a pool with range liquidity.
+// At most StockIn and MoneyIn are taken, the amounts needed depend on the current price of the pool.
+type MsgAddRangeLiquidity struct {
+	Sender     sdk.AccAddress `json:"sender"`
+	Stock      string         `json:"stock"`
+	Money      string         `json:"money"`
+	LowerPrice sdk.Dec        `json:"lower_price"`
+	UpperPrice sdk.Dec        `json:"upper_price"`
+	StockIn    sdk.Int        `json:"stock_in"`
+	MoneyIn    sdk.Int        `json:"money_in"`
+}
+
+func (m MsgAddRangeLiquidity) Route() string {
+	return market.ModuleName
+}
+
+func (m MsgAddRangeLiquidity) Type() string {
+	return "add_range_liquidity"
+}
+
+func (m MsgAddRangeLiquidity) ValidateBasic() sdk.Error {
+	if m.Sender.Empty() {
+		return sdk.ErrInvalidAddress("missing sender address")
+	}
+	if len(m.Stock) == 0 || len(m.Money) == 0 {
+		return ErrInvalidToken("token is empty")
+	}
+	if err := ValidatePriceRange(m.LowerPrice, m.UpperPrice); err != nil {
+		return err
+	}
+	if m.StockIn == (sdk.Int{}) || m.StockIn.IsNegative() {
+		return ErrInvalidAmount(m.StockIn)
+	}
+	if m.MoneyIn == (sdk.Int{}) || m.MoneyIn.IsNegative() {
+		return ErrInvalidAmount(m.MoneyIn)
+	}
+	if m.StockIn.IsZero() && m.MoneyIn.IsZero() {
+		return ErrInvalidAmount(m.MoneyIn)
+	}
+	return nil
+}
+
+func (m MsgAddRangeLiquidity) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+func (m MsgAddRangeLiquidity) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+
+func (m *MsgAddRangeLiquidity) SetAccAddress(address sdk.AccAddress) {
+	m.Sender = address
+}
+
+// MsgRemoveRangeLiquidity takes Liquidity out of the sender's position within [LowerPrice, UpperPrice),
+// together with all the fees earned by the position. A Liquidity of zero only takes the fees out.
+type MsgRemoveRangeLiquidity struct {
+	Sender     sdk.AccAddress `json:"sender"`
+	Stock      string         `json:"stock"`
+	Money      string         `json:"money"`
+	LowerPrice sdk.Dec        `json:"lower_price"`
+	UpperPrice sdk.Dec        `json:"upper_price"`
+	Liquidity  sdk.Int        `json:"liquidity"`
+	To         sdk.AccAddress `json:"to"`
+}
+
+func (m MsgRemoveRangeLiquidity) Route() string {
+	return market.ModuleName
+}
+
+func (m MsgRemoveRangeLiquidity) Type() string {
+	return "remove_range_liquidity"
+}
+
+func (m MsgRemoveRangeLiquidity) ValidateBasic() sdk.Error {
+	if m.Sender.Empty() {
+		return sdk.ErrInvalidAddress("missing sender address")
+	}
+	if len(m.Stock) == 0 || len(m.Money) == 0 {
+		return ErrInvalidToken("token is empty")
+	}
+	if err := ValidatePriceRange(m.LowerPrice, m.UpperPrice); err != nil {
+		return err
+	}
+	if m.Liquidity == (sdk.Int{}) || m.Liquidity.IsNegative() {
+		return ErrInvalidAmount(m.Liquidity)
+	}
+	//if To is nil, sender => To
+	return nil
+}
+
+func (m MsgRemoveRangeLiquidity) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+func (m MsgRemoveRangeLiquidity) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+
+func (m *MsgRemoveRangeLiquidity) SetAccAddress(address sdk.AccAddress) {
+	m.Sender = address
+}
+
+// MsgFundRewardProgram funds the reward program of a pool, which pays RewardPerBlock of Denom to the
+// liquidity providers of the pool pro rata till the funded amount runs out. Anyone can fund a program, and
+// what remains is refunded to the funders pro rata when the pool is delisted.
+// RewardPerBlock is set when the program is created or has run out, and must be zero or unchanged otherwise.
+type MsgFundRewardProgram struct {
+	Sender         sdk.AccAddress `json:"sender"`
+	Stock          string         `json:"stock"`
+	Money          string         `json:"money"`
+	Denom          string         `json:"denom"`
+	Amount         sdk.Int        `json:"amount"`
+	RewardPerBlock sdk.Int        `json:"reward_per_block"`
+}
+
+func (m MsgFundRewardProgram) Route() string {
+	return market.ModuleName
+}
+
+func (m MsgFundRewardProgram) Type() string {
+	return "fund_reward_program"
+}
+
+func (m MsgFundRewardProgram) ValidateBasic() sdk.Error {
+	if m.Sender.Empty() {
+		return sdk.ErrInvalidAddress("missing sender address")
+	}
+	if len(m.Stock) == 0 || len(m.Money) == 0 || len(m.Denom) == 0 {
+		return ErrInvalidToken("token is empty")
+	}
+	if m.Amount == (sdk.Int{}) || !m.Amount.IsPositive() {
+		return ErrInvalidAmount(m.Amount)
+	}
+	if m.RewardPerBlock == (sdk.Int{}) || m.RewardPerBlock.IsNegative() {
+		return ErrInvalidAmount(m.RewardPerBlock)
+	}
+	return nil
+}
+
+func (m MsgFundRewardProgram) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+func (m MsgFundRewardProgram) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+
+func (m *MsgFundRewardProgram) SetAccAddress(address sdk.AccAddress) {
+	m.Sender = address
+}
+
+// MsgClaimRewards takes out all the rewards the sender earned from the reward program of a pool
+type MsgClaimRewards struct {
+	Sender sdk.AccAddress `json:"sender"`
+	Stock  string         `json:"stock"`
+	Money  string         `json:"money"`
+	To     sdk.AccAddress `json:"to"`
+}
+
+func (m MsgClaimRewards) Route() string {
+	return market.ModuleName
+}
+
+func (m MsgClaimRewards) Type() string {
+	return "claim_rewards"
+}
+
+func (m MsgClaimRewards) ValidateBasic() sdk.Error {
+	if m.Sender.Empty() {
+		return sdk.ErrInvalidAddress("missing sender address")
+	}
+	if len(m.Stock) == 0 || len(m.Money) == 0 {
+		return ErrInvalidToken("token is empty")
+	}
+	//if To is nil, sender => To
+	return nil
+}
+
+func (m MsgClaimRewards) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+func (m MsgClaimRewards) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+
+func (m *MsgClaimRewards) SetAccAddress(address sdk.AccAddress) {
+	m.Sender = address
+}
+
+// MsgFlashBorrow borrows StockAmount and MoneyAmount from the AMM reserves of a pool. They must be
+// repaid with the fee of dealing with the pool by a MsgFlashRepay later in the same tx, or the tx is rejected.
+type MsgFlashBorrow struct {
+	Sender      sdk.AccAddress `json:"sender"`
+	Stock       string         `json:"stock"`
+	Money       string         `json:"money"`
+	StockAmount sdk.Int        `json:"stock_amount"`
+	MoneyAmount sdk.Int        `json:"money_amount"`
+}
+
+func (m MsgFlashBorrow) Route() string {
+	return market.ModuleName
+}
+
+func (m MsgFlashBorrow) Type() string {
+	return "flash_borrow"
+}
+
+func (m MsgFlashBorrow) ValidateBasic() sdk.Error {
+	if m.Sender.Empty() {
+		return sdk.ErrInvalidAddress("missing sender address")
+	}
+	if len(m.Stock) == 0 || len(m.Money) == 0 {
+		return ErrInvalidToken("token is empty")
+	}
+	if m.StockAmount == (sdk.Int{}) || m.StockAmount.IsNegative() {
+		return ErrInvalidAmount(m.StockAmount)
+	}
+	if m.MoneyAmount == (sdk.Int{}) || m.MoneyAmount.IsNegative() {
+		return ErrInvalidAmount(m.MoneyAmount)
+	}
+	if m.StockAmount.IsZero() && m.MoneyAmount.IsZero() {
+		return ErrInvalidAmount(m.StockAmount)
+	}
+	return nil
+}
+
+func (m MsgFlashBorrow) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+func (m MsgFlashBorrow) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+
+func (m *MsgFlashBorrow) SetAccAddress(address sdk.AccAddress) {
+	m.Sender = address
+}
+
+// IsClosedBy returns whether msg repays this loan, see authx.MsgToBeClosed
+func (m MsgFlashBorrow) IsClosedBy(msg sdk.Msg) bool {
+	repay, ok := msg.(MsgFlashRepay)
+	return ok && repay.Sender.Equals(m.Sender) && repay.Stock == m.Stock && repay.Money == m.Money
+}
+
+// MsgFlashRepay repays all the amounts the sender borrowed from a pool in this tx, together with the fees
+type MsgFlashRepay struct {
+	Sender sdk.AccAddress `json:"sender"`
+	Stock  string         `json:"stock"`
+	Money  string         `json:"money"`
+}
+
+func (m MsgFlashRepay) Route() string {
+	return market.ModuleName
+}
+
+func (m MsgFlashRepay) Type() string {
+	return "flash_repay"
+}
+
+func (m MsgFlashRepay) ValidateBasic() sdk.Error {
+	if m.Sender.Empty() {
+		return sdk.ErrInvalidAddress("missing sender address")
+	}
+	if len(m.Stock) == 0 || len(m.Money) == 0 {
+		return ErrInvalidToken("token is empty")
+	}
+	return nil
+}
+
+func (m MsgFlashRepay) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+func (m MsgFlashRepay) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+
+func (m *MsgFlashRepay) SetAccAddress(address sdk.AccAddress) {
+	m.Sender = address
+}
+
+// MsgSwapExactIn swaps an exact amount of path[0] for as much path[len(path)-1] as possible,
+// through the pools of every two adjacent tokens in path
+type MsgSwapExactIn struct {
+	Sender       sdk.AccAddress `json:"sender"`
+	Path         []string       `json:"path"`
+	AmountIn     sdk.Int        `json:"amount_in"`
+	MinAmountOut sdk.Int        `json:"min_amount_out"`
+	Deadline     int64          `json:"deadline"`
+	To           sdk.AccAddress `json:"to"`
+}
+
+func (m MsgSwapExactIn) Route() string {
+	return market.ModuleName
+}
+
+func (m MsgSwapExactIn) Type() string {
+	return "swap_exact_in"
+}
+
+func (m MsgSwapExactIn) ValidateBasic() sdk.Error {
+	if m.Sender.Empty() {
+		return sdk.ErrInvalidAddress("missing sender address")
+	}
+	if err := ValidateSwapPath(m.Path); err != nil {
+		return err
+	}
+	if m.AmountIn == (sdk.Int{}) || !m.AmountIn.IsPositive() {
+		return ErrInvalidAmount(m.AmountIn)
+	}
+	if m.MinAmountOut == (sdk.Int{}) || m.MinAmountOut.IsNegative() {
+		return ErrInvalidAmount(m.MinAmountOut)
+	}
+	if m.Deadline <= 0 {
+		return ErrSwapExpired(m.Deadline, 0)
+	}
+	//if To is nil, Sender => To
+	return nil
+}
+
+func (m MsgSwapExactIn) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+func (m MsgSwapExactIn) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+
+func (m *MsgSwapExactIn) SetAccAddress(address sdk.AccAddress) {
+	m.Sender = address
+}
+
+// MsgSwapExactOut swaps as little path[0] as possible for an exact amount of path[len(path)-1],
+// through the pools of every two adjacent tokens in path
+type MsgSwapExactOut struct {
+	Sender      sdk.AccAddress `json:"sender"`
+	Path        []string       `json:"path"`
+	AmountOut   sdk.Int        `json:"amount_out"`
+	MaxAmountIn sdk.Int        `json:"max_amount_in"`
+	Deadline    int64          `json:"deadline"`
+	To          sdk.AccAddress `json:"to"`
+}
+
+func (m MsgSwapExactOut) Route() string {
+	return market.ModuleName
+}
+
+func (m MsgSwapExactOut) Type() string {
+	return "swap_exact_out"
+}
+
+func (m MsgSwapExactOut) ValidateBasic() sdk.Error {
+	if m.Sender.Empty() {
+		return sdk.ErrInvalidAddress("missing sender address")
+	}
+	if err := ValidateSwapPath(m.Path); err != nil {
+		return err
+	}
+	if m.AmountOut == (sdk.Int{}) || !m.AmountOut.IsPositive() {
+		return ErrInvalidAmount(m.AmountOut)
+	}
+	if m.MaxAmountIn == (sdk.Int{}) || !m.MaxAmountIn.IsPositive() {
+		return ErrInvalidAmount(m.MaxAmountIn)
+	}
+	if m.Deadline <= 0 {
+		return ErrSwapExpired(m.Deadline, 0)
+	}
+	//if To is nil, Sender => To
+	return nil
+}
+
+func (m MsgSwapExactOut) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(m))
+}
+
+func (m MsgSwapExactOut) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{m.Sender}
+}
+
+func (m *MsgSwapExactOut) SetAccAddress(address sdk.AccAddress) {
+	m.Sender = address
+}
+
+// an amount which is not set is taken as zero
+func isNegative(amount sdk.Int) bool {
+	return amount != (sdk.Int{}) && amount.IsNegative()
+}
+
+// ValidateSwapPath checks that path has at least two tokens and no token appears twice,
+// so that no pool is traded more than once in a swap
+func ValidateSwapPath(path []string) sdk.Error {
+	if len(path) < 2 {
+		return ErrInvalidSwapPath("at least two tokens are needed")
+	}
+	tokens := make(map[string]struct{}, len(path))
+	for _, token := range path {
+		if len(token) == 0 {
+			return ErrInvalidSwapPath("token is empty")
+		}
+		if _, ok := tokens[token]; ok {
+			return ErrInvalidSwapPath("duplicate token " + token)
+		}
+		tokens[token] = struct{}{}
+	}
+	return nil
+}
+
+// ValidatePriceRange checks that the range [lowerPrice, upperPrice) is positive and not empty
+func ValidatePriceRange(lowerPrice, upperPrice sdk.Dec) sdk.Error {
+	if lowerPrice.IsNil() || upperPrice.IsNil() || !lowerPrice.IsPositive() || !lowerPrice.LT(upperPrice) {
+		return ErrInvalidPriceRange(lowerPrice, upperPrice)
+	}
+	return nil
+}
+
+// ValidateRangeTicks checks that the bounds of a price range are on the ticks of a pool,
+// which are the multiples of 10^-pricePrecision
+func ValidateRangeTicks(lowerPrice, upperPrice sdk.Dec, pricePrecision byte) sdk.Error {
+	ticksPerUnit := sdk.NewIntWithDecimal(1, int(pricePrecision))
+	if !lowerPrice.MulInt(ticksPerUnit).IsInteger() || !upperPrice.MulInt(ticksPerUnit).IsInteger() {
+		return ErrInvalidPriceRange(lowerPrice, upperPrice)
+	}
+	return nil
+}
+
+// ValidateCurve checks the curve of a pool, range liquidity is only provided on the constant product curve
+func ValidateCurve(curveType byte, amplification int64, rangeLiquidity bool) sdk.Error {
+	switch curveType {
+	case CurveConstantProduct:
+		if amplification != 0 {
+			return ErrInvalidCurve(curveType, amplification)
+		}
+	case CurveStableSwap:
+		if rangeLiquidity || amplification <= 0 || amplification > MaxAmplification {
+			return ErrInvalidCurve(curveType, amplification)
+		}
+	default:
+		return ErrInvalidCurve(curveType, amplification)
+	}
+	return nil
+}
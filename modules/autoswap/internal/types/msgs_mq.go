@@ -17,6 +17,7 @@ const (
 const (
 	CancelOrderByManual    = "Manually cancel the order"
 	CancelOrderByAllFilled = "The order was fully filled"
+	CancelOrderByDelist    = "The market was delisted"
 )
 
 type CreateOrderInfoMq struct {
@@ -3,7 +3,9 @@ package types
 import (
 	"bytes"
 	"fmt"
+	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
@@ -14,6 +16,7 @@ const (
 	DefaultDealWithPoolFeeRate = 50
 	DefaultFeeToPool           = 6
 	DefaultFeeToValidator      = 4
+	DefaultPairMinExpiredTime  = 7 * 24 * time.Hour
 )
 
 var (
@@ -22,6 +25,9 @@ var (
 	keyDealWithPoolFeeRate = []byte("DealWithPoolFeeRate")
 	keyFeeToPool           = []byte("FeeToPool")
 	keyFeeToValidator      = []byte("FeeToValidator")
+	keyPairMinExpiredTime  = []byte("PairMinExpiredTime")
+	KeyFeeOn               = []byte("FeeOn")
+	KeyFeeReceiver         = []byte("FeeReceiver")
 )
 
 type Params struct {
@@ -30,6 +36,11 @@ type Params struct {
 	DealWithPoolFeeRate int64 `json:"deal_with_pool_fee_rate"`
 	FeeToPool           int64 `json:"fee_to_pool"`
 	FeeToValidator      int64 `json:"fee_to_validator"`
+	// The minimum delay between a cancel trading pair request and its effective time, in nanoseconds
+	PairMinExpiredTime int64 `json:"pair_min_expired_time"`
+	// When FeeOn is true, 1/6 of the growth of sqrt(k) of the pools is minted to FeeReceiver as liquidity
+	FeeOn       bool           `json:"fee_on"`
+	FeeReceiver sdk.AccAddress `json:"fee_receiver"`
 }
 
 func ParamKeyTable() params.KeyTable {
@@ -43,6 +54,7 @@ func DefaultParams() Params {
 		DealWithPoolFeeRate: DefaultDealWithPoolFeeRate,
 		FeeToPool:           DefaultFeeToPool,
 		FeeToValidator:      DefaultFeeToValidator,
+		PairMinExpiredTime:  int64(DefaultPairMinExpiredTime),
 	}
 }
 
@@ -53,6 +65,9 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		{Key: keyDealWithPoolFeeRate, Value: &p.DealWithPoolFeeRate},
 		{Key: keyFeeToPool, Value: &p.FeeToPool},
 		{Key: keyFeeToValidator, Value: &p.FeeToValidator},
+		{Key: keyPairMinExpiredTime, Value: &p.PairMinExpiredTime},
+		{Key: KeyFeeOn, Value: &p.FeeOn},
+		{Key: KeyFeeReceiver, Value: &p.FeeReceiver},
 	}
 }
 
@@ -65,6 +80,12 @@ func (p *Params) ValidateGenesis() error {
 		return fmt.Errorf("FeeRate should be less than 1. TakerFeeRate: %d, MakerFeeRate: %d, DealWithPoolFeeRate: %d",
 			p.TakerFeeRate, p.MakerFeeRate, p.DealWithPoolFeeRate)
 	}
+	if p.PairMinExpiredTime < 0 {
+		return fmt.Errorf("PairMinExpiredTime can not be a negative number: %d", p.PairMinExpiredTime)
+	}
+	if p.FeeOn && p.FeeReceiver.Empty() {
+		return fmt.Errorf("FeeReceiver can not be empty when FeeOn is true")
+	}
 	return nil
 }
 
@@ -80,10 +101,16 @@ func (p Params) String() string {
 	MakerFeeRate: %d,
 	DealWithPoolFeeRate: %d,
 	FeeToPool: %d,
-	FeeToValidator: %d`,
+	FeeToValidator: %d,
+	PairMinExpiredTime: %d,
+	FeeOn: %t,
+	FeeReceiver: %s`,
 		p.TakerFeeRate,
 		p.MakerFeeRate,
 		p.DealWithPoolFeeRate,
 		p.FeeToPool,
-		p.FeeToValidator)
+		p.FeeToValidator,
+		p.PairMinExpiredTime,
+		p.FeeOn,
+		p.FeeReceiver)
 }
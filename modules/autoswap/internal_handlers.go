@@ -2,6 +2,8 @@ package autoswap
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/coinexchain/cet-sdk/msgqueue"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -24,10 +26,28 @@ func NewInternalHandler(k *keepers.Keeper) sdk.Handler {
 			return handleMsgAddLiquidity(ctx, k, msg)
 		case types.MsgRemoveLiquidity:
 			return handleMsgRemoveLiquidity(ctx, k, msg)
+		case types.MsgTransferLiquidity:
+			return handleMsgTransferLiquidity(ctx, k, msg)
+		case types.MsgAddRangeLiquidity:
+			return handleMsgAddRangeLiquidity(ctx, k, msg)
+		case types.MsgRemoveRangeLiquidity:
+			return handleMsgRemoveRangeLiquidity(ctx, k, msg)
+		case types.MsgFundRewardProgram:
+			return handleMsgFundRewardProgram(ctx, k, msg)
+		case types.MsgClaimRewards:
+			return handleMsgClaimRewards(ctx, k, msg)
+		case types.MsgFlashBorrow:
+			return handleMsgFlashBorrow(ctx, k, msg)
+		case types.MsgFlashRepay:
+			return handleMsgFlashRepay(ctx, k, msg)
 		case types.MsgAutoSwapCreateOrder:
 			return handleMsgCreateOrder(ctx, k, msg)
 		case types.MsgAutoSwapCancelOrder:
 			return handleMsgCancelOrder(ctx, k, msg)
+		case types.MsgSwapExactIn:
+			return handleMsgSwapExactIn(ctx, k, msg)
+		case types.MsgSwapExactOut:
+			return handleMsgSwapExactOut(ctx, k, msg)
 		default:
 			return dex.ErrUnknownRequest(types.ModuleName, msg)
 		}
@@ -46,28 +66,85 @@ func handleMsgCreateTradingPair(ctx sdk.Context, k *keepers.Keeper, msg types.Ms
 	if info != nil {
 		return types.ErrPairAlreadyExist().Result()
 	}
-	k.CreatePair(ctx, msg.Creator, marKey, msg.PricePrecision)
+	switch {
+	case msg.RangeLiquidity:
+		k.CreateRangePair(ctx, msg.Creator, marKey, msg.PricePrecision, msg.InitPrice)
+	case msg.CurveType == types.CurveStableSwap:
+		k.CreateStablePair(ctx, msg.Creator, marKey, msg.PricePrecision, msg.Amplification)
+	default:
+		k.CreatePair(ctx, msg.Creator, marKey, msg.PricePrecision)
+	}
 	return sdk.Result{
 		Events: ctx.EventManager().Events(),
 	}
 }
 
 func handleMsgCancelTradingPair(ctx sdk.Context, k *keepers.Keeper, msg types.MsgCancelTradingPair) sdk.Result {
-	return sdk.Result{}
+	if err := checkMsgCancelTradingPair(ctx, k, msg); err != nil {
+		return err.Result()
+	}
+	if k.HasDelistRequest(ctx, msg.TradingPair) {
+		return types.ErrDelistRequestExist(msg.TradingPair).Result()
+	}
+	k.AddDelistRequest(ctx, msg.EffectiveTime, msg.TradingPair)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyCancelPair,
+			sdk.NewAttribute(AttributeSymbol, msg.TradingPair),
+			sdk.NewAttribute(AttributeEffectiveTime, strconv.FormatInt(msg.EffectiveTime, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func checkMsgCancelTradingPair(ctx sdk.Context, k *keepers.Keeper, msg types.MsgCancelTradingPair) sdk.Error {
+	currTime := ctx.BlockHeader().Time.UnixNano()
+	if msg.EffectiveTime < currTime+k.GetParams(ctx).PairMinExpiredTime {
+		return types.ErrInvalidEffectiveTime()
+	}
+	if k.GetPoolInfo(ctx, msg.TradingPair) == nil {
+		return types.ErrPairIsNotExist()
+	}
+	stock, _ := dex.SplitSymbol(msg.TradingPair)
+	token := k.ExpectedAssetKeeper.GetToken(ctx, stock)
+	if token == nil || !msg.Sender.Equals(token.GetOwner()) {
+		return types.ErrNotStockOwner(msg.Sender)
+	}
+	return nil
 }
 
 func handleMsgAddLiquidity(ctx sdk.Context, k *keepers.Keeper, msg types.MsgAddLiquidity) sdk.Result {
+	if isExpired(ctx, msg.Deadline) {
+		return types.ErrLiquidityExpired(msg.Deadline, ctx.BlockHeight()).Result()
+	}
 	marKey := dex.GetSymbol(msg.Stock, msg.Money)
 	info := k.GetPoolInfo(ctx, marKey)
 	if info == nil {
 		return types.ErrPairIsNotExist().Result()
 	}
+	if info.RangeLiquidity {
+		return types.ErrInvalidPoolType(marKey, true).Result()
+	}
 	var liquidity sdk.Int
 	to := msg.To
 	if to.Empty() {
 		to = msg.Sender
 	}
 	stockR, moneyR := info.GetLiquidityAmountIn(msg.StockIn, msg.MoneyIn)
+	if isLessThan(stockR, msg.MinStockIn) {
+		return types.ErrStockInIsSmallerThanExpected(msg.MinStockIn, stockR).Result()
+	}
+	if isLessThan(moneyR, msg.MinMoneyIn) {
+		return types.ErrMoneyInIsSmallerThanExpected(msg.MinMoneyIn, moneyR).Result()
+	}
 	err := k.SendCoinsFromUserToPool(ctx, msg.Sender, sdk.NewCoins(sdk.NewCoin(msg.Stock, stockR), sdk.NewCoin(msg.Money, moneyR)))
 	if err != nil {
 		return err.Result()
@@ -96,12 +173,24 @@ func handleMsgAddLiquidity(ctx sdk.Context, k *keepers.Keeper, msg types.MsgAddL
 }
 
 func handleMsgRemoveLiquidity(ctx sdk.Context, k *keepers.Keeper, msg types.MsgRemoveLiquidity) sdk.Result {
+	if isExpired(ctx, msg.Deadline) {
+		return types.ErrLiquidityExpired(msg.Deadline, ctx.BlockHeight()).Result()
+	}
 	marKey := dex.GetSymbol(msg.Stock, msg.Money)
 	//todo: get trading pair, if not exist, return;
-	stockOut, moneyOut, err := k.Burn(ctx, marKey, msg.Sender, msg.Amount)
+	// burn in a cached context, which is dropped if the amounts taken out are too small
+	cacheCtx, write := ctx.CacheContext()
+	stockOut, moneyOut, err := k.Burn(cacheCtx, marKey, msg.Sender, msg.Amount)
 	if err != nil {
 		return err.Result()
 	}
+	if isLessThan(stockOut, msg.MinStockOut) {
+		return types.ErrStockOutIsSmallerThanExpected(msg.MinStockOut, stockOut).Result()
+	}
+	if isLessThan(moneyOut, msg.MinMoneyOut) {
+		return types.ErrMoneyOutIsSmallerThanExpected(msg.MinMoneyOut, moneyOut).Result()
+	}
+	write()
 	to := msg.To
 	if to.Empty() {
 		to = msg.Sender
@@ -128,6 +217,151 @@ func handleMsgRemoveLiquidity(ctx sdk.Context, k *keepers.Keeper, msg types.MsgR
 	}
 }
 
+func handleMsgTransferLiquidity(ctx sdk.Context, k *keepers.Keeper, msg types.MsgTransferLiquidity) sdk.Result {
+	marKey := dex.GetSymbol(msg.Stock, msg.Money)
+	if err := k.TransferLiquidity(ctx, marKey, msg.Sender, msg.To, msg.Amount); err != nil {
+		return err.Result()
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyTransferLiq,
+			sdk.NewAttribute(AttributeSymbol, marKey),
+			sdk.NewAttribute(AttributeRecipient, msg.To.String()),
+			sdk.NewAttribute(AttributeAmount, msg.Amount.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgAddRangeLiquidity(ctx sdk.Context, k *keepers.Keeper, msg types.MsgAddRangeLiquidity) sdk.Result {
+	marKey := dex.GetSymbol(msg.Stock, msg.Money)
+	// add the position in a cached context, which is dropped if the tokens can't be sent to the pool
+	cacheCtx, write := ctx.CacheContext()
+	liquidity, stockIn, moneyIn, err := k.AddRangeLiquidity(cacheCtx, marKey, msg.Sender, msg.LowerPrice, msg.UpperPrice, msg.StockIn, msg.MoneyIn)
+	if err != nil {
+		return err.Result()
+	}
+	if err = k.SendCoinsFromUserToPool(cacheCtx, msg.Sender, sdk.NewCoins(sdk.NewCoin(msg.Stock, stockIn), sdk.NewCoin(msg.Money, moneyIn))); err != nil {
+		return err.Result()
+	}
+	write()
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyAddRangeLiq,
+			sdk.NewAttribute(AttributeSymbol, marKey),
+			sdk.NewAttribute(AttributeLowerPrice, msg.LowerPrice.String()),
+			sdk.NewAttribute(AttributeUpperPrice, msg.UpperPrice.String()),
+			sdk.NewAttribute(AttributeAmount, liquidity.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgRemoveRangeLiquidity(ctx sdk.Context, k *keepers.Keeper, msg types.MsgRemoveRangeLiquidity) sdk.Result {
+	marKey := dex.GetSymbol(msg.Stock, msg.Money)
+	stockOut, moneyOut, err := k.RemoveRangeLiquidity(ctx, marKey, msg.Sender, msg.LowerPrice, msg.UpperPrice, msg.Liquidity)
+	if err != nil {
+		return err.Result()
+	}
+	to := msg.To
+	if to.Empty() {
+		to = msg.Sender
+	}
+	if err = k.SendCoinsFromPoolToUser(ctx, to, sdk.NewCoins(sdk.NewCoin(msg.Stock, stockOut), sdk.NewCoin(msg.Money, moneyOut))); err != nil {
+		return err.Result()
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyRemoveRangeLiq,
+			sdk.NewAttribute(AttributeSymbol, marKey),
+			sdk.NewAttribute(AttributeLowerPrice, msg.LowerPrice.String()),
+			sdk.NewAttribute(AttributeUpperPrice, msg.UpperPrice.String()),
+			sdk.NewAttribute(AttributeAmount, msg.Liquidity.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgFundRewardProgram(ctx sdk.Context, k *keepers.Keeper, msg types.MsgFundRewardProgram) sdk.Result {
+	marKey := dex.GetSymbol(msg.Stock, msg.Money)
+	// fund the program in a cached context, which is dropped if the tokens can't be sent to the pool
+	cacheCtx, write := ctx.CacheContext()
+	if err := k.FundRewardProgram(cacheCtx, marKey, msg.Sender, msg.Denom, msg.Amount, msg.RewardPerBlock); err != nil {
+		return err.Result()
+	}
+	if err := k.SendCoinsFromUserToPool(cacheCtx, msg.Sender, sdk.NewCoins(sdk.NewCoin(msg.Denom, msg.Amount))); err != nil {
+		return err.Result()
+	}
+	write()
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyFundRewards,
+			sdk.NewAttribute(AttributeSymbol, marKey),
+			sdk.NewAttribute(AttributeAmount, sdk.NewCoin(msg.Denom, msg.Amount).String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgClaimRewards(ctx sdk.Context, k *keepers.Keeper, msg types.MsgClaimRewards) sdk.Result {
+	marKey := dex.GetSymbol(msg.Stock, msg.Money)
+	rewards, err := k.ClaimRewards(ctx, marKey, msg.Sender)
+	if err != nil {
+		return err.Result()
+	}
+	to := msg.To
+	if to.Empty() {
+		to = msg.Sender
+	}
+	if err = k.SendCoinsFromPoolToUser(ctx, to, rewards); err != nil {
+		return err.Result()
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyClaimRewards,
+			sdk.NewAttribute(AttributeSymbol, marKey),
+			sdk.NewAttribute(AttributeRecipient, to.String()),
+			sdk.NewAttribute(AttributeAmount, rewards.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
 func handleMsgCreateOrder(ctx sdk.Context, k *keepers.Keeper, msg types.MsgAutoSwapCreateOrder) sdk.Result {
 	if err := k.AddLimitOrder(ctx, msg.GetOrder()); err != nil {
 		return err.Result()
@@ -145,8 +379,129 @@ func handleMsgCancelOrder(ctx sdk.Context, k *keepers.Keeper, msg types.MsgAutoS
 	}
 }
 
+func handleMsgSwapExactIn(ctx sdk.Context, k *keepers.Keeper, msg types.MsgSwapExactIn) sdk.Result {
+	if isExpired(ctx, msg.Deadline) {
+		return types.ErrSwapExpired(msg.Deadline, ctx.BlockHeight()).Result()
+	}
+	to := msg.To
+	if to.Empty() {
+		to = msg.Sender
+	}
+	amounts, err := k.SwapExactIn(ctx, msg.Sender, to, msg.Path, msg.AmountIn, msg.MinAmountOut)
+	if err != nil {
+		return err.Result()
+	}
+	emitSwapEvents(ctx, msg.Sender, msg.Path, amounts)
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgSwapExactOut(ctx sdk.Context, k *keepers.Keeper, msg types.MsgSwapExactOut) sdk.Result {
+	if isExpired(ctx, msg.Deadline) {
+		return types.ErrSwapExpired(msg.Deadline, ctx.BlockHeight()).Result()
+	}
+	to := msg.To
+	if to.Empty() {
+		to = msg.Sender
+	}
+	amounts, err := k.SwapExactOut(ctx, msg.Sender, to, msg.Path, msg.AmountOut, msg.MaxAmountIn)
+	if err != nil {
+		return err.Result()
+	}
+	emitSwapEvents(ctx, msg.Sender, msg.Path, amounts)
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func emitSwapEvents(ctx sdk.Context, sender sdk.AccAddress, path []string, amounts []sdk.Int) {
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeySwap,
+			sdk.NewAttribute(AttributeSwapPath, strings.Join(path, ",")),
+			sdk.NewAttribute(AttributeAmountIn, amounts[0].String()),
+			sdk.NewAttribute(AttributeAmountOut, amounts[len(amounts)-1].String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, sender.String()),
+		),
+	})
+}
+
+// a deadline of zero means no deadline
+func isExpired(ctx sdk.Context, deadline int64) bool {
+	return deadline > 0 && ctx.BlockHeight() > deadline
+}
+
+// a minimum amount which is not set means no limit
+func isLessThan(amount, min sdk.Int) bool {
+	return min != (sdk.Int{}) && amount.LT(min)
+}
+
 func fillMsgQueue(ctx sdk.Context, keeper *Keeper, key string, msg interface{}) {
 	if keeper.IsSubscribed(types.Topic) {
 		msgqueue.FillMsgs(ctx, key, msg)
 	}
 }
+
+func handleMsgFlashBorrow(ctx sdk.Context, k *keepers.Keeper, msg types.MsgFlashBorrow) sdk.Result {
+	marKey := dex.GetSymbol(msg.Stock, msg.Money)
+	// the loan is recorded in a cached context, which is dropped if the tokens can't be lent out
+	cacheCtx, write := ctx.CacheContext()
+	if err := k.FlashBorrow(cacheCtx, marKey, msg.Sender, msg.StockAmount, msg.MoneyAmount); err != nil {
+		return err.Result()
+	}
+	coins := sdk.NewCoins(sdk.NewCoin(msg.Stock, msg.StockAmount), sdk.NewCoin(msg.Money, msg.MoneyAmount))
+	if err := k.SendCoinsFromPoolToUser(cacheCtx, msg.Sender, coins); err != nil {
+		return err.Result()
+	}
+	write()
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyFlashBorrow,
+			sdk.NewAttribute(AttributeSymbol, marKey),
+			sdk.NewAttribute(AttributeAmount, coins.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgFlashRepay(ctx sdk.Context, k *keepers.Keeper, msg types.MsgFlashRepay) sdk.Result {
+	marKey := dex.GetSymbol(msg.Stock, msg.Money)
+	// the loan is closed in a cached context, which is dropped if the sender can't repay it
+	cacheCtx, write := ctx.CacheContext()
+	stockIn, moneyIn, err := k.FlashRepay(cacheCtx, marKey, msg.Sender)
+	if err != nil {
+		return err.Result()
+	}
+	coins := sdk.NewCoins(sdk.NewCoin(msg.Stock, stockIn), sdk.NewCoin(msg.Money, moneyIn))
+	if err = k.SendCoinsFromUserToPool(cacheCtx, msg.Sender, coins); err != nil {
+		return err.Result()
+	}
+	write()
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyFlashRepay,
+			sdk.NewAttribute(AttributeSymbol, marKey),
+			sdk.NewAttribute(AttributeAmount, coins.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
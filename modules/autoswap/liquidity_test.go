@@ -0,0 +1,75 @@
+package autoswap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/coinexchain/cet-sdk/modules/asset"
+	"github.com/coinexchain/cet-sdk/modules/autoswap"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	"github.com/coinexchain/cet-sdk/testapp"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+// newAppWithTokens returns an app at height 10, in which all the tokens are issued to owner
+func newAppWithTokens(t *testing.T, owner sdk.AccAddress, tokens ...string) (*testapp.TestApp, sdk.Context) {
+	app := testapp.NewTestApp()
+	ctx := sdk.NewContext(app.Cms, abci.Header{Height: 10}, false, log.NewNopLogger())
+	app.SupplyKeeper.SetSupply(ctx, supply.Supply{Total: sdk.Coins{}})
+	app.AssetKeeper.SetParams(ctx, asset.DefaultParams())
+	app.AutoSwapKeeper.SetParams(ctx, types.DefaultParams())
+	app.AccountKeeper.SetAccount(ctx, app.AccountKeeper.NewAccount(ctx, supply.NewEmptyModuleAccount(autoswap.PoolModuleAcc)))
+	for _, sym := range tokens {
+		require.NoError(t, app.AssetKeeper.IssueToken(ctx, sym, sym, sdk.NewInt(1e10), owner,
+			false, false, false, false, sym, sym, sym))
+		require.NoError(t, app.AssetKeeper.SendCoinsFromAssetModuleToAccount(ctx, owner, sdk.NewCoins(sdk.NewCoin(sym, sdk.NewInt(1e10)))))
+	}
+	return app, ctx
+}
+
+func TestLiquiditySlippageAndDeadline(t *testing.T) {
+	owner := sdk.AccAddress("owner")
+	app, ctx := newAppWithTokens(t, owner, "foo0", "usd0")
+	k := app.AutoSwapKeeper
+	symbol := dex.GetSymbol("foo0", "usd0")
+	k.CreatePair(ctx, owner, symbol, 0)
+	handler := autoswap.NewHandler(k)
+
+	addMsg := types.MsgAddLiquidity{Sender: owner, Stock: "foo0", Money: "usd0",
+		StockIn: sdk.NewInt(10000), MoneyIn: sdk.NewInt(1000000), Deadline: 9}
+	require.Equal(t, sdk.CodeType(types.CodeLiquidityExpired), handler(ctx, addMsg).Code)
+	addMsg.Deadline = 10
+	require.True(t, handler(ctx, addMsg).IsOK())
+	require.Equal(t, sdk.NewInt(100000), k.GetLiquidity(ctx, symbol, owner))
+
+	// only 500 stock is needed for 50000 money at the ratio of the pool
+	addMsg.StockIn, addMsg.MoneyIn = sdk.NewInt(1000), sdk.NewInt(50000)
+	addMsg.MinStockIn = sdk.NewInt(501)
+	require.Equal(t, sdk.CodeType(types.CodeStockInIsSmall), handler(ctx, addMsg).Code)
+	addMsg.StockIn, addMsg.MoneyIn = sdk.NewInt(500), sdk.NewInt(100000)
+	addMsg.MinStockIn, addMsg.MinMoneyIn = sdk.NewInt(500), sdk.NewInt(50001)
+	require.Equal(t, sdk.CodeType(types.CodeMoneyInIsSmall), handler(ctx, addMsg).Code)
+	addMsg.MinMoneyIn = sdk.NewInt(50000)
+	require.True(t, handler(ctx, addMsg).IsOK())
+	require.Equal(t, sdk.NewInt(105000), k.GetLiquidity(ctx, symbol, owner))
+
+	removeMsg := types.MsgRemoveLiquidity{Sender: owner, Stock: "foo0", Money: "usd0",
+		Amount: sdk.NewInt(5000), MinStockOut: sdk.NewInt(501), Deadline: 9}
+	require.Equal(t, sdk.CodeType(types.CodeLiquidityExpired), handler(ctx, removeMsg).Code)
+	removeMsg.Deadline = 0
+	require.Equal(t, sdk.CodeType(types.CodeStockOutIsSmall), handler(ctx, removeMsg).Code)
+	removeMsg.MinStockOut, removeMsg.MinMoneyOut = sdk.NewInt(500), sdk.NewInt(50001)
+	require.Equal(t, sdk.CodeType(types.CodeMoneyOutIsSmall), handler(ctx, removeMsg).Code)
+	// nothing is burnt by the failed messages
+	require.Equal(t, sdk.NewInt(105000), k.GetLiquidity(ctx, symbol, owner))
+	require.Equal(t, sdk.NewInt(105000), k.GetPoolInfo(ctx, symbol).TotalSupply)
+	removeMsg.MinMoneyOut = sdk.NewInt(50000)
+	require.True(t, handler(ctx, removeMsg).IsOK())
+	require.Equal(t, sdk.NewInt(100000), k.GetLiquidity(ctx, symbol, owner))
+}
@@ -0,0 +1,160 @@
+package autoswap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	"github.com/coinexchain/cet-sdk/modules/market"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+func TestRangeLiquidity(t *testing.T) {
+	owner := sdk.AccAddress("owner_______________")
+	trader := sdk.AccAddress("trader______________")
+	app, ctx := newAppWithTokens(t, owner, "foo0", "usd0")
+	k := app.AutoSwapKeeper
+	handler := autoswap.NewHandler(k)
+	symbol := dex.GetSymbol("foo0", "usd0")
+	createMsg := types.MsgAutoSwapCreateTradingPair{Stock: "foo0", Money: "usd0", Creator: owner,
+		PricePrecision: 8, RangeLiquidity: true, InitPrice: sdk.OneDec()}
+	require.True(t, handler(ctx, createMsg).IsOK())
+
+	res := handler(ctx, types.MsgAddLiquidity{Sender: owner, Stock: "foo0", Money: "usd0",
+		StockIn: sdk.NewInt(100), MoneyIn: sdk.NewInt(100)})
+	require.Equal(t, sdk.CodeType(types.CodeInvalidPoolType), res.Code)
+
+	lowerA, upperA := sdk.MustNewDecFromStr("0.9"), sdk.MustNewDecFromStr("1.1")
+	lowerB, upperB := sdk.MustNewDecFromStr("1.05"), sdk.MustNewDecFromStr("1.2")
+	addMsg := types.MsgAddRangeLiquidity{Sender: owner, Stock: "foo0", Money: "usd0",
+		LowerPrice: lowerA, UpperPrice: upperA, StockIn: sdk.NewInt(20), MoneyIn: sdk.NewInt(10)}
+	// the liquidity of a position is not less than MinRangeLiquidity
+	require.Equal(t, sdk.CodeType(types.CodeInvalidLiquidityAmount), handler(ctx, addMsg).Code)
+	addMsg.StockIn, addMsg.MoneyIn = sdk.NewInt(200000), sdk.NewInt(100000)
+	// the prices are the multiples of 10^-PricePrecision
+	addMsg.LowerPrice = sdk.MustNewDecFromStr("0.900000001")
+	require.Equal(t, sdk.CodeType(types.CodeInvalidPriceRange), handler(ctx, addMsg).Code)
+	addMsg.LowerPrice = lowerA
+	require.True(t, handler(ctx, addMsg).IsOK())
+	// B is above the price, so it only takes stock
+	addMsg.LowerPrice, addMsg.UpperPrice = lowerB, upperB
+	require.True(t, handler(ctx, addMsg).IsOK())
+	posA := k.GetRangePosition(ctx, symbol, owner, lowerA, upperA)
+	posB := k.GetRangePosition(ctx, symbol, owner, lowerB, upperB)
+	info := k.GetPoolInfo(ctx, symbol)
+	require.Equal(t, 4, len(k.GetTicks(ctx, symbol)))
+	require.Equal(t, posA.Liquidity, info.ActiveLiquidity)
+	require.Equal(t, sdk.NewInt(100000), info.MoneyAmmReserve)
+	require.Equal(t, sdk.NewInt(1e10-100000), app.BankxKeeper.GetCoins(ctx, owner).AmountOf("usd0"))
+	require.Equal(t, sdk.NewInt(1e10).Sub(info.StockAmmReserve), app.BankxKeeper.GetCoins(ctx, owner).AmountOf("foo0"))
+
+	// the swap crosses 1.05, where B becomes active
+	require.NoError(t, app.BankxKeeper.SendCoins(ctx, owner, trader, sdk.NewCoins(sdk.NewCoin("usd0", sdk.NewInt(1000000)))))
+	path := []string{"usd0", "foo0"}
+	amounts, err := k.GetAmountsOut(ctx, path, sdk.NewInt(100000))
+	require.NoError(t, err)
+	res = handler(ctx, types.MsgSwapExactIn{Sender: trader, Path: path, AmountIn: sdk.NewInt(100000),
+		MinAmountOut: amounts[1], Deadline: 10})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, amounts[1], app.BankxKeeper.GetCoins(ctx, trader).AmountOf("foo0"))
+	info = k.GetPoolInfo(ctx, symbol)
+	price := info.SqrtPrice.Mul(info.SqrtPrice)
+	require.True(t, price.GT(lowerB) && price.LT(upperA))
+	require.Equal(t, posA.Liquidity.Add(posB.Liquidity), info.ActiveLiquidity)
+	require.True(t, info.FeeGrowthStock.IsPositive())
+
+	// more than all the stock in the ranges can't be bought
+	_, err = k.GetAmountsIn(ctx, path, info.StockAmmReserve)
+	require.Equal(t, sdk.CodeType(types.CodeInvalidSwap), err.Code())
+	amounts, err = k.GetAmountsIn(ctx, path, sdk.NewInt(1000))
+	require.NoError(t, err)
+	require.True(t, handler(ctx, types.MsgSwapExactOut{Sender: trader, Path: path, AmountOut: sdk.NewInt(1000),
+		MaxAmountIn: amounts[0], Deadline: 10}).IsOK())
+
+	// a sell order under the price of the pool moves the price to 0.95, crossing 1.05 downward
+	res = handler(ctx, market.MsgCreateOrder{Sender: owner, Identify: 1, TradingPair: symbol, PricePrecision: 2,
+		Price: 95, Quantity: 1000000, Side: market.SELL})
+	require.True(t, res.IsOK(), res.Log)
+	info = k.GetPoolInfo(ctx, symbol)
+	price = info.SqrtPrice.Mul(info.SqrtPrice)
+	require.True(t, price.LTE(sdk.MustNewDecFromStr("0.95")) && price.GT(sdk.MustNewDecFromStr("0.9499")))
+	require.Equal(t, posA.Liquidity, info.ActiveLiquidity)
+	require.True(t, info.StockOrderBookReserve.IsPositive())
+	require.True(t, info.FeeGrowthMoney.IsPositive())
+
+	gene := autoswap.ExportGenesis(ctx, *k)
+	require.Equal(t, 2, len(gene.RangePositions))
+	require.Equal(t, 4, len(gene.Ticks))
+	require.NoError(t, gene.Validate())
+	gene.Ticks = gene.Ticks[1:]
+	require.Error(t, gene.Validate())
+	gene = autoswap.ExportGenesis(ctx, *k)
+	gene.RangePositions = gene.RangePositions[1:]
+	require.Error(t, gene.Validate())
+
+	// removing A takes out its tokens and fees, B holds only stock again
+	before := app.BankxKeeper.GetCoins(ctx, owner)
+	res = handler(ctx, types.MsgRemoveRangeLiquidity{Sender: owner, Stock: "foo0", Money: "usd0",
+		LowerPrice: lowerA, UpperPrice: upperA, Liquidity: posA.Liquidity})
+	require.True(t, res.IsOK(), res.Log)
+	received := app.BankxKeeper.GetCoins(ctx, owner).Sub(before)
+	require.True(t, received.AmountOf("foo0").IsPositive() && received.AmountOf("usd0").IsPositive())
+	require.Nil(t, k.GetRangePosition(ctx, symbol, owner, lowerA, upperA))
+	info = k.GetPoolInfo(ctx, symbol)
+	require.Equal(t, 2, len(k.GetTicks(ctx, symbol)))
+	require.True(t, info.ActiveLiquidity.IsZero())
+	stockB, moneyB := info.StockAmmReserve, info.MoneyAmmReserve
+	require.True(t, stockB.GTE(sdk.NewInt(100000)) && moneyB.LT(sdk.NewInt(10)))
+
+	// the pool keeps nothing after the last position is removed, except the rounding dust
+	res = handler(ctx, types.MsgRemoveRangeLiquidity{Sender: owner, Stock: "foo0", Money: "usd0",
+		LowerPrice: lowerB, UpperPrice: upperB, Liquidity: posB.Liquidity.AddRaw(1)})
+	require.Equal(t, sdk.CodeType(types.CodeInvalidLiquidityAmount), res.Code)
+	res = handler(ctx, types.MsgRemoveRangeLiquidity{Sender: owner, Stock: "foo0", Money: "usd0",
+		LowerPrice: lowerB, UpperPrice: upperB, Liquidity: posB.Liquidity})
+	require.True(t, res.IsOK(), res.Log)
+	info = k.GetPoolInfo(ctx, symbol)
+	require.Equal(t, 0, len(k.GetTicks(ctx, symbol)))
+	require.True(t, info.StockAmmReserve.LT(sdk.NewInt(10)))
+}
+
+func TestRangeLiquidityFeeOfCrossedRanges(t *testing.T) {
+	owner := sdk.AccAddress("owner_______________")
+	trader := sdk.AccAddress("trader______________")
+	app, ctx := newAppWithTokens(t, owner, "foo0", "usd0")
+	k := app.AutoSwapKeeper
+	handler := autoswap.NewHandler(k)
+	symbol := dex.GetSymbol("foo0", "usd0")
+	require.True(t, handler(ctx, types.MsgAutoSwapCreateTradingPair{Stock: "foo0", Money: "usd0", Creator: owner,
+		PricePrecision: 8, RangeLiquidity: true, InitPrice: sdk.OneDec()}).IsOK())
+	lower, upper := sdk.MustNewDecFromStr("0.9"), sdk.MustNewDecFromStr("1.1")
+	require.True(t, handler(ctx, types.MsgAddRangeLiquidity{Sender: owner, Stock: "foo0", Money: "usd0",
+		LowerPrice: lower, UpperPrice: upper, StockIn: sdk.NewInt(200000), MoneyIn: sdk.NewInt(100000)}).IsOK())
+	stockIn := k.GetPoolInfo(ctx, symbol).StockAmmReserve
+
+	// the buy order takes all the stock of the position and moves the price beyond it,
+	// the fee is still earned by the position
+	require.NoError(t, app.BankxKeeper.SendCoins(ctx, owner, trader, sdk.NewCoins(sdk.NewCoin("usd0", sdk.NewInt(10000000)))))
+	res := handler(ctx, market.MsgCreateOrder{Sender: trader, Identify: 1, TradingPair: symbol, PricePrecision: 2,
+		Price: 200, Quantity: 1000000, Side: market.BUY})
+	require.True(t, res.IsOK(), res.Log)
+	info := k.GetPoolInfo(ctx, symbol)
+	require.True(t, info.ActiveLiquidity.IsZero())
+	require.True(t, info.SqrtPrice.Mul(info.SqrtPrice).GTE(sdk.MustNewDecFromStr("1.0999")))
+	require.True(t, info.FeeGrowthStock.IsPositive())
+	bought := app.BankxKeeper.GetCoins(ctx, trader).AmountOf("foo0")
+	require.True(t, bought.IsPositive() && bought.LT(stockIn))
+
+	pos := k.GetRangePosition(ctx, symbol, owner, lower, upper)
+	before := app.BankxKeeper.GetCoins(ctx, owner)
+	require.True(t, handler(ctx, types.MsgRemoveRangeLiquidity{Sender: owner, Stock: "foo0", Money: "usd0",
+		LowerPrice: lower, UpperPrice: upper, Liquidity: pos.Liquidity}).IsOK())
+	// the position holds no stock above its range, so the stock received is the fee
+	received := app.BankxKeeper.GetCoins(ctx, owner).Sub(before)
+	require.True(t, received.AmountOf("foo0").IsPositive())
+	require.True(t, received.AmountOf("foo0").LT(bought.QuoRaw(100)))
+}
@@ -0,0 +1,142 @@
+package autoswap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+func TestLiquidityRewards(t *testing.T) {
+	alice := sdk.AccAddress("alice_______________")
+	bob := sdk.AccAddress("bob_________________")
+	carol := sdk.AccAddress("carol_______________")
+	app, ctx := newAppWithTokens(t, alice, "foo0", "usd0", "bar0")
+	k := app.AutoSwapKeeper
+	handler := autoswap.NewHandler(k)
+	symbol := dex.GetSymbol("foo0", "usd0")
+	require.True(t, handler(ctx, types.MsgAutoSwapCreateTradingPair{Stock: "foo0", Money: "usd0", Creator: alice, PricePrecision: 8}).IsOK())
+	require.NoError(t, app.BankxKeeper.SendCoins(ctx, alice, bob, sdk.NewCoins(sdk.NewCoin("foo0", sdk.NewInt(1000)), sdk.NewCoin("usd0", sdk.NewInt(1000)))))
+	addMsg := types.MsgAddLiquidity{Sender: alice, Stock: "foo0", Money: "usd0", StockIn: sdk.NewInt(1000), MoneyIn: sdk.NewInt(1000), To: alice}
+	require.True(t, handler(ctx, addMsg).IsOK())
+
+	// 10 bar0 per block from height 10, for 10 blocks
+	fundMsg := types.MsgFundRewardProgram{Sender: alice, Stock: "foo0", Money: "usd0", Denom: "bar0",
+		Amount: sdk.NewInt(100), RewardPerBlock: sdk.ZeroInt()}
+	require.Equal(t, sdk.CodeType(types.CodeInvalidRewardProgram), handler(ctx, fundMsg).Code)
+	fundMsg.RewardPerBlock = sdk.NewInt(10)
+	require.True(t, handler(ctx, fundMsg).IsOK())
+	require.Equal(t, sdk.NewInt(1e10-100), app.BankxKeeper.GetCoins(ctx, alice).AmountOf("bar0"))
+
+	// alice earns all the rewards of 2 blocks before bob comes
+	ctx = ctx.WithBlockHeight(12)
+	addMsg.Sender, addMsg.To = bob, bob
+	require.True(t, handler(ctx, addMsg).IsOK())
+
+	ctx = ctx.WithBlockHeight(15)
+	require.Equal(t, sdk.NewInt(15), k.GetUnclaimedRewards(ctx, symbol, bob))
+	querier := keepers.NewQuerier(*k)
+	res, err := querier(ctx, []string{types.QueryRewards}, abci.RequestQuery{Data: app.Cdc.MustMarshalJSON(keepers.QueryLiquidityParam{Owner: bob})})
+	require.Nil(t, err)
+	var rewards []keepers.PoolReward
+	app.Cdc.MustUnmarshalJSON(res, &rewards)
+	require.Equal(t, 1, len(rewards))
+	require.Equal(t, sdk.NewInt(15), rewards[0].Unclaimed)
+	require.Equal(t, sdk.NewInt(50), rewards[0].Program.Remaining)
+
+	// the rewards can't be paid in another token, or at another rate before the program runs out
+	fundMsg.RewardPerBlock = sdk.NewInt(20)
+	require.Equal(t, sdk.CodeType(types.CodeInvalidRewardProgram), handler(ctx, fundMsg).Code)
+	fundMsg.RewardPerBlock, fundMsg.Denom = sdk.ZeroInt(), "foo0"
+	require.Equal(t, sdk.CodeType(types.CodeInvalidRewardProgram), handler(ctx, fundMsg).Code)
+
+	// 20 + 30/2
+	require.True(t, handler(ctx, types.MsgClaimRewards{Sender: alice, Stock: "foo0", Money: "usd0", To: carol}).IsOK())
+	require.Equal(t, sdk.NewInt(35), app.BankxKeeper.GetCoins(ctx, carol).AmountOf("bar0"))
+
+	// the rewards earned before the transfer stay with bob
+	ctx = ctx.WithBlockHeight(16)
+	require.True(t, handler(ctx, types.MsgTransferLiquidity{Sender: bob, Stock: "foo0", Money: "usd0", Amount: sdk.NewInt(500), To: carol}).IsOK())
+	require.Equal(t, sdk.NewInt(20), k.GetUnclaimedRewards(ctx, symbol, bob))
+	require.True(t, k.GetUnclaimedRewards(ctx, symbol, carol).IsZero())
+
+	ctx = ctx.WithBlockHeight(18)
+	res2 := handler(ctx, types.MsgRemoveLiquidity{Sender: alice, Stock: "foo0", Money: "usd0", Amount: sdk.NewInt(1000), To: alice})
+	require.True(t, res2.IsOK(), res2.Log)
+	require.Equal(t, sdk.NewInt(15), k.GetUnclaimedRewards(ctx, symbol, alice))
+
+	// the program runs out at height 20
+	ctx = ctx.WithBlockHeight(30)
+	require.Equal(t, sdk.NewInt(15), k.GetUnclaimedRewards(ctx, symbol, alice))
+	require.Equal(t, sdk.NewInt(35), k.GetUnclaimedRewards(ctx, symbol, bob))
+	require.Equal(t, sdk.NewInt(15), k.GetUnclaimedRewards(ctx, symbol, carol))
+
+	gene := autoswap.ExportGenesis(ctx, *k)
+	require.Equal(t, 1, len(gene.RewardPrograms))
+	require.Equal(t, 3, len(gene.LiquidityRewards))
+	require.NoError(t, gene.Validate())
+	gene.RewardPrograms = nil
+	require.Error(t, gene.Validate())
+
+	for _, owner := range []sdk.AccAddress{alice, bob, carol} {
+		before := app.BankxKeeper.GetCoins(ctx, owner).AmountOf("bar0")
+		require.True(t, handler(ctx, types.MsgClaimRewards{Sender: owner, Stock: "foo0", Money: "usd0"}).IsOK())
+		require.True(t, app.BankxKeeper.GetCoins(ctx, owner).AmountOf("bar0").GT(before))
+	}
+	require.True(t, k.GetRewardProgram(ctx, symbol).Remaining.IsZero())
+	// alice has no liquidity, so her reward is removed after it's claimed
+	require.Equal(t, 2, len(autoswap.ExportGenesis(ctx, *k).LiquidityRewards))
+
+	// the rate can be changed after the program runs out
+	fundMsg.RewardPerBlock, fundMsg.Denom = sdk.NewInt(20), "bar0"
+	require.True(t, handler(ctx, fundMsg).IsOK())
+	require.Equal(t, sdk.NewInt(20), k.GetRewardProgram(ctx, symbol).RewardPerBlock)
+
+	require.Equal(t, sdk.CodeType(types.CodeRewardProgramNotExist),
+		handler(ctx, types.MsgClaimRewards{Sender: alice, Stock: "bar0", Money: "usd0"}).Code)
+}
+
+func TestDelistPairWithRewardProgram(t *testing.T) {
+	alice := sdk.AccAddress("alice_______________")
+	bob := sdk.AccAddress("bob_________________")
+	app, ctx := newAppWithTokens(t, alice, "foo0", "usd0", "bar0")
+	ctx = ctx.WithBlockTime(time.Unix(1000, 0))
+	k := app.AutoSwapKeeper
+	handler := autoswap.NewHandler(k)
+	symbol := dex.GetSymbol("foo0", "usd0")
+	createMsg := types.MsgAutoSwapCreateTradingPair{Stock: "foo0", Money: "usd0", Creator: alice, PricePrecision: 8}
+	require.True(t, handler(ctx, createMsg).IsOK())
+	require.True(t, handler(ctx, types.MsgAddLiquidity{Sender: alice, Stock: "foo0", Money: "usd0",
+		StockIn: sdk.NewInt(1000), MoneyIn: sdk.NewInt(1000), To: alice}).IsOK())
+	require.NoError(t, app.BankxKeeper.SendCoins(ctx, alice, bob, sdk.NewCoins(sdk.NewCoin("bar0", sdk.NewInt(300)))))
+
+	fundMsg := types.MsgFundRewardProgram{Sender: alice, Stock: "foo0", Money: "usd0", Denom: "bar0",
+		Amount: sdk.NewInt(100), RewardPerBlock: sdk.NewInt(10)}
+	require.True(t, handler(ctx, fundMsg).IsOK())
+	fundMsg.Sender, fundMsg.Amount, fundMsg.RewardPerBlock = bob, sdk.NewInt(300), sdk.ZeroInt()
+	require.True(t, handler(ctx, fundMsg).IsOK())
+	require.Equal(t, 2, len(k.GetRewardProgram(ctx, symbol).Funders))
+
+	// alice earns 50 before the pair is delisted, and the remaining 350 is refunded by 1:3
+	ctx = ctx.WithBlockHeight(15)
+	k.AddDelistRequest(ctx, ctx.BlockHeader().Time.UnixNano(), symbol)
+	autoswap.EndBlocker(ctx, k)
+	require.Nil(t, k.GetPoolInfo(ctx, symbol))
+	require.Nil(t, k.GetRewardProgram(ctx, symbol))
+	require.Equal(t, 0, len(autoswap.ExportGenesis(ctx, *k).LiquidityRewards))
+	require.Equal(t, sdk.NewInt(1e10-400+50+87), app.BankxKeeper.GetCoins(ctx, alice).AmountOf("bar0"))
+	require.Equal(t, sdk.NewInt(263), app.BankxKeeper.GetCoins(ctx, bob).AmountOf("bar0"))
+
+	// the pool created again under the same symbol starts without a reward program
+	require.True(t, handler(ctx, createMsg).IsOK())
+	require.Equal(t, sdk.CodeType(types.CodeRewardProgramNotExist),
+		handler(ctx, types.MsgClaimRewards{Sender: alice, Stock: "foo0", Money: "usd0"}).Code)
+}
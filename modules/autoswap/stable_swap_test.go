@@ -0,0 +1,69 @@
+package autoswap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/autoswap"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+func TestStableSwapPool(t *testing.T) {
+	owner := sdk.AccAddress("owner_______________")
+	trader := sdk.AccAddress("trader______________")
+	app, ctx := newAppWithTokens(t, owner, "usdx", "usdy")
+	k := app.AutoSwapKeeper
+	handler := autoswap.NewHandler(k)
+	symbol := dex.GetSymbol("usdx", "usdy")
+
+	createMsg := types.MsgAutoSwapCreateTradingPair{Stock: "usdx", Money: "usdy", Creator: owner,
+		PricePrecision: 8, CurveType: types.CurveStableSwap}
+	require.Equal(t, sdk.CodeType(types.CodeInvalidCurve), createMsg.ValidateBasic().Code())
+	createMsg.Amplification = 100
+	createMsg.RangeLiquidity, createMsg.InitPrice = true, sdk.OneDec()
+	require.Equal(t, sdk.CodeType(types.CodeInvalidCurve), createMsg.ValidateBasic().Code())
+	createMsg.RangeLiquidity, createMsg.InitPrice = false, sdk.Dec{}
+	require.NoError(t, createMsg.ValidateBasic())
+	require.True(t, handler(ctx, createMsg).IsOK())
+	info := k.GetPoolInfo(ctx, symbol)
+	require.Equal(t, byte(types.CurveStableSwap), info.CurveType)
+	require.Equal(t, int64(100), info.Amplification)
+
+	res := handler(ctx, types.MsgAddLiquidity{Sender: owner, Stock: "usdx", Money: "usdy",
+		StockIn: sdk.NewInt(1000000), MoneyIn: sdk.NewInt(1000000), To: owner})
+	require.True(t, res.IsOK(), res.Log)
+	// the liquidity of balanced reserves is the same as on x*y=k
+	require.Equal(t, sdk.NewInt(1000000), k.GetLiquidity(ctx, symbol, owner))
+
+	require.NoError(t, app.BankxKeeper.SendCoins(ctx, owner, trader, sdk.NewCoins(sdk.NewCoin("usdy", sdk.NewInt(100000)))))
+	path := []string{"usdy", "usdx"}
+	amounts, err := k.GetAmountsOut(ctx, path, sdk.NewInt(100000))
+	require.NoError(t, err)
+	// x*y=k only pays out 90909 before the fee
+	require.True(t, amounts[1].GT(sdk.NewInt(99000)))
+	res = handler(ctx, types.MsgSwapExactIn{Sender: trader, Path: path, AmountIn: sdk.NewInt(100000),
+		MinAmountOut: amounts[1], Deadline: 10})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, amounts[1], app.BankxKeeper.GetCoins(ctx, trader).AmountOf("usdx"))
+
+	// swap back for an exact amount
+	path = []string{"usdx", "usdy"}
+	amounts, err = k.GetAmountsIn(ctx, path, sdk.NewInt(50000))
+	require.NoError(t, err)
+	require.True(t, amounts[0].LT(sdk.NewInt(50500)))
+	res = handler(ctx, types.MsgSwapExactOut{Sender: trader, Path: path, AmountOut: sdk.NewInt(50000),
+		MaxAmountIn: amounts[0], Deadline: 10})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.NewInt(50000), app.BankxKeeper.GetCoins(ctx, trader).AmountOf("usdy"))
+	_, err = k.GetAmountsIn(ctx, path, k.GetPoolInfo(ctx, symbol).MoneyAmmReserve)
+	require.Equal(t, sdk.CodeType(types.CodeInvalidSwap), err.Code())
+
+	gene := autoswap.ExportGenesis(ctx, *k)
+	require.NoError(t, gene.Validate())
+	gene.PoolInfos[0].Amplification = 0
+	require.Error(t, gene.Validate())
+}
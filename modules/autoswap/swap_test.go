@@ -0,0 +1,91 @@
+package autoswap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/coinexchain/cet-sdk/modules/asset"
+	"github.com/coinexchain/cet-sdk/modules/autoswap"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/autoswap/internal/types"
+	"github.com/coinexchain/cet-sdk/testapp"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+func TestMultiHopSwap(t *testing.T) {
+	owner := sdk.AccAddress("owner")
+	trader := sdk.AccAddress("trader")
+	receiver := sdk.AccAddress("receiver")
+	app := testapp.NewTestApp()
+	ctx := sdk.NewContext(app.Cms, abci.Header{Height: 10}, false, log.NewNopLogger())
+	app.SupplyKeeper.SetSupply(ctx, supply.Supply{Total: sdk.Coins{}})
+	app.AssetKeeper.SetParams(ctx, asset.DefaultParams())
+	app.AutoSwapKeeper.SetParams(ctx, types.DefaultParams())
+	app.AccountKeeper.SetAccount(ctx, app.AccountKeeper.NewAccount(ctx, supply.NewEmptyModuleAccount(autoswap.PoolModuleAcc)))
+	for _, sym := range []string{"foo0", "bar0", "usd0"} {
+		require.NoError(t, app.AssetKeeper.IssueToken(ctx, sym, sym, sdk.NewInt(1e10), owner,
+			false, false, false, false, sym, sym, sym))
+		require.NoError(t, app.AssetKeeper.SendCoinsFromAssetModuleToAccount(ctx, owner, sdk.NewCoins(sdk.NewCoin(sym, sdk.NewInt(1e10)))))
+	}
+	k := app.AutoSwapKeeper
+	for _, stock := range []string{"foo0", "bar0"} {
+		symbol := dex.GetSymbol(stock, "usd0")
+		k.CreatePair(ctx, owner, symbol, 0)
+		coins := sdk.NewCoins(sdk.NewCoin(stock, sdk.NewInt(10000)), sdk.NewCoin("usd0", sdk.NewInt(1000000)))
+		require.NoError(t, k.SendCoinsFromUserToPool(ctx, owner, coins))
+		_, err := k.Mint(ctx, symbol, sdk.NewInt(10000), sdk.NewInt(1000000), owner)
+		require.NoError(t, err)
+	}
+	require.NoError(t, app.BankxKeeper.SendCoins(ctx, owner, trader, sdk.NewCoins(sdk.NewCoin("foo0", sdk.NewInt(10000)))))
+	path := []string{"foo0", "usd0", "bar0"}
+
+	// quote without executing
+	querier := keepers.NewQuerier(*k)
+	quote := func(amount int64, exactOut bool) []sdk.Int {
+		reqBytes := app.Cdc.MustMarshalJSON(keepers.QuerySwapQuoteParam{Path: path, Amount: sdk.NewInt(amount), ExactOut: exactOut})
+		res, err := querier(ctx, []string{types.QuerySwapQuote}, abci.RequestQuery{Data: reqBytes})
+		require.Nil(t, err)
+		var resp keepers.QuerySwapQuoteResponse
+		app.Cdc.MustUnmarshalJSON(res, &resp)
+		return resp.Amounts
+	}
+	// 1000000*1000/11000 = 90909, minus the fee 455
+	// 10000*90454/1090454 = 829, minus the fee 5
+	require.Equal(t, []sdk.Int{sdk.NewInt(1000), sdk.NewInt(90454), sdk.NewInt(824)}, quote(1000, false))
+
+	handler := autoswap.NewHandler(k)
+	msg := types.MsgSwapExactIn{Sender: trader, Path: path, AmountIn: sdk.NewInt(1000),
+		MinAmountOut: sdk.NewInt(825), Deadline: 9, To: receiver}
+	require.Equal(t, sdk.CodeType(types.CodeSwapExpired), handler(ctx, msg).Code)
+	msg.Deadline = 10
+	require.Equal(t, sdk.CodeType(types.CodeAmountOutIsSmall), handler(ctx, msg).Code)
+	require.Equal(t, sdk.NewInt(10000), app.BankxKeeper.GetCoins(ctx, trader).AmountOf("foo0"))
+	msg.Path = []string{"foo0", "bar0"}
+	require.Equal(t, sdk.CodeType(types.CodeInvalidSwap), handler(ctx, msg).Code)
+
+	msg.Path = path
+	msg.MinAmountOut = sdk.NewInt(824)
+	require.True(t, handler(ctx, msg).IsOK())
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin("foo0", sdk.NewInt(9000))), app.BankxKeeper.GetCoins(ctx, trader))
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin("bar0", sdk.NewInt(824))), app.BankxKeeper.GetCoins(ctx, receiver))
+	info := k.GetPoolInfo(ctx, dex.GetSymbol("foo0", "usd0"))
+	require.Equal(t, sdk.NewInt(11000), info.StockAmmReserve)
+	require.True(t, info.MoneyAmmReserve.GT(sdk.NewInt(1000000-90909)))
+
+	amounts := quote(500, true)
+	require.True(t, amounts[2].Equal(sdk.NewInt(500)))
+	outMsg := types.MsgSwapExactOut{Sender: trader, Path: path, AmountOut: sdk.NewInt(500),
+		MaxAmountIn: amounts[0].SubRaw(1), Deadline: 10}
+	require.Equal(t, sdk.CodeType(types.CodeAmountInIsLarge), handler(ctx, outMsg).Code)
+	outMsg.MaxAmountIn = amounts[0]
+	require.True(t, handler(ctx, outMsg).IsOK())
+	require.Equal(t, sdk.NewInt(9000).Sub(amounts[0]), app.BankxKeeper.GetCoins(ctx, trader).AmountOf("foo0"))
+	require.True(t, app.BankxKeeper.GetCoins(ctx, trader).AmountOf("bar0").GTE(sdk.NewInt(500)))
+	require.True(t, app.BankxKeeper.GetCoins(ctx, trader).AmountOf("usd0").IsZero())
+}
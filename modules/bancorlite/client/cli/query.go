@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 
@@ -58,3 +59,39 @@ Example :
 		},
 	}
 }
+
+func QueryQuoteCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quote [stock] [money]",
+		Short: "query the price impact and fee of a trade with a bancor pool",
+		Long: `query the stock and money traded, the price impact and the fee of a hypothetical trade
+with a bancor pool, without sending any tx.
+
+Example :
+	cetcli query bancorlite quote stock money --side buy --amount=100 \
+	--trust-node=true --chain-id=coinexdex
+	cetcli query bancorlite quote stock money --side buy --money-in=120 \
+	--trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			isBuy, err := getSide()
+			if err != nil {
+				return err
+			}
+			query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryQuote)
+			param := &keepers.QueryQuoteParam{
+				Symbol:  dex.GetSymbol(args[0], args[1]),
+				IsBuy:   isBuy,
+				Amount:  viper.GetInt64(FlagAmount),
+				MoneyIn: viper.GetInt64(FlagMoneyIn),
+			}
+			return cliutil.CliQuery(cdc, query, param)
+		},
+	}
+
+	cmd.Flags().Int(FlagAmount, 0, "The amount of tokens to be traded.")
+	cmd.Flags().Int(FlagMoneyIn, 0, "The exact amount of money to spend when buying.")
+	cmd.Flags().String(FlagSide, "", "the side of the trade, 'buy' or 'sell'.")
+	_ = cmd.MarkFlagRequired(FlagSide)
+	return cmd
+}
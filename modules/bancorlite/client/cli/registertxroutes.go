@@ -19,6 +19,7 @@ func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 		QueryParamsCmd(cdc),
 		QueryBancorInfoCmd(cdc),
 		QueryBancorListCmd(cdc),
+		QueryQuoteCmd(cdc),
 	)...)
 	return bancorliteQueryCmd
 }
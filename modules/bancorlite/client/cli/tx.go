@@ -22,6 +22,7 @@ const (
 	FlagSide               = "side"
 	FlagAmount             = "amount"
 	FlagMoneyLimit         = "money-limit"
+	FlagMoneyIn            = "money-in"
 	FlagInitPrice          = "init-price"
 	FlagEarliestCancelTime = "earliest-cancel-time"
 )
@@ -107,22 +108,19 @@ func BancorTradeCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "trade [stock] [money]",
 		Short: "Trade with a bancor pool",
-		Long: `Sell Stocks to a bancor pool or buy Stocks from a bancor pool.
+		Long: `Sell Stocks to a bancor pool or buy Stocks from a bancor pool. When buying with an exact
+amount of money, the amount is the least stock to buy.
 
 Example: 
 	 cetcli tx bancorlite trade stock money --side buy --amount=100 --money-limit=120
 	 cetcli tx bancorlite trade stock money --side sell --amount=100 --money-limit=80
+	 cetcli tx bancorlite trade stock money --side buy --money-in=120 --amount=95 --money-limit=0
 `,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var isBuy bool
-			switch viper.GetString(FlagSide) {
-			case "buy":
-				isBuy = true
-			case "sell":
-				isBuy = false
-			default:
-				return errors.New("unknown Side. Please specify 'buy' or 'sell'")
+			isBuy, err := getSide()
+			if err != nil {
+				return err
 			}
 			msg := &types.MsgBancorTrade{
 				Stock:      args[0],
@@ -130,6 +128,7 @@ Example:
 				Amount:     viper.GetInt64(FlagAmount),
 				IsBuy:      isBuy,
 				MoneyLimit: viper.GetInt64(FlagMoneyLimit),
+				MoneyIn:    viper.GetInt64(FlagMoneyIn),
 			}
 			return cliutil.CliRunCommand(cdc, msg)
 		},
@@ -137,6 +136,7 @@ Example:
 
 	cmd.Flags().Int(FlagAmount, 0, "The amount of tokens to be traded.")
 	cmd.Flags().Int(FlagMoneyLimit, 0, "The upper bound of money you want to pay when buying, or the lower bound of money you want to get when selling. Specify zero or negative value if you do not want a such a limit.")
+	cmd.Flags().Int(FlagMoneyIn, 0, "The exact amount of money to spend when buying. Specify zero if you want to buy an exact amount of tokens.")
 	cmd.Flags().String(FlagSide, "", "the side of the trade, 'buy' or 'sell'.")
 	cmd.Flags().Bool(cliutil.FlagGenerateUnsignedTx, false, "Generate a unsigned tx")
 
@@ -168,3 +168,15 @@ Example:
 
 	return cmd
 }
+
+func getSide() (isBuy bool, err error) {
+	switch viper.GetString(FlagSide) {
+	case "buy":
+		isBuy = true
+	case "sell":
+		isBuy = false
+	default:
+		err = errors.New("unknown Side. Please specify 'buy' or 'sell'")
+	}
+	return
+}
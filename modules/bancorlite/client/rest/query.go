@@ -3,6 +3,7 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -21,6 +22,7 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 	r.HandleFunc("/bancorlite/pools/{symbol}", queryBancorInfoHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc("/bancorlite/parameters", queryParamsHandlerFn(cliCtx)).Methods("GET")
 	r.HandleFunc("/bancorlite/infos", queryBancorsHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/bancorlite/pools/{symbol}/quote", queryQuoteHandlerFn(cdc, cliCtx)).Methods("GET")
 }
 
 // format: barcorlite/pools/btc-cet
@@ -51,3 +53,39 @@ func queryBancorsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 		restutil.RestQuery(cdc, cliCtx, w, r, query, nil, nil)
 	}
 }
+
+// format: barcorlite/pools/btc-cet/quote?side=buy&amount=100 or ?side=buy&money_in=120
+func queryQuoteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryQuote)
+		symbol := strings.Replace(vars["symbol"], "-", "/", 1)
+		if !market.IsValidTradingPair(strings.Split(symbol, "/")) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid Trading pair")
+			return
+		}
+		param := &keepers.QueryQuoteParam{Symbol: symbol}
+		switch r.FormValue("side") {
+		case "buy":
+			param.IsBuy = true
+		case "sell":
+		default:
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid side")
+			return
+		}
+		var err error
+		if amount := r.FormValue("amount"); len(amount) != 0 {
+			if param.Amount, err = strconv.ParseInt(amount, 10, 64); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid amount")
+				return
+			}
+		}
+		if moneyIn := r.FormValue("money_in"); len(moneyIn) != 0 {
+			if param.MoneyIn, err = strconv.ParseInt(moneyIn, 10, 64); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid money in")
+				return
+			}
+		}
+		restutil.RestQuery(cdc, cliCtx, w, r, query, param, nil)
+	}
+}
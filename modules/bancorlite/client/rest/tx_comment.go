@@ -90,6 +90,7 @@ type BancorTradeReq struct {
 	Amount     string       `json:"amount"`
 	IsBuy      bool         `json:"is_buy"`
 	MoneyLimit string       `json:"money_limit"`
+	MoneyIn    string       `json:"money_in"`
 }
 
 var _ restutil.RestReq = (*BancorTradeReq)(nil)
@@ -112,6 +113,13 @@ func (req *BancorTradeReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.M
 		return nil, errors.New("invalid money limit")
 	}
 
+	var moneyIn int64
+	if len(req.MoneyIn) != 0 {
+		if moneyIn, err = strconv.ParseInt(req.MoneyIn, 10, 64); err != nil {
+			return nil, errors.New("invalid money in")
+		}
+	}
+
 	return &types.MsgBancorTrade{
 		Sender:     sender,
 		Stock:      req.Stock,
@@ -119,6 +127,7 @@ func (req *BancorTradeReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.M
 		Amount:     amount,
 		IsBuy:      req.IsBuy,
 		MoneyLimit: moneyLimit,
+		MoneyIn:    moneyIn,
 	}, nil
 }
 
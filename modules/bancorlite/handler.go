@@ -153,20 +153,19 @@ func handleMsgBancorTrade(ctx sdk.Context, k Keeper, msg types.MsgBancorTrade) s
 		k.IsForbiddenByTokenIssuer(ctx, bi.Money, bi.Owner) {
 		return types.ErrTokenForbiddenByOwner().Result()
 	}
-	if !types.CheckStockPrecision(sdk.NewInt(msg.Amount), bi.StockPrecision) {
-		return types.ErrStockAmountPrecisionNotMatch().Result()
+	biNew, amount, diff, err := bi.SimulateTrade(sdk.NewInt(msg.Amount), sdk.NewInt(msg.MoneyIn), msg.IsBuy)
+	if err != nil {
+		return err.Result()
 	}
-	stockInPool := bi.StockInPool.AddRaw(msg.Amount)
-	if msg.IsBuy {
-		stockInPool = bi.StockInPool.SubRaw(msg.Amount)
+	if msg.MoneyIn > 0 && amount.LT(sdk.NewInt(msg.Amount)) {
+		return types.ErrStockCrossLimit().Result()
 	}
-	biNew := *bi
-	if ok := biNew.UpdateStockInPool(stockInPool); !ok {
-		return types.ErrStockInPoolOutofBound().Result()
+	// the stock bought with MoneyIn is not limited by ValidateBasic, so it is checked before used as an int64
+	if amount.GT(sdk.NewInt(types.MaxTradeAmount)) {
+		return types.ErrTradeAmountIsTooLarge().Result()
 	}
 
 	var (
-		diff            sdk.Int
 		coinsFromPool   sdk.Coins
 		coinsToPool     sdk.Coins
 		moneyCrossLimit bool
@@ -174,21 +173,13 @@ func handleMsgBancorTrade(ctx sdk.Context, k Keeper, msg types.MsgBancorTrade) s
 	)
 
 	if msg.IsBuy {
-		diff = biNew.MoneyInPool.Sub(bi.MoneyInPool)
-		if !diff.IsPositive() {
-			return types.ErrTradeMoneyNotPositive().Result()
-		}
 		coinsToPool = sdk.Coins{sdk.NewCoin(msg.Money, diff)}
-		coinsFromPool = sdk.Coins{sdk.NewCoin(msg.Stock, sdk.NewInt(msg.Amount))}
+		coinsFromPool = sdk.Coins{sdk.NewCoin(msg.Stock, amount)}
 		moneyCrossLimit = msg.MoneyLimit > 0 && diff.GT(sdk.NewInt(msg.MoneyLimit))
 		moneyErr = "more than"
 	} else {
-		diff = bi.MoneyInPool.Sub(biNew.MoneyInPool)
-		if !diff.IsPositive() {
-			return types.ErrTradeMoneyNotPositive().Result()
-		}
 		coinsFromPool = sdk.Coins{sdk.NewCoin(msg.Money, diff)}
-		coinsToPool = sdk.Coins{sdk.NewCoin(msg.Stock, sdk.NewInt(msg.Amount))}
+		coinsToPool = sdk.Coins{sdk.NewCoin(msg.Stock, amount)}
 		moneyCrossLimit = msg.MoneyLimit > 0 && diff.LT(sdk.NewInt(msg.MoneyLimit))
 		moneyErr = "less than"
 	}
@@ -197,7 +188,7 @@ func handleMsgBancorTrade(ctx sdk.Context, k Keeper, msg types.MsgBancorTrade) s
 		return types.ErrMoneyCrossLimit(moneyErr).Result()
 	}
 
-	commission := getTradeFee(ctx, k, msg, diff)
+	commission := k.GetTradeFee(ctx, msg.Stock, msg.Money, amount, diff)
 	rebateAcc, rebate, balance, exist := k.GetRebate(ctx, msg.Sender, commission)
 	if exist {
 		if err := k.DeductFee(ctx, msg.Sender, sdk.NewCoins(sdk.NewCoin(dex.CET, balance))); err != nil {
@@ -229,10 +220,10 @@ func handleMsgBancorTrade(ctx sdk.Context, k Keeper, msg types.MsgBancorTrade) s
 		Sender:            msg.Sender,
 		Stock:             msg.Stock,
 		Money:             msg.Money,
-		Amount:            msg.Amount,
+		Amount:            amount.Int64(),
 		Side:              byte(side),
 		MoneyLimit:        msg.MoneyLimit,
-		TxPrice:           sdk.NewDecFromInt(diff).QuoInt64(msg.Amount),
+		TxPrice:           sdk.NewDecFromInt(diff).QuoInt(amount),
 		UsedCommission:    balance.Int64(),
 		RebateAmount:      rebate.Int64(),
 		RebateRefereeAddr: rebateAcc,
@@ -273,21 +264,6 @@ func fillMsgQueue(ctx sdk.Context, keeper Keeper, key string, msg interface{}) {
 	}
 }
 
-func getTradeFee(ctx sdk.Context, k keepers.Keeper, msg types.MsgBancorTrade,
-	amountOfMoney sdk.Int) sdk.Int {
-
-	volume := k.GetMarketVolume(ctx, msg.Stock, msg.Money, sdk.NewDec(msg.Amount), sdk.NewDecFromInt(amountOfMoney))
-	commission := volume.
-		Mul(sdk.NewDec(k.GetParams(ctx).TradeFeeRate)).
-		QuoInt64(10000).TruncateInt64()
-
-	min := k.GetMarketFeeMin(ctx)
-	if commission < min {
-		return sdk.NewInt(min)
-	}
-	return sdk.NewInt(commission)
-}
-
 func swapStockAndMoney(ctx sdk.Context, k keepers.Keeper, trader sdk.AccAddress, owner sdk.AccAddress,
 	coinsFromPool sdk.Coins, coinsToPool sdk.Coins) sdk.Error {
 	if err := k.SendCoins(ctx, trader, owner, coinsToPool); err != nil {
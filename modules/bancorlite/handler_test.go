@@ -533,6 +533,61 @@ func Test_handleMsgBancorTradeAfterInit(t *testing.T) {
 	}
 }
 
+func Test_handleMsgBancorTradeWithMoneyIn(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	require.True(t, prepareBancorInit(input))
+
+	// 1000000 money buys the most stock it can pay for
+	moneyIn := sdk.NewInt(1000000)
+	bi := input.bik.Load(input.ctx, dex.GetSymbol(stock, money))
+	_, stockOut, moneyOut, err := bi.SimulateTrade(sdk.ZeroInt(), moneyIn, true)
+	require.Nil(t, err)
+	require.True(t, stockOut.IsPositive() && moneyOut.LTE(moneyIn))
+	_, _, moneyMore, err := bi.SimulateTrade(stockOut.AddRaw(1), sdk.ZeroInt(), true)
+	require.Nil(t, err)
+	require.True(t, moneyMore.GT(moneyIn))
+
+	msg := types.MsgBancorTrade{
+		Sender:  tradeAddr,
+		Stock:   stock,
+		Money:   money,
+		Amount:  stockOut.Int64() + 1,
+		IsBuy:   true,
+		MoneyIn: moneyIn.Int64(),
+	}
+	require.Equal(t, types.ErrStockCrossLimit().Result(), input.handler(input.ctx, msg))
+	msg.Amount = stockOut.Int64()
+	require.True(t, input.handler(input.ctx, msg).IsOK())
+	coins := input.akp.GetAccount(input.ctx, tradeAddr).GetCoins()
+	require.Equal(t, stockOut, coins.AmountOf(stock))
+	require.Equal(t, sdk.NewInt(issueAmount).Sub(moneyOut), coins.AmountOf(money))
+}
+
+func Test_handleMsgBancorTradeWithMoneyInTooMuchStock(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	require.True(t, prepareBancorInit(input))
+
+	// a pool so cheap that 1 money buys more stock than MaxTradeAmount
+	bi := input.bik.Load(input.ctx, dex.GetSymbol(stock, money))
+	bi.MaxMoney = sdk.ZeroInt()
+	bi.InitPrice, bi.MaxPrice, bi.Price = sdk.ZeroDec(), sdk.SmallestDec(), sdk.ZeroDec()
+	bi.MaxSupply = sdk.NewInt(types.MaxTradeAmount).MulRaw(2)
+	bi.StockInPool, bi.MoneyInPool = bi.MaxSupply, sdk.ZeroInt()
+	input.bik.Save(input.ctx, bi)
+	_, stockOut, _, err := bi.SimulateTrade(sdk.ZeroInt(), sdk.OneInt(), true)
+	require.Nil(t, err)
+	require.True(t, stockOut.GT(sdk.NewInt(types.MaxTradeAmount)))
+
+	msg := types.MsgBancorTrade{
+		Sender:  tradeAddr,
+		Stock:   stock,
+		Money:   money,
+		IsBuy:   true,
+		MoneyIn: 1,
+	}
+	require.Equal(t, types.ErrTradeAmountIsTooLarge().Result(), input.handler(input.ctx, msg))
+}
+
 func Test_BancorCancel(t *testing.T) {
 	type args struct {
 		ctx       sdk.Context
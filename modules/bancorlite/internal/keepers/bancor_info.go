@@ -2,6 +2,7 @@ package keepers
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/coinexchain/cet-sdk/modules/bancorlite/internal/types"
 
@@ -73,6 +74,73 @@ func (bi *BancorInfo) UpdateStockInPool(stockInPool sdk.Int) bool {
 	return true
 }
 
+// SimulateTrade returns the pool after trading amount of stock with it, together with the stock and
+// money traded. A positive moneyIn buys as much stock as it can pay for instead, and amount is ignored.
+func (bi *BancorInfo) SimulateTrade(amount, moneyIn sdk.Int, isBuy bool) (biNew BancorInfo, stock, money sdk.Int, err sdk.Error) {
+	biNew = *bi
+	if moneyIn.IsPositive() {
+		var ok bool
+		if stock, biNew, ok = bi.buyStockWithMoney(moneyIn); !ok {
+			return biNew, sdk.ZeroInt(), sdk.ZeroInt(), types.ErrTradeMoneyNotPositive()
+		}
+	} else {
+		if !types.CheckStockPrecision(amount, bi.StockPrecision) {
+			return biNew, sdk.ZeroInt(), sdk.ZeroInt(), types.ErrStockAmountPrecisionNotMatch()
+		}
+		stock = amount
+		stockInPool := bi.StockInPool.Add(amount)
+		if isBuy {
+			stockInPool = bi.StockInPool.Sub(amount)
+		}
+		if ok := biNew.UpdateStockInPool(stockInPool); !ok {
+			return biNew, sdk.ZeroInt(), sdk.ZeroInt(), types.ErrStockInPoolOutofBound()
+		}
+	}
+	money = biNew.MoneyInPool.Sub(bi.MoneyInPool)
+	if !isBuy {
+		money = money.Neg()
+	}
+	if !money.IsPositive() {
+		return biNew, sdk.ZeroInt(), sdk.ZeroInt(), types.ErrTradeMoneyNotPositive()
+	}
+	return biNew, stock, money, nil
+}
+
+// buyStockWithMoney returns the most stock, in whole units of the stock precision, which costs no more
+// than moneyIn, and the pool after buying it. The cost grows with the stock bought, so the curve is
+// solved by bisection over UpdateStockInPool. ok is false if moneyIn can't buy any stock.
+func (bi *BancorInfo) buyStockWithMoney(moneyIn sdk.Int) (stock sdk.Int, biNew BancorInfo, ok bool) {
+	unit := sdk.OneInt()
+	if bi.StockPrecision != 0 && bi.StockPrecision <= 8 {
+		unit = sdk.NewInt(int64(math.Pow10(int(bi.StockPrecision))))
+	}
+	buy := func(units sdk.Int) (BancorInfo, bool) {
+		b := *bi
+		if !b.UpdateStockInPool(bi.StockInPool.Sub(units.Mul(unit))) {
+			return b, false
+		}
+		return b, b.MoneyInPool.Sub(bi.MoneyInPool).LTE(moneyIn)
+	}
+	// buying low units is affordable, and buying high units is not
+	low, high := sdk.ZeroInt(), bi.StockInPool.Quo(unit)
+	if _, affordable := buy(high); affordable {
+		low = high
+	}
+	for high.Sub(low).GT(sdk.OneInt()) {
+		mid := low.Add(high).QuoRaw(2)
+		if _, affordable := buy(mid); affordable {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	if low.IsZero() {
+		return sdk.ZeroInt(), *bi, false
+	}
+	biNew, _ = buy(low)
+	return low.Mul(unit), biNew, true
+}
+
 func (bi *BancorInfo) IsConsistent() bool {
 	if bi.StockInPool.IsNegative() || bi.StockInPool.GT(bi.MaxSupply) {
 		return false
@@ -130,26 +198,28 @@ type BancorInfoDisplay struct {
 	EarliestCancelTime int64  `json:"earliest_cancel_time"`
 }
 
-func NewBancorInfoDisplay(bi *BancorInfo) BancorInfoDisplay {
-	price := sdk.ZeroDec()
+// CurrentPrice returns the marginal price of the curve at the current supply
+func (bi *BancorInfo) CurrentPrice() sdk.Dec {
+	if !bi.MaxMoney.IsPositive() {
+		return bi.Price
+	}
 	suppliedStock := bi.MaxSupply.Sub(bi.StockInPool)
-	if bi.MaxMoney.IsPositive() {
-		s := suppliedStock.MulRaw(types.SupplyRatioSamples).Quo(bi.MaxSupply).Int64()
-		if s == types.SupplyRatioSamples {
-			price = bi.MaxPrice
-		} else if s == 0 && bi.MoneyInPool.IsZero() {
-			price = bi.InitPrice
-		} else {
-			ratio := types.TableLookup(bi.AR+types.ARSamples, s)
-			ratioNext := types.TableLookup(bi.AR+types.ARSamples, s+1)
-			price = bi.InitPrice.Add(
-				bi.MaxPrice.Sub(bi.InitPrice).MulInt64(types.ARSamples).MulInt64(types.ARSamples).
-					QuoInt64(bi.AR + types.ARSamples).
-					Mul(ratioNext.Sub(ratio)))
-		}
-	} else {
-		price = bi.Price
+	s := suppliedStock.MulRaw(types.SupplyRatioSamples).Quo(bi.MaxSupply).Int64()
+	if s == types.SupplyRatioSamples {
+		return bi.MaxPrice
+	} else if s == 0 && bi.MoneyInPool.IsZero() {
+		return bi.InitPrice
 	}
+	ratio := types.TableLookup(bi.AR+types.ARSamples, s)
+	ratioNext := types.TableLookup(bi.AR+types.ARSamples, s+1)
+	return bi.InitPrice.Add(
+		bi.MaxPrice.Sub(bi.InitPrice).MulInt64(types.ARSamples).MulInt64(types.ARSamples).
+			QuoInt64(bi.AR + types.ARSamples).
+			Mul(ratioNext.Sub(ratio)))
+}
+
+func NewBancorInfoDisplay(bi *BancorInfo) BancorInfoDisplay {
+	price := bi.CurrentPrice()
 	return BancorInfoDisplay{
 		Owner:              bi.Owner.String(),
 		Stock:              bi.Stock,
@@ -177,6 +177,20 @@ func (keeper *Keeper) GetMarketVolume(ctx sdk.Context, stock, money string, stoc
 	return keeper.mk.GetMarketVolume(ctx, stock, money, stockVolume, moneyVolume)
 }
 
+// GetTradeFee returns the commission in CET for trading stockAmount of stock for moneyAmount of money
+func (keeper *Keeper) GetTradeFee(ctx sdk.Context, stock, money string, stockAmount, moneyAmount sdk.Int) sdk.Int {
+	volume := keeper.GetMarketVolume(ctx, stock, money, sdk.NewDecFromInt(stockAmount), sdk.NewDecFromInt(moneyAmount))
+	commission := volume.
+		Mul(sdk.NewDec(keeper.GetParams(ctx).TradeFeeRate)).
+		QuoInt64(10000).TruncateInt64()
+
+	min := keeper.GetMarketFeeMin(ctx)
+	if commission < min {
+		return sdk.NewInt(min)
+	}
+	return sdk.NewInt(commission)
+}
+
 func (keeper *Keeper) IsMarketExist(ctx sdk.Context, symbol string) bool {
 	return keeper.mk.IsMarketExist(ctx, symbol)
 }
@@ -12,6 +12,7 @@ const (
 	QueryBancorInfo = "bancor-info"
 	QueryParameters = "parameters"
 	QueryBancors    = "bancor-list"
+	QueryQuote      = "bancor-quote"
 )
 
 // creates a querier for asset REST endpoints
@@ -24,6 +25,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryBancorInfo(ctx, req, keeper)
 		case QueryBancors:
 			return queryBancorList(ctx, req, keeper)
+		case QueryQuote:
+			return queryQuote(ctx, req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("query symbol : " + path[0])
 		}
@@ -51,6 +54,62 @@ func queryBancorInfo(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]b
 	return bz, nil
 }
 
+type QueryQuoteParam struct {
+	Symbol  string `json:"symbol"`
+	IsBuy   bool   `json:"is_buy"`
+	Amount  int64  `json:"amount"`
+	MoneyIn int64  `json:"money_in"`
+}
+
+// TradeQuote is the result of a hypothetical trade with a bancor pool
+type TradeQuote struct {
+	StockAmount  sdk.Int `json:"stock_amount"`
+	MoneyAmount  sdk.Int `json:"money_amount"`
+	AveragePrice sdk.Dec `json:"average_price"`
+	PriceBefore  sdk.Dec `json:"price_before"`
+	PriceAfter   sdk.Dec `json:"price_after"`
+	// the relative difference between the average price and the price before the trade,
+	// which is zero if the price before is zero
+	PriceImpact sdk.Dec `json:"price_impact"`
+	// the commission in CET
+	Fee sdk.Int `json:"fee"`
+}
+
+func queryQuote(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var param QueryQuoteParam
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, sdk.NewError(types.CodeSpaceBancorlite, types.CodeUnMarshalFailed, "failed to parse param")
+	}
+	if param.MoneyIn != 0 && (param.MoneyIn < 0 || !param.IsBuy) {
+		return nil, types.ErrInvalidMoneyIn()
+	}
+	bi := keeper.Load(ctx, param.Symbol)
+	if bi == nil {
+		return nil, types.ErrNoBancorExists()
+	}
+	biNew, stock, money, err := bi.SimulateTrade(sdk.NewInt(param.Amount), sdk.NewInt(param.MoneyIn), param.IsBuy)
+	if err != nil {
+		return nil, err
+	}
+	quote := TradeQuote{
+		StockAmount:  stock,
+		MoneyAmount:  money,
+		AveragePrice: sdk.NewDecFromInt(money).QuoInt(stock),
+		PriceBefore:  bi.CurrentPrice(),
+		PriceAfter:   biNew.CurrentPrice(),
+		PriceImpact:  sdk.ZeroDec(),
+		Fee:          keeper.GetTradeFee(ctx, bi.Stock, bi.Money, stock, money),
+	}
+	if quote.PriceBefore.IsPositive() {
+		quote.PriceImpact = quote.AveragePrice.Sub(quote.PriceBefore).Abs().Quo(quote.PriceBefore)
+	}
+	bz, e := codec.MarshalJSONIndent(types.ModuleCdc, quote)
+	if e != nil {
+		return nil, types.ErrMarshalFailed()
+	}
+	return bz, nil
+}
+
 func queryBancorList(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
 	infos := k.GetAllBancorInfos(ctx)
 	infoList := make([]BancorInfoDisplay, len(infos))
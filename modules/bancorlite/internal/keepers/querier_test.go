@@ -11,6 +11,7 @@ import (
 
 	"github.com/coinexchain/cet-sdk/modules/bancorlite/internal/keepers"
 	"github.com/coinexchain/cet-sdk/modules/bancorlite/internal/types"
+	"github.com/coinexchain/cet-sdk/modules/market"
 	"github.com/coinexchain/cet-sdk/testapp"
 	"github.com/coinexchain/cet-sdk/testutil"
 )
@@ -62,3 +63,53 @@ func TestQueryBancorInfo(t *testing.T) {
 	require.Equal(t, "foo", bid.Stock)
 	require.Equal(t, "bar", bid.Money)
 }
+
+func TestQueryQuote(t *testing.T) {
+	testApp := testapp.NewTestApp()
+	ctx := testApp.NewCtx()
+	testApp.BancorKeeper.SetParams(ctx, types.DefaultParams())
+	testApp.MarketKeeper.SetParams(ctx, market.DefaultParams())
+
+	_, _, addr := testutil.KeyPubAddr()
+	bi := keepers.BancorInfo{
+		Owner:       addr,
+		Stock:       "foo",
+		Money:       "cet",
+		InitPrice:   sdk.NewDec(1),
+		MaxSupply:   sdk.NewInt(1e10),
+		MaxPrice:    sdk.NewDec(3),
+		MaxMoney:    sdk.ZeroInt(),
+		Price:       sdk.NewDec(1),
+		StockInPool: sdk.NewInt(1e10),
+		MoneyInPool: sdk.ZeroInt(),
+	}
+	testApp.BancorKeeper.Save(ctx, &bi)
+	querier := keepers.NewQuerier(testApp.BancorKeeper)
+	query := func(param keepers.QueryQuoteParam) (quote keepers.TradeQuote, err sdk.Error) {
+		res, err := querier(ctx, []string{keepers.QueryQuote}, abci.RequestQuery{Data: testApp.Cdc.MustMarshalJSON(param)})
+		if err == nil {
+			testApp.Cdc.MustUnmarshalJSON(res, &quote)
+		}
+		return
+	}
+
+	// buying half of the supply moves the price from 1 to 2, at the average price of 1.5
+	quote, err := query(keepers.QueryQuoteParam{Symbol: "foo/cet", IsBuy: true, Amount: 5e9})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(7.5e9), quote.MoneyAmount)
+	require.Equal(t, sdk.NewDec(1), quote.PriceBefore)
+	require.Equal(t, sdk.NewDec(2), quote.PriceAfter)
+	require.Equal(t, sdk.NewDecWithPrec(5, 1), quote.PriceImpact)
+	require.Equal(t, sdk.NewInt(7.5e6), quote.Fee)
+
+	quote, err = query(keepers.QueryQuoteParam{Symbol: "foo/cet", IsBuy: true, MoneyIn: 7.5e9 + 1})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(5e9), quote.StockAmount)
+	require.Equal(t, sdk.NewInt(7.5e9), quote.MoneyAmount)
+
+	// nothing can be sold before anything is bought
+	_, err = query(keepers.QueryQuoteParam{Symbol: "foo/cet", Amount: 100})
+	require.Equal(t, types.CodeStockInPoolOutOfBound, err.Code())
+	_, err = query(keepers.QueryQuoteParam{Symbol: "foo/cet", MoneyIn: 100})
+	require.Equal(t, types.CodeInvalidMoneyIn, err.Code())
+}
@@ -38,6 +38,8 @@ const (
 	CodeAlphaBreakLimit              sdk.CodeType = 1030
 	CodeMaxMoneyTooBig               sdk.CodeType = 1031
 	CodeNegativeMaxMoney             sdk.CodeType = 1032
+	CodeStockCrossLimit              sdk.CodeType = 1033
+	CodeInvalidMoneyIn               sdk.CodeType = 1034
 )
 
 func ErrInvalidSymbol() sdk.Error {
@@ -104,6 +106,14 @@ func ErrMoneyCrossLimit(moneyErr string) sdk.Error {
 	return sdk.NewError(CodeSpaceBancorlite, CodeMoneyCrossLimit, "The money amount in this trade is "+moneyErr+" the limited value.")
 }
 
+func ErrStockCrossLimit() sdk.Error {
+	return sdk.NewError(CodeSpaceBancorlite, CodeStockCrossLimit, "The stock amount in this trade is less than the limited value.")
+}
+
+func ErrInvalidMoneyIn() sdk.Error {
+	return sdk.NewError(CodeSpaceBancorlite, CodeInvalidMoneyIn, "The exact money in can only be positive and used for buying")
+}
+
 func ErrTradeQuantityTooSmall(amount int64) sdk.Error {
 	return sdk.NewError(CodeSpaceBancorlite, CodeTradeQuantityTooSmall, "The trade commission (%d) too small", amount)
 }
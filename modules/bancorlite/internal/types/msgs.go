@@ -44,6 +44,8 @@ type MsgBancorTrade struct {
 	IsBuy  bool  `json:"is_buy"`
 	//money up limit
 	MoneyLimit int64 `json:"money_limit"`
+	//exact money to spend when buying, in which case Amount is the lower limit of the stock bought
+	MoneyIn int64 `json:"money_in"`
 }
 
 func (msg MsgBancorInit) GetSymbol() string {
@@ -222,7 +224,17 @@ func (msg MsgBancorTrade) ValidateBasic() sdk.Error {
 	if !market.IsValidTradingPair([]string{msg.Stock, msg.Money}) {
 		return ErrInvalidSymbol()
 	}
-	if msg.Amount <= 0 {
+	if msg.MoneyIn != 0 {
+		if msg.MoneyIn < 0 || !msg.IsBuy {
+			return ErrInvalidMoneyIn()
+		}
+		if msg.MoneyIn > MaxTradeAmount {
+			return ErrTradeAmountIsTooLarge()
+		}
+		if msg.Amount < 0 {
+			return ErrNonPositiveAmount()
+		}
+	} else if msg.Amount <= 0 {
 		return ErrNonPositiveAmount()
 	}
 	if msg.Amount > MaxTradeAmount {
@@ -151,6 +151,7 @@ func TestMsgBancorTrade_ValidateBasic(t *testing.T) {
 		Amount     int64
 		IsBuy      bool
 		MoneyLimit int64
+		MoneyIn    int64
 	}
 	tests := []struct {
 		name   string
@@ -217,6 +218,42 @@ func TestMsgBancorTrade_ValidateBasic(t *testing.T) {
 			},
 			want: ErrTradeAmountIsTooLarge(),
 		},
+		{
+			name: "positive money in",
+			fields: fields{
+				Sender:  addrUser,
+				Stock:   "abc",
+				Money:   "cet",
+				Amount:  0,
+				IsBuy:   true,
+				MoneyIn: 100,
+			},
+			want: nil,
+		},
+		{
+			name: "negative money in when selling",
+			fields: fields{
+				Sender:  addrUser,
+				Stock:   "abc",
+				Money:   "cet",
+				Amount:  10,
+				IsBuy:   false,
+				MoneyIn: 100,
+			},
+			want: ErrInvalidMoneyIn(),
+		},
+		{
+			name: "negative money in",
+			fields: fields{
+				Sender:  addrUser,
+				Stock:   "abc",
+				Money:   "cet",
+				Amount:  10,
+				IsBuy:   true,
+				MoneyIn: -1,
+			},
+			want: ErrInvalidMoneyIn(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -227,6 +264,7 @@ func TestMsgBancorTrade_ValidateBasic(t *testing.T) {
 				Amount:     tt.fields.Amount,
 				IsBuy:      tt.fields.IsBuy,
 				MoneyLimit: tt.fields.MoneyLimit,
+				MoneyIn:    tt.fields.MoneyIn,
 			}
 			if got := msg.ValidateBasic(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("MsgBancorTrade.ValidateBasic() = %v, want %v", got, tt.want)
@@ -19,11 +19,16 @@ const (
 	SymbolSeparator         = types.SymbolSeparator
 	LimitOrder              = types.LimitOrder
 	GTE                     = types.GTE
+	IOC                     = types.IOC
+	PostOnly                = types.PostOnly
+	FOK                     = types.FOK
 	BID                     = types.BID
 	ASK                     = types.ASK
 	BUY                     = types.BUY
 	SELL                    = types.SELL
 	DecByteCount            = types.DecByteCount
+	StopLimit               = types.StopLimit
+	TakeProfit              = types.TakeProfit
 	QueryMarket             = keepers.QueryMarket
 	QueryMarkets            = keepers.QueryMarkets
 	QueryOrdersInMarket     = keepers.QueryOrdersInMarket
@@ -31,37 +36,79 @@ const (
 	QueryUserOrders         = keepers.QueryUserOrders
 	QueryWaitCancelMarkets  = keepers.QueryWaitCancelMarkets
 	QueryParameters         = keepers.QueryParameters
+	QueryDepth              = keepers.QueryDepth
+	QueryTicker             = keepers.QueryTicker
+	QueryCandles            = keepers.QueryCandles
+	QueryFeeTier            = keepers.QueryFeeTier
+	QueryExportBook         = keepers.QueryExportBook
+	BookSnapshotVersion     = types.BookSnapshotVersion
+	CandleSpanMinute        = types.CandleSpanMinute
+	CandleSpanHour          = types.CandleSpanHour
+	CandleSpanDay           = types.CandleSpanDay
+	CallAuctionMatching     = types.CallAuctionMatching
+	ContinuousMatching      = types.ContinuousMatching
+	SelfTradeAllowed        = types.SelfTradeAllowed
+	SelfTradeCancelNewest   = types.SelfTradeCancelNewest
+	SelfTradeCancelOldest   = types.SelfTradeCancelOldest
+	SelfTradeCancelBoth     = types.SelfTradeCancelBoth
+	SelfTradeDecrement      = types.SelfTradeDecrement
 )
 
 var (
-	NewBaseKeeper       = keepers.NewKeeper
-	DefaultParams       = types.DefaultParams
-	DecToBigEndianBytes = types.DecToBigEndianBytes
-	ValidateOrderID     = types.ValidateOrderID
-	IsValidTradingPair  = types.IsValidTradingPair
-	ModuleCdc           = types.ModuleCdc
-	GetSymbol           = dex.GetSymbol
-	SplitSymbol         = dex.SplitSymbol
-	AssemblyOrderID     = types.AssemblyOrderID
-	RegisterCodec       = types.RegisterCodec
+	NewBaseKeeper        = keepers.NewKeeper
+	DefaultParams        = types.DefaultParams
+	DecToBigEndianBytes  = types.DecToBigEndianBytes
+	ValidateOrderID      = types.ValidateOrderID
+	IsValidTradingPair   = types.IsValidTradingPair
+	ModuleCdc            = types.ModuleCdc
+	GetSymbol            = dex.GetSymbol
+	SplitSymbol          = dex.SplitSymbol
+	AssemblyOrderID      = types.AssemblyOrderID
+	RegisterCodec        = types.RegisterCodec
+	NewQueryDepthParam   = keepers.NewQueryDepthParam
+	NewDepthAggregator   = keepers.NewDepthAggregator
+	NewQueryCandlesParam = keepers.NewQueryCandlesParam
+	NewCandleKeeper      = keepers.NewCandleKeeper
 )
 
 type (
-	Keeper                  = keepers.Keeper
-	Order                   = types.Order
-	MarketInfo              = types.MarketInfo
-	Params                  = types.Params
-	MsgCreateOrder          = types.MsgCreateOrder
-	MsgCreateTradingPair    = types.MsgCreateTradingPair
-	MsgCancelOrder          = types.MsgCancelOrder
-	MsgCancelTradingPair    = types.MsgCancelTradingPair
-	MsgModifyPricePrecision = types.MsgModifyPricePrecision
-	CreateOrderInfo         = types.CreateOrderInfo
-	FillOrderInfo           = types.FillOrderInfo
-	CancelOrderInfo         = types.CancelOrderInfo
-	QueryMarketParam        = keepers.QueryMarketParam
-	QueryOrderParam         = keepers.QueryOrderParam
-	QueryMarketInfo         = keepers.QueryMarketInfo
-	QueryUserOrderList      = keepers.QueryUserOrderList
-	ResOrder                = keepers.ResOrder
+	Keeper                    = keepers.Keeper
+	Order                     = types.Order
+	MarketInfo                = types.MarketInfo
+	Params                    = types.Params
+	MsgCreateOrder            = types.MsgCreateOrder
+	MsgCreateTradingPair      = types.MsgCreateTradingPair
+	MsgCancelOrder            = types.MsgCancelOrder
+	MsgCancelTradingPair      = types.MsgCancelTradingPair
+	MsgModifyPricePrecision   = types.MsgModifyPricePrecision
+	MsgCreateConditionalOrder = types.MsgCreateConditionalOrder
+	MsgCancelConditionalOrder = types.MsgCancelConditionalOrder
+	MsgBatchCreateOrders      = types.MsgBatchCreateOrders
+	MsgCancelOrders           = types.MsgCancelOrders
+	MsgModifyOrder            = types.MsgModifyOrder
+	MsgHaltMarket             = types.MsgHaltMarket
+	MsgResumeMarket           = types.MsgResumeMarket
+	ConditionalOrder          = types.ConditionalOrder
+	CreateOrderInfo           = types.CreateOrderInfo
+	FillOrderInfo             = types.FillOrderInfo
+	CancelOrderInfo           = types.CancelOrderInfo
+	MarketHaltInfo            = types.MarketHaltInfo
+	QueryMarketParam          = keepers.QueryMarketParam
+	QueryCancelMarkets        = keepers.QueryCancelMarkets
+	QueryOrderParam           = keepers.QueryOrderParam
+	QueryMarketInfo           = keepers.QueryMarketInfo
+	QueryUserOrderList        = keepers.QueryUserOrderList
+	ResOrder                  = keepers.ResOrder
+	QueryDepthParam           = keepers.QueryDepthParam
+	QueryDepthResult          = keepers.QueryDepthResult
+	DepthLevel                = keepers.DepthLevel
+	DepthAggregator           = keepers.DepthAggregator
+	QueryCandlesParam         = keepers.QueryCandlesParam
+	Candle                    = types.Candle
+	Ticker                    = types.Ticker
+	FeeTier                   = types.FeeTier
+	AccountVolume             = types.AccountVolume
+	QueryFeeTierParam         = keepers.QueryFeeTierParam
+	QueryFeeTierResult        = keepers.QueryFeeTierResult
+	BookSnapshot              = types.BookSnapshot
 )
@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -16,6 +17,14 @@ import (
 	"github.com/coinexchain/cosmos-utils/client/cliutil"
 )
 
+const (
+	FlagDepthPrecision = "precision"
+	FlagDepthCount     = "count"
+	FlagCandleSpan     = "span"
+	FlagCandleSince    = "since"
+	FlagCandleCount    = "count"
+)
+
 // GetQueryCmd returns the cli query commands for this module
 func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 	// Group asset queries under a subcommand
@@ -28,8 +37,13 @@ func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 		QueryMarketCmd(cdc),
 		QueryMarketListCmd(cdc),
 		QueryOrderbookCmd(cdc),
+		QueryDepthCmd(cdc),
+		QueryTickerCmd(cdc),
+		QueryCandlesCmd(cdc),
 		QueryOrderCmd(cdc),
-		QueryUserOrderList(cdc))...)
+		QueryUserOrderList(cdc),
+		QueryFeeTierCmd(cdc),
+		QueryExportBookCmd(cdc))...)
 	return mktQueryCmd
 }
 
@@ -101,6 +115,83 @@ Example :
 	}
 }
 
+func QueryDepthCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "depth [pair]",
+		Short: "query the aggregated price levels of the orders in a market",
+		Long: `query the aggregated price levels of the orders in a market.
+The bid prices are rounded down and the ask prices are rounded up to the precision,
+which can not be larger than the price precision of the market.
+
+Example : 
+	cetcli query market depth eth/cet \
+	--precision=2 --count=20 --trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(strings.Split(args[0], types.SymbolSeparator)) != 2 {
+				return errors.Errorf("trading-pair illegal : %s, For example : eth/cet.", args[0])
+			}
+			query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryDepth)
+			param := keepers.NewQueryDepthParam(args[0], viper.GetInt(FlagDepthPrecision), viper.GetInt(FlagDepthCount))
+			return cliutil.CliQuery(cdc, query, param)
+		},
+	}
+	cmd.Flags().Int(FlagDepthPrecision, -1, "The price precision of the levels, default to the price precision of the market")
+	cmd.Flags().Int(FlagDepthCount, keepers.DefaultDepthCount, "The max number of the levels on each side")
+	return cmd
+}
+
+func QueryTickerCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ticker [pair]",
+		Short: "query the 24-hour ticker of a market",
+		Long: `query the 24-hour ticker of a market.
+
+Example : 
+	cetcli query market ticker eth/cet \
+	--trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(strings.Split(args[0], types.SymbolSeparator)) != 2 {
+				return errors.Errorf("trading-pair illegal : %s, For example : eth/cet.", args[0])
+			}
+			query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryTicker)
+			return cliutil.CliQuery(cdc, query, keepers.NewQueryMarketParam(args[0]))
+		},
+	}
+}
+
+func QueryCandlesCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "candles [pair]",
+		Short: "query the OHLCV candles of a market",
+		Long: `query the OHLCV candles of a market, in ascending order of time.
+The span of the candles can be 1m, 1h or 1d, and the candles start from
+the one containing the unix time given by --since.
+
+Example : 
+	cetcli query market candles eth/cet \
+	--span=1h --since=1577836800 --count=24 --trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(strings.Split(args[0], types.SymbolSeparator)) != 2 {
+				return errors.Errorf("trading-pair illegal : %s, For example : eth/cet.", args[0])
+			}
+			span, ok := types.CandleSpanNames[viper.GetString(FlagCandleSpan)]
+			if !ok {
+				return errors.Errorf("span illegal : %s, should be one of 1m, 1h and 1d.", viper.GetString(FlagCandleSpan))
+			}
+			query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryCandles)
+			param := keepers.NewQueryCandlesParam(args[0], span, viper.GetInt64(FlagCandleSince), viper.GetInt(FlagCandleCount))
+			return cliutil.CliQuery(cdc, query, param)
+		},
+	}
+	cmd.Flags().String(FlagCandleSpan, "1m", "The span of the candles, 1m, 1h or 1d")
+	cmd.Flags().Int64(FlagCandleSince, 0, "The unix time from which the candles start")
+	cmd.Flags().Int(FlagCandleCount, keepers.DefaultCandleCount, "The max number of the candles")
+	return cmd
+}
+
 func QueryOrderCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "order-info [orderID]",
@@ -145,3 +236,50 @@ Example:
 
 	return cmd
 }
+
+func QueryFeeTierCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fee-tier [userAddress]",
+		Short: "Query the rolling trading volume and the fee tier of an account",
+		Long: `Query the trading volume of an account in the latest 30 days, measured in sato CET,
+and the fee tier and the discount on commission it reaches.
+
+Example:
+	cetcli query market fee-tier [userAddress] \
+	--trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			route := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryFeeTier)
+			return cliutil.CliQuery(cdc, route, keepers.QueryFeeTierParam{Address: addr})
+		},
+	}
+
+	return cmd
+}
+
+func QueryExportBookCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export-book [pair]",
+		Short: "Export the order book of a trading pair",
+		Long: `Export the orders, the market info with the last executed price and the pending delist
+request of a trading pair at the latest height, in a versioned JSON format. The output can be
+put into the "order_books" of the market genesis of a test network to replay the order book,
+and the coins frozen by the orders must also be frozen in the genesis accounts.
+
+Example:
+	cetcli query market export-book eth/cet \
+	--trust-node=true --chain-id=coinexdex`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(strings.Split(args[0], types.SymbolSeparator)) != 2 {
+				return errors.Errorf("trading-pair illegal : %s, For example : eth/cet.", args[0])
+			}
+			query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryExportBook)
+			return cliutil.CliQuery(cdc, query, keepers.NewQueryMarketParam(args[0]))
+		},
+	}
+}
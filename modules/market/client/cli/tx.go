@@ -20,9 +20,18 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		CreateMarketCmd(cdc),
 		CreateGTEOrderTxCmd(cdc),
 		CreateIOCOrderTxCmd(cdc),
+		CreatePostOnlyOrderTxCmd(cdc),
+		CreateFOKOrderTxCmd(cdc),
 		CancelOrder(cdc),
+		BatchCreateOrdersTxCmd(cdc),
+		CancelOrdersTxCmd(cdc),
+		ModifyOrderTxCmd(cdc),
 		CancelMarket(cdc),
 		ModifyTradingPairPricePrecision(cdc),
+		HaltMarketCmd(cdc),
+		ResumeMarketCmd(cdc),
+		CreateConditionalOrderTxCmd(cdc),
+		CancelConditionalOrder(cdc),
 	)...)
 
 	return mktTxCmd
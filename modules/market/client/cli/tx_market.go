@@ -18,6 +18,7 @@ const (
 	FlagMoney          = "money"
 	FlagPricePrecision = "price-precision"
 	FlagOrderPrecision = "order-precision"
+	FlagMatchingMode   = "matching-mode"
 )
 
 var createMarketFlags = []string{
@@ -36,7 +37,7 @@ Example :
 	cetcli tx market create-trading-pair  \
 	--from bob --chain-id=coinexdex  \
 	--stock=eth --money=cet --order-precision=8 \
-	--price-precision=8 --gas 20000 --fees=1000cet`,
+	--price-precision=8 --matching-mode=0 --gas 20000 --fees=1000cet`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			msg, err := getCreateMarketMsg()
 			if err != nil {
@@ -52,6 +53,8 @@ Example :
 		" control the price accuracy of the order when token trades")
 	cmd.Flags().Int(FlagOrderPrecision, 0, "To control the granularity of token trade, "+
 		"the token amount of trade must be a multiple of granularity.")
+	cmd.Flags().Int(FlagMatchingMode, int(types.CallAuctionMatching), "The matching mode of the trading-pair, "+
+		"0 for periodic call auction, 1 for continuous matching at the maker price")
 	for _, flag := range createMarketFlags {
 		cmd.MarkFlagRequired(flag)
 	}
@@ -80,6 +83,7 @@ func parseCreateMarketFlags() (*types.MsgCreateTradingPair, error) {
 		Money:          viper.GetString(FlagMoney),
 		PricePrecision: byte(viper.GetInt(FlagPricePrecision)),
 		OrderPrecision: byte(viper.GetInt(FlagOrderPrecision)),
+		MatchingMode:   byte(viper.GetInt(FlagMatchingMode)),
 	}
 	return msg, nil
 }
@@ -152,3 +156,49 @@ func getModifyTradingPairPricePrecisionMsg(cdc *codec.Codec) (*types.MsgModifyPr
 	}
 	return &msg, nil
 }
+
+func HaltMarketCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "halt-market",
+		Short: "Halt the trading of a trading pair",
+		Long: `Halt the trading of a trading pair. Only the stock's issuer can halt it.
+New orders are rejected and no orders are matched until the trading pair is resumed.
+
+Example: 
+	cetcli tx market halt-market --trading-pair=etc/cet \
+	--from=bob --chain-id=coinexdex --gas=10000000 --fees=10000cet`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := types.MsgHaltMarket{
+				TradingPair: viper.GetString(FlagSymbol),
+			}
+			return cliutil.CliRunCommand(cdc, &msg)
+		},
+	}
+
+	cmd.Flags().String(FlagSymbol, "btc/cet", "The market trading-pair")
+	cmd.MarkFlagRequired(FlagSymbol)
+	return cmd
+}
+
+func ResumeMarketCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume-market",
+		Short: "Resume the trading of a halted trading pair",
+		Long: `Resume the trading of a trading pair, which was halted by the stock's issuer or
+by the circuit breaker. Only the stock's issuer can resume it.
+
+Example: 
+	cetcli tx market resume-market --trading-pair=etc/cet \
+	--from=bob --chain-id=coinexdex --gas=10000000 --fees=10000cet`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := types.MsgResumeMarket{
+				TradingPair: viper.GetString(FlagSymbol),
+			}
+			return cliutil.CliRunCommand(cdc, &msg)
+		},
+	}
+
+	cmd.Flags().String(FlagSymbol, "btc/cet", "The market trading-pair")
+	cmd.MarkFlagRequired(FlagSymbol)
+	return cmd
+}
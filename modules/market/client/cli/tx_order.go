@@ -2,6 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -23,6 +25,12 @@ const (
 	FlagBlocks    = "blocks"
 	FlagTime      = "time"
 	FlagIdentify  = "identify"
+
+	FlagSelfTradePrevention = "self-trade-prevention"
+
+	FlagConditionType = "condition-type"
+	FlagTriggerPrice  = "trigger-price"
+	FlagOrderIDs      = "order-ids"
 )
 
 var createOrderFlags = []string{
@@ -47,7 +55,7 @@ Example:
 	--side=1 --price-precision=10 --from=bob --identify=1 \
 	--chain-id=coinexdex --gas=10000 --fees=1000cet`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return createAndBroadCastOrder(cdc, false)
+			return createAndBroadCastOrder(cdc, types.IOC, "create-ioc-order")
 		},
 	}
 	markCreateOrderFlags(cmd)
@@ -66,7 +74,7 @@ Example:
 	--price-precision=10 --blocks=100000 --from=bob --identify=1 \
 	--chain-id=coinexdex --gas=10000 --fees=1000cet`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return createAndBroadCastOrder(cdc, true)
+			return createAndBroadCastOrder(cdc, types.GTE, "create-gte-order")
 		},
 	}
 	markCreateOrderFlags(cmd)
@@ -74,20 +82,58 @@ Example:
 	return cmd
 }
 
-func createAndBroadCastOrder(cdc *codec.Codec, isGTE bool) error {
-	msg, err := parseCreateOrderFlags(isGTE)
+func CreatePostOnlyOrderTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-post-only-order",
+		Short: "Create a post-only order and sign tx",
+		Long: `Create a post-only order and sign tx, broadcast to nodes.
+A post-only order stays in the order book like a GTE order, but it is canceled
+instead of being matched if it would take liquidity in the block it was created.
+
+Example:
+	cetcli tx market create-post-only-order --trading-pair=btc/cet \
+	--order-type=2 --price=520 --quantity=10000000 --side=1 \
+	--price-precision=10 --blocks=100000 --from=bob --identify=1 \
+	--chain-id=coinexdex --gas=10000 --fees=1000cet`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return createAndBroadCastOrder(cdc, types.PostOnly, "create-post-only-order")
+		},
+	}
+	markCreateOrderFlags(cmd)
+	cmd.Flags().Int(FlagBlocks, 10000, "the post-only order will exist at least blocks in blockChain")
+	return cmd
+}
+
+func CreateFOKOrderTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-fok-order",
+		Short: "Create a FOK order and sign tx",
+		Long: `Create a fill-or-kill order and sign tx, broadcast to nodes.
+A FOK order is canceled without any deal unless it can be fully filled in the block it was created.
+
+Example:
+	cetcli tx market create-fok-order --trading-pair=btc/cet \
+	--order-type=2 --price=520 --quantity=10000000 \
+	--side=1 --price-precision=10 --from=bob --identify=1 \
+	--chain-id=coinexdex --gas=10000 --fees=1000cet`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return createAndBroadCastOrder(cdc, types.FOK, "create-fok-order")
+		},
+	}
+	markCreateOrderFlags(cmd)
+	return cmd
+}
+
+func createAndBroadCastOrder(cdc *codec.Codec, timeInForce int64, cmdName string) error {
+	msg, err := parseCreateOrderFlags(timeInForce)
 	if err != nil {
-		if isGTE {
-			return errors.Errorf("errors : %s, please see help : "+
-				"$ cetcli tx market create-gte-order -h", err.Error())
-		}
 		return errors.Errorf("errors : %s, please see help : "+
-			"$ cetcli tx market create-ioc-order -h", err.Error())
+			"$ cetcli tx market %s -h", err.Error(), cmdName)
 	}
 	return cliutil.CliRunCommand(cdc, msg)
 }
 
-func parseCreateOrderFlags(isGTE bool) (*types.MsgCreateOrder, error) {
+func parseCreateOrderFlags(timeInForce int64) (*types.MsgCreateOrder, error) {
 	for _, flag := range createOrderFlags {
 		if viper.Get(flag) == nil {
 			return nil, fmt.Errorf("--%s flag is a noop" + flag)
@@ -102,10 +148,9 @@ func parseCreateOrderFlags(isGTE bool) (*types.MsgCreateOrder, error) {
 		PricePrecision: byte(viper.GetInt(FlagPricePrecision)),
 		Quantity:       viper.GetInt64(FlagQuantity),
 		ExistBlocks:    viper.GetInt64(FlagBlocks),
-		TimeInForce:    types.IOC,
-	}
-	if isGTE {
-		msg.TimeInForce = types.GTE
+		TimeInForce:    timeInForce,
+
+		SelfTradePrevention: byte(viper.GetInt(FlagSelfTradePrevention)),
 	}
 	return msg, nil
 }
@@ -120,6 +165,8 @@ func markCreateOrderFlags(cmd *cobra.Command) {
 	cmd.Flags().Int(FlagIdentify, 0, "A transaction can contain multiple order "+
 		"creation messages, the identify field was added to the order creation message to give each "+
 		"order a unique ID. So the order ID consists of user address, user sequence, identify.")
+	cmd.Flags().Int(FlagSelfTradePrevention, int(types.SelfTradeAllowed), "What happens when the order would trade "+
+		"with another order of yours.(allowed : 0; cancel-newest : 1; cancel-oldest : 2; cancel-both : 3; decrement : 4)")
 
 	for _, flag := range createOrderFlags {
 		cmd.MarkFlagRequired(flag)
@@ -146,7 +193,168 @@ Examples:
 	return cmd
 }
 
+func BatchCreateOrdersTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-create-orders [orders-file]",
+		Short: "Create several orders in one message and sign tx",
+		Long: `Create several orders in one message and sign tx, broadcast to nodes.
+The file contains a JSON list of orders with the same fields as in create-gte-order. The orders
+share the sequence of the transaction, so each of them must have a different identify.
+If any order fails, none of them is created.
+
+Example:
+	cetcli tx market batch-create-orders orders.json \
+	--from=bob --chain-id=coinexdex --gas=100000 --fees=10000cet
+
+orders.json:
+	[{"trading_pair":"btc/cet","order_type":2,"price_precision":10,"price":"520",
+	  "quantity":"10000000","side":1,"time_in_force":"3","exist_blocks":"100000","identify":1},
+	 {"trading_pair":"btc/cet","order_type":2,"price_precision":10,"price":"530",
+	  "quantity":"10000000","side":2,"time_in_force":"5","exist_blocks":"100000","identify":2}]`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			msg := &types.MsgBatchCreateOrders{}
+			if err := cdc.UnmarshalJSON(bz, &msg.Orders); err != nil {
+				return errors.Errorf("errors : %s, please see help : "+
+					"$ cetcli tx market batch-create-orders -h", err.Error())
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+	return cmd
+}
+
+func CancelOrdersTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-orders",
+		Short: "cancel several orders in blockchain",
+		Long: `cancel the listed orders, and all the orders in a trading pair if it is specified.
+If any listed order can not be canceled, none of them is canceled.
+
+Examples:
+	cetcli tx market cancel-orders --order-ids=[id1],[id2] \
+	--trust-node=true --from=bob --chain-id=coinexdex
+
+	cetcli tx market cancel-orders --trading-pair=btc/cet \
+	--trust-node=true --from=bob --chain-id=coinexdex`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := &types.MsgCancelOrders{
+				TradingPair: viper.GetString(FlagSymbol),
+			}
+			if ids := viper.GetString(FlagOrderIDs); len(ids) != 0 {
+				msg.OrderIDs = strings.Split(ids, ",")
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+	cmd.Flags().String(FlagOrderIDs, "", "The comma-separated order ids")
+	cmd.Flags().String(FlagSymbol, "", "Cancel all the orders in this trading pair")
+	return cmd
+}
+
+func ModifyOrderTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "modify-order",
+		Short: "modify the price or reduce the quantity of an order",
+		Long: `modify the price or reduce the left quantity of an open order.
+An order keeps its time priority if only its quantity is reduced, and it is
+re-queued as a new order of the current block if its price is changed.
+
+Examples:
+	cetcli tx market modify-order --order-id=[id] --price=530 --price-precision=10 \
+	--trust-node=true --from=bob --chain-id=coinexdex
+
+	cetcli tx market modify-order --order-id=[id] --quantity=5000000 \
+	--trust-node=true --from=bob --chain-id=coinexdex`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := &types.MsgModifyOrder{
+				OrderID:        viper.GetString(FlagOrderID),
+				PricePrecision: byte(viper.GetInt(FlagPricePrecision)),
+				Price:          viper.GetInt64(FlagPrice),
+				Quantity:       viper.GetInt64(FlagQuantity),
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+	markQueryOrDelCmd(cmd)
+	cmd.Flags().Int64(FlagPrice, 0, "The new price of the order, 0 to keep the current price")
+	cmd.Flags().Int(FlagPricePrecision, 8, "The precision of the new price")
+	cmd.Flags().Int64(FlagQuantity, 0, "The new left quantity of the order, 0 to keep the current quantity")
+	return cmd
+}
+
 func markQueryOrDelCmd(cmd *cobra.Command) {
 	cmd.Flags().String(FlagOrderID, "", "The order id")
 	cmd.MarkFlagRequired(FlagOrderID)
 }
+
+func CreateConditionalOrderTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-conditional-order",
+		Short: "Create a conditional GTE order and sign tx",
+		Long: `Create a conditional GTE order and sign tx, broadcast to nodes.
+The order stays dormant until the last executed price of the trading pair crosses the trigger price,
+and then it is converted into a GTE order. The coins are frozen only when it is triggered.
+
+Example:
+	cetcli tx market create-conditional-order --trading-pair=btc/cet \
+	--order-type=2 --price=520 --quantity=10000000 --side=2 \
+	--price-precision=10 --blocks=100000 --from=bob --identify=1 \
+	--condition-type=1 --trigger-price=530 \
+	--chain-id=coinexdex --gas=10000 --fees=1000cet`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := parseCreateOrderFlags(types.GTE)
+			if err != nil {
+				return errors.Errorf("errors : %s, please see help : "+
+					"$ cetcli tx market create-conditional-order -h", err.Error())
+			}
+			conditionalMsg := &types.MsgCreateConditionalOrder{
+				Identify:       msg.Identify,
+				TradingPair:    msg.TradingPair,
+				OrderType:      msg.OrderType,
+				PricePrecision: msg.PricePrecision,
+				Price:          msg.Price,
+				Quantity:       msg.Quantity,
+				Side:           msg.Side,
+				TimeInForce:    msg.TimeInForce,
+				ExistBlocks:    msg.ExistBlocks,
+				ConditionType:  byte(viper.GetInt(FlagConditionType)),
+				TriggerPrice:   viper.GetInt64(FlagTriggerPrice),
+
+				SelfTradePrevention: msg.SelfTradePrevention,
+			}
+			return cliutil.CliRunCommand(cdc, conditionalMsg)
+		},
+	}
+	markCreateOrderFlags(cmd)
+	cmd.Flags().Int(FlagBlocks, 10000, "the gte order will exist at least blocks in blockChain after it is triggered")
+	cmd.Flags().Int(FlagConditionType, 1, "The condition to trigger the order.(stop-limit : 1; take-profit : 2)")
+	cmd.Flags().Int64(FlagTriggerPrice, 0, "The trigger price, which uses the same precision as the price")
+	cmd.MarkFlagRequired(FlagConditionType)
+	cmd.MarkFlagRequired(FlagTriggerPrice)
+	return cmd
+}
+
+func CancelConditionalOrder(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-conditional-order",
+		Short: "cancel a conditional order which is not triggered yet",
+		Long: `cancel a conditional order which is not triggered yet.
+
+Examples:
+	cetcli tx market cancel-conditional-order --order-id=[id] \
+	--trust-node=true --from=bob --chain-id=coinexdex`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg := &types.MsgCancelConditionalOrder{
+				OrderID: viper.GetString(FlagOrderID),
+			}
+			return cliutil.CliRunCommand(cdc, msg)
+		},
+	}
+	markQueryOrDelCmd(cmd)
+	return cmd
+}
@@ -3,6 +3,7 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -42,6 +43,82 @@ func queryOrdersInMarketHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) h
 	}
 }
 
+func queryDepthHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryDepth)
+		if !types.IsValidTradingPair([]string{vars["stock"], vars["money"]}) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid Trading pair")
+			return
+		}
+		precision, err := parseIntQueryArg(r, "precision", -1)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid precision")
+			return
+		}
+		count, err := parseIntQueryArg(r, "count", keepers.DefaultDepthCount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid count")
+			return
+		}
+		param := keepers.NewQueryDepthParam(dex.GetSymbol(vars["stock"], vars["money"]), precision, count)
+		restutil.RestQuery(cdc, cliCtx, w, r, query, param, nil)
+	}
+}
+
+func queryTickerHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryTicker)
+		if !types.IsValidTradingPair([]string{vars["stock"], vars["money"]}) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid Trading pair")
+			return
+		}
+		param := keepers.NewQueryMarketParam(dex.GetSymbol(vars["stock"], vars["money"]))
+		restutil.RestQuery(cdc, cliCtx, w, r, query, param, nil)
+	}
+}
+
+func queryCandlesHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryCandles)
+		if !types.IsValidTradingPair([]string{vars["stock"], vars["money"]}) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid Trading pair")
+			return
+		}
+		spanName := r.URL.Query().Get("span")
+		if len(spanName) == 0 {
+			spanName = "1m"
+		}
+		span, ok := types.CandleSpanNames[spanName]
+		if !ok {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid span")
+			return
+		}
+		since, err := parseIntQueryArg(r, "since", 0)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid since")
+			return
+		}
+		count, err := parseIntQueryArg(r, "count", keepers.DefaultCandleCount)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid count")
+			return
+		}
+		param := keepers.NewQueryCandlesParam(dex.GetSymbol(vars["stock"], vars["money"]), span, int64(since), count)
+		restutil.RestQuery(cdc, cliCtx, w, r, query, param, nil)
+	}
+}
+
+func parseIntQueryArg(r *http.Request, name string, defaultVal int) (int, error) {
+	str := r.URL.Query().Get(name)
+	if len(str) == 0 {
+		return defaultVal, nil
+	}
+	return strconv.Atoi(str)
+}
+
 func queryMarketsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryMarkets)
@@ -81,3 +158,30 @@ func queryParamsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 		restutil.RestQuery(nil, cliCtx, w, r, route, nil, nil)
 	}
 }
+
+func queryFeeTierHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		addr, err := sdk.AccAddressFromBech32(vars["address"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		param := keepers.QueryFeeTierParam{Address: addr}
+		route := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryFeeTier)
+		restutil.RestQuery(cdc, cliCtx, w, r, route, param, nil)
+	}
+}
+
+func queryExportBookHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryExportBook)
+		if !types.IsValidTradingPair([]string{vars["stock"], vars["money"]}) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid Trading pair")
+			return
+		}
+		param := keepers.NewQueryMarketParam(dex.GetSymbol(vars["stock"], vars["money"]))
+		restutil.RestQuery(cdc, cliCtx, w, r, query, param, nil)
+	}
+}
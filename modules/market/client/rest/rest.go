@@ -15,9 +15,14 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
 	r.HandleFunc("/market/trading-pairs/{stock}/{money}", queryMarketHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc("/market/orderbook/{stock}/{money}", queryOrdersInMarketHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/market/depth/{stock}/{money}", queryDepthHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/market/ticker/{stock}/{money}", queryTickerHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/market/candles/{stock}/{money}", queryCandlesHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc("/market/exist-trading-pairs", queryMarketsHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc("/market/orders/{order-id}", queryOrderInfoHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc("/market/orders/account/{address}", queryUserOrderListHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/market/fee-tier/{address}", queryFeeTierHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/market/export-book/{stock}/{money}", queryExportBookHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc("/market/parameters", queryParamsHandlerFn(cliCtx)).Methods("GET")
 }
 
@@ -25,7 +30,16 @@ func registerTXRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec
 	r.HandleFunc("/market/gte-orders", createGTEOrderHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc("/market/trading-pairs", createMarketHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc("/market/ioc-orders", createIOCOrderHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/post-only-orders", createPostOnlyOrderHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/fok-orders", createFOKOrderHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc("/market/cancel-order", cancelOrderHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/batch-orders", batchCreateOrdersHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/cancel-orders", cancelOrdersHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/modify-order", modifyOrderHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc("/market/cancel-trading-pair", cancelMarketHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc("/market/price-precision", modifyTradingPairPricePrecision(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/halt-market", haltMarketHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/resume-market", resumeMarketHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/conditional-orders", createConditionalOrderHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/market/cancel-conditional-order", cancelConditionalOrderHandlerFn(cdc, cliCtx)).Methods("POST")
 }
@@ -19,6 +19,7 @@ type createMarketReq struct {
 	Money          string       `json:"money"`
 	PricePrecision int          `json:"price_precision"`
 	OrderPrecision int          `json:"order_precision,omitempty"`
+	MatchingMode   int          `json:"matching_mode,omitempty"`
 }
 
 func (req *createMarketReq) New() restutil.RestReq {
@@ -29,6 +30,7 @@ func (req *createMarketReq) GetBaseReq() *rest.BaseReq {
 }
 func (req *createMarketReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
 	msg := types.NewMsgCreateTradingPair(req.Stock, req.Money, sender, byte(req.PricePrecision), byte(req.OrderPrecision))
+	msg.MatchingMode = byte(req.MatchingMode)
 	return msg, nil
 }
 
@@ -74,6 +76,47 @@ func (req *modifyPricePrecision) GetMsg(r *http.Request, sender sdk.AccAddress)
 	return msg, nil
 }
 
+type marketOperationReq struct {
+	BaseReq     rest.BaseReq `json:"base_req"`
+	TradingPair string       `json:"trading_pair"`
+}
+
+type haltMarketReq struct {
+	marketOperationReq
+}
+
+func (req *haltMarketReq) New() restutil.RestReq {
+	return new(haltMarketReq)
+}
+func (req *haltMarketReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+func (req *haltMarketReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := types.MsgHaltMarket{
+		Sender:      sender,
+		TradingPair: req.TradingPair,
+	}
+	return msg, nil
+}
+
+type resumeMarketReq struct {
+	marketOperationReq
+}
+
+func (req *resumeMarketReq) New() restutil.RestReq {
+	return new(resumeMarketReq)
+}
+func (req *resumeMarketReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+func (req *resumeMarketReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := types.MsgResumeMarket{
+		Sender:      sender,
+		TradingPair: req.TradingPair,
+	}
+	return msg, nil
+}
+
 func createMarketHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	var req createMarketReq
 	return restutil.NewRestHandler(cdc, cliCtx, &req)
@@ -88,3 +131,13 @@ func modifyTradingPairPricePrecision(cdc *codec.Codec, cliCtx context.CLIContext
 	var req modifyPricePrecision
 	return restutil.NewRestHandler(cdc, cliCtx, &req)
 }
+
+func haltMarketHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req haltMarketReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+
+func resumeMarketHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req resumeMarketReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
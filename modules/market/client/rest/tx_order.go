@@ -24,6 +24,8 @@ type createOrderReq struct {
 	Side           int          `json:"side"`
 	ExistBlocks    int          `json:"exist_blocks"`
 	TimeInForce    int          `json:"time_in_force"`
+
+	SelfTradePrevention int `json:"self_trade_prevention,omitempty"`
 }
 
 func (req *createOrderReq) New() restutil.RestReq {
@@ -44,9 +46,16 @@ func (req *createOrderReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.M
 		Side:           byte(req.Side),
 		TimeInForce:    types.IOC,
 		ExistBlocks:    int64(req.ExistBlocks),
+
+		SelfTradePrevention: byte(req.SelfTradePrevention),
 	}
-	if r.URL.Path == "/market/gte-orders" {
+	switch r.URL.Path {
+	case "/market/gte-orders":
 		msg.TimeInForce = types.GTE
+	case "/market/post-only-orders":
+		msg.TimeInForce = types.PostOnly
+	case "/market/fok-orders":
+		msg.TimeInForce = types.FOK
 	}
 	return msg, nil
 }
@@ -78,6 +87,14 @@ func createIOCOrderHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.H
 	return createOrderAndBroadCast(cdc, cliCtx)
 }
 
+func createPostOnlyOrderHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return createOrderAndBroadCast(cdc, cliCtx)
+}
+
+func createFOKOrderHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return createOrderAndBroadCast(cdc, cliCtx)
+}
+
 func cancelOrderHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	var req cancelOrderReq
 	return restutil.NewRestHandler(cdc, cliCtx, &req)
@@ -87,3 +104,184 @@ func createOrderAndBroadCast(cdc *codec.Codec, cliCtx context.CLIContext) http.H
 	var req createOrderReq
 	return restutil.NewRestHandler(cdc, cliCtx, &req)
 }
+
+type createConditionalOrderReq struct {
+	BaseReq        rest.BaseReq `json:"base_req"`
+	OrderType      int          `json:"order_type"`
+	TradingPair    string       `json:"trading_pair"`
+	Identify       int          `json:"identify"`
+	PricePrecision int          `json:"price_precision"`
+	Price          int64        `json:"price"`
+	Quantity       int64        `json:"quantity"`
+	Side           int          `json:"side"`
+	ExistBlocks    int          `json:"exist_blocks"`
+	ConditionType  int          `json:"condition_type"`
+	TriggerPrice   int64        `json:"trigger_price"`
+
+	SelfTradePrevention int `json:"self_trade_prevention,omitempty"`
+}
+
+func (req *createConditionalOrderReq) New() restutil.RestReq {
+	return new(createConditionalOrderReq)
+}
+func (req *createConditionalOrderReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+func (req *createConditionalOrderReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := types.MsgCreateConditionalOrder{
+		Sender:         sender,
+		TradingPair:    req.TradingPair,
+		Identify:       byte(req.Identify),
+		OrderType:      byte(req.OrderType),
+		PricePrecision: byte(req.PricePrecision),
+		Price:          req.Price,
+		Quantity:       req.Quantity,
+		Side:           byte(req.Side),
+		TimeInForce:    types.GTE,
+		ExistBlocks:    int64(req.ExistBlocks),
+		ConditionType:  byte(req.ConditionType),
+		TriggerPrice:   req.TriggerPrice,
+
+		SelfTradePrevention: byte(req.SelfTradePrevention),
+	}
+	return msg, nil
+}
+
+type cancelConditionalOrderReq struct {
+	BaseReq rest.BaseReq `json:"base_req"`
+	OrderID string       `json:"order_id"`
+}
+
+func (req *cancelConditionalOrderReq) New() restutil.RestReq {
+	return new(cancelConditionalOrderReq)
+}
+func (req *cancelConditionalOrderReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+func (req *cancelConditionalOrderReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgCancelConditionalOrder{
+		OrderID: req.OrderID,
+		Sender:  sender,
+	}
+	return msg, nil
+}
+
+func createConditionalOrderHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req createConditionalOrderReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+
+func cancelConditionalOrderHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req cancelConditionalOrderReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+
+type batchOrderItem struct {
+	OrderType      int    `json:"order_type"`
+	TradingPair    string `json:"trading_pair"`
+	Identify       int    `json:"identify"`
+	PricePrecision int    `json:"price_precision"`
+	Price          int64  `json:"price"`
+	Quantity       int64  `json:"quantity"`
+	Side           int    `json:"side"`
+	ExistBlocks    int    `json:"exist_blocks"`
+	TimeInForce    int    `json:"time_in_force"`
+
+	SelfTradePrevention int `json:"self_trade_prevention,omitempty"`
+}
+
+type batchCreateOrdersReq struct {
+	BaseReq rest.BaseReq     `json:"base_req"`
+	Orders  []batchOrderItem `json:"orders"`
+}
+
+func (req *batchCreateOrdersReq) New() restutil.RestReq {
+	return new(batchCreateOrdersReq)
+}
+func (req *batchCreateOrdersReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+func (req *batchCreateOrdersReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgBatchCreateOrders{
+		Sender: sender,
+		Orders: make([]types.MsgCreateOrder, len(req.Orders)),
+	}
+	for i, item := range req.Orders {
+		msg.Orders[i] = types.MsgCreateOrder{
+			Sender:         sender,
+			TradingPair:    item.TradingPair,
+			Identify:       byte(item.Identify),
+			OrderType:      byte(item.OrderType),
+			PricePrecision: byte(item.PricePrecision),
+			Price:          item.Price,
+			Quantity:       item.Quantity,
+			Side:           byte(item.Side),
+			TimeInForce:    int64(item.TimeInForce),
+			ExistBlocks:    int64(item.ExistBlocks),
+
+			SelfTradePrevention: byte(item.SelfTradePrevention),
+		}
+	}
+	return msg, nil
+}
+
+type cancelOrdersReq struct {
+	BaseReq     rest.BaseReq `json:"base_req"`
+	OrderIDs    []string     `json:"order_ids"`
+	TradingPair string       `json:"trading_pair"`
+}
+
+func (req *cancelOrdersReq) New() restutil.RestReq {
+	return new(cancelOrdersReq)
+}
+func (req *cancelOrdersReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+func (req *cancelOrdersReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgCancelOrders{
+		Sender:      sender,
+		OrderIDs:    req.OrderIDs,
+		TradingPair: req.TradingPair,
+	}
+	return msg, nil
+}
+
+type modifyOrderReq struct {
+	BaseReq        rest.BaseReq `json:"base_req"`
+	OrderID        string       `json:"order_id"`
+	PricePrecision int          `json:"price_precision"`
+	Price          int64        `json:"price"`
+	Quantity       int64        `json:"quantity"`
+}
+
+func (req *modifyOrderReq) New() restutil.RestReq {
+	return new(modifyOrderReq)
+}
+func (req *modifyOrderReq) GetBaseReq() *rest.BaseReq {
+	return &req.BaseReq
+}
+func (req *modifyOrderReq) GetMsg(r *http.Request, sender sdk.AccAddress) (sdk.Msg, error) {
+	msg := &types.MsgModifyOrder{
+		Sender:         sender,
+		OrderID:        req.OrderID,
+		PricePrecision: byte(req.PricePrecision),
+		Price:          req.Price,
+		Quantity:       req.Quantity,
+	}
+	return msg, nil
+}
+
+func batchCreateOrdersHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req batchCreateOrdersReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+
+func cancelOrdersHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req cancelOrdersReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
+
+func modifyOrderHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	var req modifyOrderReq
+	return restutil.NewRestHandler(cdc, cliCtx, &req)
+}
@@ -2,6 +2,7 @@ package market
 
 import (
 	"crypto/sha256"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,12 +17,17 @@ import (
 
 // Some handlers which are useful when orders are matched and traded.
 type InfoForDeal struct {
+	keeper        keepers.Keeper
 	bxKeeper      types.ExpectedBankxKeeper
 	msgSender     msgqueue.MsgSender
 	dataHash      []byte
 	changedOrders map[string]*types.Order
-	lastPrice     sdk.Dec
-	context       sdk.Context
+	// the orders canceled by self-trade prevention
+	selfTradeOrders map[string]bool
+	deals           types.Candle
+	context         sdk.Context
+
+	marketParams types.Params
 }
 
 // returns true when a buyer's frozen money is not enough to buy LeftStock.
@@ -65,6 +71,19 @@ func (wo *WrappedOrder) String() string {
 	return wo.order.OrderID()
 }
 
+func (wo *WrappedOrder) GetSelfTradePrevention() byte {
+	return wo.order.SelfTradePrevention
+}
+
+// The stock removed to prevent a self-trade is not dealt, and the coins frozen for it are returned when the order is removed
+func (wo *WrappedOrder) PreventSelfTrade(amount int64) {
+	wo.order.LeftStock -= amount
+	wo.infoForDeal.changedOrders[wo.order.OrderID()] = wo.order
+	if wo.order.LeftStock == 0 {
+		wo.infoForDeal.selfTradeOrders[wo.order.OrderID()] = true
+	}
+}
+
 func (wo *WrappedOrder) GetHash() []byte {
 	res := sha256.Sum256(append([]byte(wo.order.OrderID()), wo.infoForDeal.dataHash...))
 	return res[:]
@@ -97,6 +116,14 @@ func (wo *WrappedOrder) Deal(otherSide match.OrderForTrade, amount int64, price
 	buyer.DealMoney += moneyAmountInt64
 	seller.DealMoney += moneyAmountInt64
 	ctx := wo.infoForDeal.context
+	// the order resting in the order book from an earlier height is the maker
+	if buyer.Height < seller.Height {
+		buyer.MakerDealStock += amount
+		wo.infoForDeal.payMakerRebate(buyer, seller, amount)
+	} else if seller.Height < buyer.Height {
+		seller.MakerDealStock += amount
+		wo.infoForDeal.payMakerRebate(seller, buyer, amount)
+	}
 	// exchange the coins
 	wo.infoForDeal.bxKeeper.UnFreezeCoins(ctx, seller.Sender, stockCoins)
 	wo.infoForDeal.bxKeeper.SendCoins(ctx, seller.Sender, buyer.Sender, stockCoins)
@@ -107,14 +134,42 @@ func (wo *WrappedOrder) Deal(otherSide match.OrderForTrade, amount int64, price
 	wo.infoForDeal.changedOrders[buyer.OrderID()] = buyer
 	wo.infoForDeal.changedOrders[seller.OrderID()] = seller
 
-	// record the last executed price, which will be stored in MarketInfo
-	wo.infoForDeal.lastPrice = price
+	// record the deal, its price will be stored in MarketInfo as the last executed price
+	wo.infoForDeal.deals.Update(price, amount, moneyAmountInt64)
+	// the trading volumes decide the fee tiers of the buyer and the seller, and a trader can't
+	// climb the tiers by trading with itself
+	if !buyer.Sender.Equals(seller.Sender) {
+		wo.infoForDeal.keeper.AddTradingVolume(ctx, buyer.Sender, stock, money, amount, moneyAmountInt64)
+		wo.infoForDeal.keeper.AddTradingVolume(ctx, seller.Sender, stock, money, amount, moneyAmountInt64)
+	}
 
 	if wo.infoForDeal.msgSender.IsSubscribed(types.Topic) {
 		SendFillMsg(ctx, seller, buyer, amount, moneyAmountInt64, price, ctx.BlockHeight())
 	}
 }
 
+// A maker's rebate of a deal is paid from the taker's frozen commission for the same deal,
+// so the rebates never exceed the commission charged on the takers.
+func (info *InfoForDeal) payMakerRebate(maker, taker *types.Order, amount int64) {
+	ctx := info.context
+	discount := info.keeper.GetFeeDiscount(ctx, taker.Sender)
+	rebate := taker.CalMakerRebateInt64(amount, discount, info.marketParams.MarketFeeRate, info.marketParams.MakerFeeRate)
+	if rebate <= 0 {
+		return
+	}
+	rebateCoins := dex.NewCetCoins(rebate)
+	if err := info.bxKeeper.UnFreezeCoins(ctx, taker.Sender, rebateCoins); err != nil {
+		ctx.Logger().Error("%s", err.Error())
+		return
+	}
+	if err := info.bxKeeper.SendCoins(ctx, taker.Sender, maker.Sender, rebateCoins); err != nil {
+		ctx.Logger().Error("%s", err.Error())
+		return
+	}
+	taker.PaidMakerRebate += rebate
+	maker.MakerRebate += rebate
+}
+
 func SendFillMsg(ctx sdk.Context, seller *Order, buyer *Order, stockAmount, moneyAmount int64, price sdk.Dec, currentHeight int64) {
 	sellInfo := types.FillOrderInfo{
 		OrderID:     seller.OrderID(),
@@ -164,7 +219,7 @@ func removeOrder(ctx sdk.Context, orderKeeper keepers.OrderKeeper, bxKeeper type
 func unfreezeCoinsForOrder(ctx sdk.Context, bxKeeper types.ExpectedBankxKeeper, order *types.Order,
 	keeper types.Keeper, marketParam *types.Params) {
 	unfreezeCoinsInOrder(ctx, order, bxKeeper)
-	chargeOrderCommission(ctx, order, marketParam.FeeForZeroDeal, bxKeeper, keeper)
+	chargeOrderCommission(ctx, order, marketParam, bxKeeper, keeper)
 	chargeOrderFeatureFee(ctx, order, marketParam.GTEOrderLifetime, bxKeeper, keeper)
 }
 
@@ -175,20 +230,32 @@ func unfreezeCoinsInOrder(ctx sdk.Context, order *types.Order, bxKeeper types.Ex
 	}
 }
 
-func chargeOrderCommission(ctx sdk.Context, order *types.Order, feeForZeroDeal int64,
+func chargeOrderCommission(ctx sdk.Context, order *types.Order, marketParam *types.Params,
 	bxKeeper types.ExpectedBankxKeeper, keeper types.Keeper) {
 	if order.FrozenCommission != 0 {
-		if err := bxKeeper.UnFreezeCoins(ctx, order.Sender, dex.NewCetCoins(order.FrozenCommission)); err != nil {
+		// the rebates paid to the makers were unfrozen when dealing
+		if err := bxKeeper.UnFreezeCoins(ctx, order.Sender, dex.NewCetCoins(order.FrozenCommission-order.PaidMakerRebate)); err != nil {
 			ctx.Logger().Error("%s", err.Error())
 		}
-		actualFee := order.CalActualOrderCommissionInt64(feeForZeroDeal)
-		chargeFee(ctx, actualFee, order.Sender, keeper)
+		actualFee := calOrderActualCommission(ctx, order, marketParam, keeper)
+		chargeFee(ctx, actualFee-order.PaidMakerRebate, order.Sender, keeper)
 	}
 }
 
+// the commission is discounted by the fee tier of the order's sender, and it includes the rebates
+// which were paid to the makers
+func calOrderActualCommission(ctx sdk.Context, order *types.Order, marketParam *types.Params, keeper types.ExpectedChargeFeeKeeper) int64 {
+	fee := order.CalActualOrderCommissionInt64(marketParam.FeeForZeroDeal, marketParam.MarketFeeRate, marketParam.MakerFeeRate)
+	fee = types.DiscountFee(fee, keeper.GetFeeDiscount(ctx, order.Sender))
+	if fee < order.PaidMakerRebate {
+		fee = order.PaidMakerRebate
+	}
+	return fee
+}
+
 func chargeOrderFeatureFee(ctx sdk.Context, order *types.Order, freeTimeBlocks int64,
 	bxKeeper types.ExpectedBankxKeeper, keeper types.Keeper) {
-	if order.TimeInForce == GTE && order.FrozenFeatureFee != 0 {
+	if !types.IsImmediateTimeInForce(order.TimeInForce) && order.FrozenFeatureFee != 0 {
 		if err := bxKeeper.UnFreezeCoins(ctx, order.Sender, dex.NewCetCoins(order.FrozenFeatureFee)); err != nil {
 			ctx.Logger().Error("%s", err.Error())
 		}
@@ -237,7 +304,12 @@ func filterCandidates(ctx sdk.Context, asKeeper types.ExpectedAssetStatusKeeper,
 	return ordersOut
 }
 
-func runMatch(ctx sdk.Context, midPrice sdk.Dec, ratio int64, symbol string, keeper keepers.Keeper, dataHash []byte, currHeight int64) (map[string]*types.Order, sdk.Dec) {
+// runMatch returns the orders need further processing, the deals, the orders canceled by self-trade prevention
+// and the orders excluded from matching
+func runMatch(ctx sdk.Context, mi types.MarketInfo, ratio int64, keeper keepers.Keeper, dataHash []byte,
+	currHeight int64) (map[string]*types.Order, types.Candle, map[string]bool, map[string]bool) {
+	symbol, midPrice := mi.GetSymbol(), mi.LastExecutedPrice
+	engine := match.NewEngine(mi.MatchingMode)
 	orderKeeper := keepers.NewOrderKeeper(keeper.GetMarketKey(), symbol, types.ModuleCdc)
 	asKeeper := keeper.GetAssetKeeper()
 	bxKeeper := keeper.GetBankxKeeper()
@@ -245,12 +317,15 @@ func runMatch(ctx sdk.Context, midPrice sdk.Dec, ratio int64, symbol string, kee
 	highPrice := midPrice.Mul(sdk.NewDec(100 + ratio)).Quo(sdk.NewDec(100))
 
 	infoForDeal := &InfoForDeal{
-		bxKeeper:      bxKeeper,
-		dataHash:      dataHash,
-		changedOrders: make(map[string]*types.Order),
-		context:       ctx,
-		lastPrice:     sdk.NewDec(0),
-		msgSender:     keeper.GetMsgProducer(),
+		keeper:          keeper,
+		bxKeeper:        bxKeeper,
+		dataHash:        dataHash,
+		changedOrders:   make(map[string]*types.Order),
+		selfTradeOrders: make(map[string]bool),
+		context:         ctx,
+		deals:           types.NewCandle(symbol, 0, ctx.BlockHeader().Time.Unix()),
+		msgSender:       keeper.GetMsgProducer(),
+		marketParams:    keeper.GetParams(ctx),
 	}
 
 	// from the order book, we fetch the candidate orders for matching and filter them
@@ -272,21 +347,72 @@ func runMatch(ctx sdk.Context, midPrice sdk.Dec, ratio int64, symbol string, kee
 			askList = append(askList, wrappedOrder)
 		}
 	}
-	// call the match engine
-	match.Match(highPrice, midPrice, lowPrice, bidList, askList)
+	// post-only and FOK orders which can not be satisfied are excluded before matching
+	var excludedList []match.OrderForTrade
+	if hasConstrainedOrder(orderCandidates, currHeight) {
+		bidList, askList, excludedList = match.ExcludeUnsatisfiedOrders(engine, highPrice, midPrice, lowPrice,
+			bidList, askList, func(order match.OrderForTrade) bool {
+				return isConstrainedOrder(order.(*WrappedOrder).order, currHeight)
+			}, func(order match.OrderForTrade, dealAmount int64) bool {
+				return isOrderSatisfied(order.(*WrappedOrder).order, dealAmount, currHeight)
+			})
+	}
+	// call the match engine chosen by the market
+	engine.Match(highPrice, midPrice, lowPrice, bidList, askList)
 
-	// both dealt orders and IOC order need further processing
+	// dealt orders, excluded orders, IOC and FOK orders need further processing
 	ordersForUpdate := infoForDeal.changedOrders
+	excludedOrders := make(map[string]bool, len(excludedList))
+	for _, excluded := range excludedList {
+		order := excluded.(*WrappedOrder).order
+		ordersForUpdate[order.OrderID()] = order
+		excludedOrders[order.OrderID()] = true
+	}
+	collectImmediateOrders(ctx, orderKeeper, currHeight, ordersForUpdate)
+
+	return ordersForUpdate, infoForDeal.deals, infoForDeal.selfTradeOrders, excludedOrders
+}
+
+// collectImmediateOrders includes the IOC and FOK orders created at currHeight in ordersForUpdate if they
+// are not included yet, so that they are removed at the end of the block
+func collectImmediateOrders(ctx sdk.Context, orderKeeper keepers.OrderKeeper, currHeight int64, ordersForUpdate map[string]*types.Order) {
 	for _, order := range orderKeeper.GetOrdersAtHeight(ctx, currHeight) {
-		if order.TimeInForce == types.IOC {
-			// if an IOC order is not included, we include it
+		if types.IsImmediateTimeInForce(order.TimeInForce) {
 			if _, ok := ordersForUpdate[order.OrderID()]; !ok {
 				ordersForUpdate[order.OrderID()] = order
 			}
 		}
 	}
+}
+
+func hasConstrainedOrder(orders []*types.Order, currHeight int64) bool {
+	for _, order := range orders {
+		if isConstrainedOrder(order, currHeight) {
+			return true
+		}
+	}
+	return false
+}
 
-	return ordersForUpdate, infoForDeal.lastPrice
+// A constrained order is excluded from matching when it can not be satisfied
+func isConstrainedOrder(order *types.Order, currHeight int64) bool {
+	return order.TimeInForce == types.FOK || isNewPostOnlyOrder(order, currHeight)
+}
+
+// A post-only order only takes effect in the block it was created, after which it rests in the order book
+func isNewPostOnlyOrder(order *types.Order, currHeight int64) bool {
+	return order.TimeInForce == types.PostOnly && order.Height == currHeight
+}
+
+// A new post-only order must not deal, and an FOK order must be fully filled
+func isOrderSatisfied(order *types.Order, dealAmount int64, currHeight int64) bool {
+	if isNewPostOnlyOrder(order, currHeight) {
+		return dealAmount == 0
+	}
+	if order.TimeInForce == types.FOK {
+		return dealAmount == order.LeftStock
+	}
+	return true
 }
 
 func removeExpiredOrder(ctx sdk.Context, keeper keepers.Keeper, marketInfoList []types.MarketInfo, marketParams *types.Params) {
@@ -307,6 +433,19 @@ func removeExpiredOrder(ctx sdk.Context, keeper keepers.Keeper, marketInfoList [
 				msgqueue.FillMsgs(ctx, types.CancelOrderInfoKey, cancelOrderInfo)
 			}
 		}
+		removeExpiredConditionalOrders(ctx, keeper, mi.GetSymbol())
+	}
+}
+
+func removeExpiredConditionalOrders(ctx sdk.Context, keeper keepers.Keeper, symbol string) {
+	conditionalKeeper := keepers.NewConditionalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+	for _, co := range conditionalKeeper.GetOrdersInMarket(ctx, symbol) {
+		if !co.IsExpired(ctx.BlockHeight()) {
+			continue
+		}
+		if err := conditionalKeeper.Remove(ctx, co); err == nil {
+			sendCancelConditionalOrderMsg(ctx, keeper, co, types.CancelOrderByGteTimeOut)
+		}
 	}
 }
 
@@ -329,6 +468,14 @@ func removeExpiredMarket(ctx sdk.Context, keeper keepers.Keeper, marketParams *t
 				msgqueue.FillMsgs(ctx, types.CancelOrderInfoKey, cancelOrderInfo)
 			}
 		}
+		conditionalKeeper := keepers.NewConditionalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+		for _, co := range conditionalKeeper.GetOrdersInMarket(ctx, symbol) {
+			if err := conditionalKeeper.Remove(ctx, co); err == nil {
+				sendCancelConditionalOrderMsg(ctx, keeper, co, types.CancelOrderByDelist)
+			}
+		}
+		conditionalKeeper.SetTriggerPending(ctx, symbol, false)
+		keepers.NewCandleKeeper(keeper.GetMarketKey(), types.ModuleCdc).RemoveMarket(ctx, symbol)
 		keeper.RemoveMarket(ctx, symbol)
 	}
 	delistKeeper.RemoveDelistRequestsBeforeTime(ctx, currTime)
@@ -363,6 +510,7 @@ func EndBlocker(ctx sdk.Context, keeper keepers.Keeper) /*sdk.Tags*/ {
 
 	markets := keeper.GetMarketsWithNewlyAddedOrder(ctx)
 	if len(markets) == 0 {
+		triggerPendingConditionalOrders(ctx, keeper, types.MaxTriggeredConditionalOrders)
 		return
 	}
 	marketInfoList := make([]types.MarketInfo, len(markets))
@@ -375,19 +523,31 @@ func EndBlocker(ctx sdk.Context, keeper keepers.Keeper) /*sdk.Tags*/ {
 	}
 	currHeight := ctx.BlockHeight()
 	ordersForUpdateList := make([]map[string]*types.Order, len(marketInfoList))
-	newPrices := make([]sdk.Dec, len(marketInfoList))
+	dealsList := make([]types.Candle, len(marketInfoList))
+	selfTradeOrdersList := make([]map[string]bool, len(marketInfoList))
+	excludedOrdersList := make([]map[string]bool, len(marketInfoList))
+	triggerBudget := types.MaxTriggeredConditionalOrders
 	for idx, mi := range marketInfoList {
 		// if a token is globally forbidden, exchange it is also impossible
 		if keeper.IsTokenForbidden(ctx, mi.Stock) ||
 			keeper.IsTokenForbidden(ctx, mi.Money) {
 			continue
 		}
-		symbol := mi.GetSymbol()
+		// a halted market keeps its orders, but they are not matched until it is resumed.
+		// The IOC and FOK orders created before it was halted in this block are still removed.
+		if mi.Halted {
+			orderKeeper := keepers.NewOrderKeeper(keeper.GetMarketKey(), mi.GetSymbol(), types.ModuleCdc)
+			ordersForUpdateList[idx] = make(map[string]*types.Order)
+			collectImmediateOrders(ctx, orderKeeper, currHeight, ordersForUpdateList[idx])
+			continue
+		}
 		dataHash := ctx.BlockHeader().DataHash
 		ratio := marketParams.MaxExecutedPriceChangeRatio
-		oUpdate, newPrice := runMatch(ctx, mi.LastExecutedPrice, ratio, symbol, keeper, dataHash, currHeight)
-		newPrices[idx] = newPrice
+		oUpdate, deals, selfTradeOrders, excludedOrders := runMatch(ctx, mi, ratio, keeper, dataHash, currHeight)
+		dealsList[idx] = deals
 		ordersForUpdateList[idx] = oUpdate
+		selfTradeOrdersList[idx] = selfTradeOrders
+		excludedOrdersList[idx] = excludedOrders
 	}
 	for idx, mi := range marketInfoList {
 		// ignore a market if there are no orders need further processing
@@ -396,32 +556,118 @@ func EndBlocker(ctx sdk.Context, keeper keepers.Keeper) /*sdk.Tags*/ {
 		}
 		bankxKeeper := keeper.GetBankxKeeper()
 		orderKeeper := keepers.NewOrderKeeper(keeper.GetMarketKey(), mi.GetSymbol(), types.ModuleCdc)
-		// update the order book
-		for _, order := range ordersForUpdateList[idx] {
+		// update the order book in the order of the order IDs, which must be the same on all the nodes
+		orderIDs := make([]string, 0, len(ordersForUpdateList[idx]))
+		for orderID := range ordersForUpdateList[idx] {
+			orderIDs = append(orderIDs, orderID)
+		}
+		sort.Strings(orderIDs)
+		for _, orderID := range orderIDs {
+			order := ordersForUpdateList[idx][orderID]
 			orderKeeper.Update(ctx, order)
-			if order.TimeInForce == types.IOC || order.LeftStock == 0 || notEnoughMoney(order) {
+			// a new post-only order excluded from matching is canceled, while one only reduced by
+			// self-trade prevention rests in the order book
+			if types.IsImmediateTimeInForce(order.TimeInForce) || order.LeftStock == 0 || notEnoughMoney(order) ||
+				(excludedOrdersList[idx][order.OrderID()] && isNewPostOnlyOrder(order, ctx.BlockHeight())) {
 				removeOrder(ctx, orderKeeper, bankxKeeper, keeper, order, &marketParams)
 				if keeper.IsSubScribed(types.Topic) {
-					cancelOrderInfo := packageCancelOrderMsg(ctx, order, &marketParams, keeper)
+					delReason := ""
+					if selfTradeOrdersList[idx][order.OrderID()] {
+						delReason = types.CancelOrderBySelfTrade
+					}
+					cancelOrderInfo := packageCancelOrderMsgWithDelReason(ctx, order, delReason, &marketParams, keeper)
 					msgqueue.FillMsgs(ctx, types.CancelOrderInfoKey, cancelOrderInfo)
 				}
 			}
 		}
-		// if some orders dealt, update last executed price of this market
-		if !newPrices[idx].IsZero() {
-			mi.LastExecutedPrice = newPrices[idx]
+		// if some orders dealt, update last executed price, the candles and the ticker of this market
+		if !dealsList[idx].IsEmpty() {
+			oldPrice := mi.LastExecutedPrice
+			mi.LastExecutedPrice = dealsList[idx].Close
+			halted := mi.CheckCircuitBreaker(oldPrice, currTime, marketParams.CircuitBreakerWindow, marketParams.CircuitBreakerRatio)
 			keeper.SetMarket(ctx, mi)
+			keeper.RecordPrice(ctx, mi.GetSymbol(), mi.LastExecutedPrice)
+			recordDeals(ctx, keeper, dealsList[idx], marketParams.CandleRetentionCount)
+			if halted {
+				sendMarketHaltMsg(ctx, keeper, mi, "")
+				ctx.EventManager().EmitEvent(newMarketHaltEvent(EventTypeKeyHaltMarket, mi))
+				continue
+			}
+			triggerBudget = triggerConditionalOrders(ctx, keeper, mi.GetSymbol(), mi.LastExecutedPrice, triggerBudget)
+		}
+	}
+	triggerPendingConditionalOrders(ctx, keeper, triggerBudget)
+}
+
+func recordDeals(ctx sdk.Context, keeper keepers.Keeper, deals types.Candle, retentionCount int64) {
+	candleKeeper := keepers.NewCandleKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+	candleKeeper.RecordDeals(ctx, deals, retentionCount)
+	candleKeeper.SetTicker(ctx, candleKeeper.RollTicker(ctx, deals.TradingPair, deals.Close, deals.StartTime))
+}
+
+// convert the conditional orders triggered by the new price into normal orders, which will be matched in next block.
+// At most budget orders are triggered, and the rest of the budget is returned. If more orders are triggered,
+// the market is marked as pending and they wait for the next blocks.
+func triggerConditionalOrders(ctx sdk.Context, keeper keepers.Keeper, symbol string, price sdk.Dec, budget int) int {
+	conditionalKeeper := keepers.NewConditionalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+	triggered, more := conditionalKeeper.GetTriggeredOrders(ctx, symbol, price, budget)
+	conditionalKeeper.SetTriggerPending(ctx, symbol, more)
+	for _, co := range triggered {
+		budget--
+		if err := conditionalKeeper.Remove(ctx, co); err != nil {
+			ctx.Logger().Error("%s", err.Error())
+			continue
+		}
+		if co.IsExpired(ctx.BlockHeight()) {
+			sendCancelConditionalOrderMsg(ctx, keeper, co, types.CancelOrderByGteTimeOut)
+			continue
+		}
+		// the triggered order may fail the checks, e.g. when the sender does not have enough coins now,
+		// and in that case, nothing it did should be committed
+		cacheCtx, write := ctx.CacheContext()
+		cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+		if _, err := createOrder(cacheCtx, co.ToMsgCreateOrder(), keeper, co.Sequence); err != nil {
+			sendCancelConditionalOrderMsg(ctx, keeper, co, types.CancelOrderByTriggerFailed)
+			continue
+		}
+		write()
+		if keeper.IsSubScribed(types.Topic) {
+			msgqueue.FillMsgs(ctx, types.TriggerConditionalOrderInfoKey, types.TriggerConditionalOrderInfo{
+				OrderID:       co.OrderID(),
+				TradingPair:   co.TradingPair,
+				Height:        ctx.BlockHeight(),
+				TriggerPrice:  co.TriggerPrice,
+				ExecutedPrice: price,
+			})
 		}
+		// the CreateOrderInfo must follow the TriggerConditionalOrderInfo
+		ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
 	}
+	return budget
 }
 
-func packageCancelOrderMsg(ctx sdk.Context, order *types.Order,
-	marketParams *Params, keeper types.ExpectedAuthXKeeper) types.CancelOrderInfo {
-	return packageCancelOrderMsgWithDelReason(ctx, order, "", marketParams, keeper)
+// trigger the orders left by the markets marked as pending in the previous blocks
+func triggerPendingConditionalOrders(ctx sdk.Context, keeper keepers.Keeper, budget int) {
+	conditionalKeeper := keepers.NewConditionalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+	for _, symbol := range conditionalKeeper.GetTriggerPendingMarkets(ctx) {
+		if budget <= 0 {
+			break
+		}
+		mi, err := keeper.GetMarketInfo(ctx, symbol)
+		if err != nil {
+			conditionalKeeper.SetTriggerPending(ctx, symbol, false)
+			continue
+		}
+		// a halted market keeps its pending orders until it is resumed
+		if mi.Halted || keeper.IsTokenForbidden(ctx, mi.Stock) || keeper.IsTokenForbidden(ctx, mi.Money) {
+			continue
+		}
+		budget = triggerConditionalOrders(ctx, keeper, symbol, mi.LastExecutedPrice, budget)
+	}
 }
 
 func packageCancelOrderMsgWithDelReason(ctx sdk.Context, order *types.Order, delReason string,
-	marketParams *Params, keeper types.ExpectedAuthXKeeper) types.CancelOrderInfo {
+	marketParams *Params, keeper types.Keeper) types.CancelOrderInfo {
 	currentHeight := ctx.BlockHeight()
 	usedFeatureFee := int64(0)
 	if order.FrozenFeatureFee != 0 {
@@ -433,16 +679,20 @@ func packageCancelOrderMsgWithDelReason(ctx sdk.Context, order *types.Order, del
 		Side:           order.Side,
 		Height:         currentHeight,
 		Price:          order.Price,
-		UsedCommission: order.CalActualOrderCommissionInt64(marketParams.FeeForZeroDeal),
+		UsedCommission: calOrderActualCommission(ctx, order, marketParams, keeper),
 		UsedFeatureFee: usedFeatureFee,
 		LeftStock:      order.LeftStock,
 		RemainAmount:   order.Freeze,
 		DealStock:      order.DealStock,
 		DealMoney:      order.DealMoney,
+		MakerDealStock: order.MakerDealStock,
+		MakerRebate:    order.MakerRebate,
 	}
 	msgInfo.RebateRefereeAddr = keeper.GetRefereeAddr(ctx, order.Sender).String()
 	if len(msgInfo.RebateRefereeAddr) != 0 {
-		msgInfo.RebateAmount = getRebateAmountInOrder(ctx, keeper, msgInfo.UsedCommission, msgInfo.UsedFeatureFee)
+		// the referee does not share the rebates paid to the makers
+		commission := msgInfo.UsedCommission - order.PaidMakerRebate
+		msgInfo.RebateAmount = getRebateAmountInOrder(ctx, keeper, commission, msgInfo.UsedFeatureFee)
 	}
 	msgInfo.DelReason = getCancelOrderReason(order, delReason)
 	return msgInfo
@@ -467,5 +717,11 @@ func getCancelOrderReason(order *types.Order, delReason string) string {
 	if notEnoughMoney(order) {
 		return types.CancelOrderByNoEnoughMoney
 	}
+	if order.TimeInForce == types.FOK {
+		return types.CancelOrderByFokType
+	}
+	if order.TimeInForce == types.PostOnly {
+		return types.CancelOrderByPostOnly
+	}
 	return types.CancelOrderByNotKnow
 }
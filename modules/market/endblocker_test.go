@@ -98,7 +98,7 @@ func TestUnfreezeCoinsForOrder(t *testing.T) {
 	}
 	require.EqualValues(t, bxKeeper.records, refouts)
 
-	commissionFee := order.CalActualOrderCommissionInt64(types.DefaultFeeForZeroDeal)
+	commissionFee := calOrderActualCommission(ctx, order, &params, mockFeeK)
 	featureFee := order.CalActualOrderFeatureFeeInt64(ctx, 10)
 	refouts = []string{
 		fmt.Sprintf("addr : %s, fee : %d", order.Sender, commissionFee),
@@ -112,7 +112,7 @@ func TestRemoveOrders(t *testing.T) {
 	bnk := &mocBankxKeeper{}
 	ctx, keys := newContextAndMarketKey(unitTestChainID)
 	subspace := params.NewKeeper(msgCdc, keys.keyParams, keys.tkeyParams, params.DefaultCodespace).Subspace(types.StoreKey)
-	keeper := keepers.NewKeeper(keys.marketKey, axk, bnk, msgCdc, msgqueue.NewProducer(nil), subspace, auth.AccountKeeper{}, &mockKeeper{})
+	keeper := keepers.NewKeeper(keys.marketKey, axk, bnk, msgCdc, msgqueue.NewProducer(nil), subspace, auth.AccountKeeper{}, &mockKeeper{}, nil)
 	keeper.SetOrderCleanTime(ctx, time.Now().Unix())
 	ctx = ctx.WithBlockTime(time.Unix(time.Now().Unix()+int64(25*60*60), 0))
 	parameters := types.Params{}
@@ -177,7 +177,7 @@ func TestDelist(t *testing.T) {
 	bnk := &mocBankxKeeper{}
 	ctx, keys := newContextAndMarketKey(unitTestChainID)
 	subspace := params.NewKeeper(msgCdc, keys.keyParams, keys.tkeyParams, params.DefaultCodespace).Subspace(types.StoreKey)
-	keeper := keepers.NewKeeper(keys.marketKey, axk, bnk, msgCdc, msgqueue.NewProducer(nil), subspace, auth.AccountKeeper{}, &mockKeeper{})
+	keeper := keepers.NewKeeper(keys.marketKey, axk, bnk, msgCdc, msgqueue.NewProducer(nil), subspace, auth.AccountKeeper{}, &mockKeeper{}, nil)
 	delistKeeper := keepers.NewDelistKeeper(keys.marketKey)
 	delistKeeper.AddDelistRequest(ctx, ctx.BlockHeight(), "btc/usdt")
 	// currDay := ctx.BlockHeader().Time.Unix()
@@ -1056,3 +1056,483 @@ func TestChargeFee(t *testing.T) {
 	keeper.cleanRecord()
 
 }
+
+func TestEndBlockerRecordsCandles(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(1, 0))
+	input.mk.SetOrderCleanTime(input.ctx, 1)
+	symbol := GetSymbol(stock, dex.CET)
+	orderKeeper := keepers.NewOrderKeeper(input.mk.GetMarketKey(), symbol, types.ModuleCdc)
+	input.mk.SetMarket(input.ctx, MarketInfo{Stock: stock, Money: dex.CET})
+
+	seller, _ := simpleAddr("00001")
+	buyer, _ := simpleAddr("00002")
+	addOrders := func(seq uint64, price int64, amount int64) {
+		sellOrder := Order{
+			LeftStock:   amount,
+			Price:       sdk.NewDec(price),
+			Sender:      seller,
+			Sequence:    seq,
+			Identify:    1,
+			TradingPair: symbol,
+			Side:        SELL,
+			Freeze:      amount,
+		}
+		buyOrder := sellOrder
+		buyOrder.Sender = buyer
+		buyOrder.Side = BUY
+		buyOrder.Freeze = amount * price
+		orderKeeper.Add(input.ctx, &sellOrder)
+		orderKeeper.Add(input.ctx, &buyOrder)
+	}
+
+	addOrders(1, 98, 100)
+	EndBlocker(input.ctx, input.mk)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(61, 0))
+	addOrders(2, 99, 50)
+	EndBlocker(input.ctx, input.mk)
+
+	candleKeeper := keepers.NewCandleKeeper(input.mk.GetMarketKey(), types.ModuleCdc)
+	minuteCandles := candleKeeper.GetCandles(input.ctx, symbol, types.CandleSpanMinute, 0, 10)
+	require.Equal(t, 2, len(minuteCandles))
+	require.Equal(t, int64(0), minuteCandles[0].StartTime)
+	require.Equal(t, sdk.NewDec(98), minuteCandles[0].Close)
+	require.Equal(t, sdk.NewInt(100), minuteCandles[0].StockVolume)
+	require.Equal(t, sdk.NewInt(9800), minuteCandles[0].MoneyVolume)
+	require.Equal(t, int64(60), minuteCandles[1].StartTime)
+	require.Equal(t, sdk.NewDec(99), minuteCandles[1].Open)
+
+	hourCandles := candleKeeper.GetCandles(input.ctx, symbol, types.CandleSpanHour, 0, 10)
+	require.Equal(t, 1, len(hourCandles))
+	require.Equal(t, sdk.NewDec(98), hourCandles[0].Open)
+	require.Equal(t, sdk.NewDec(99), hourCandles[0].High)
+	require.Equal(t, sdk.NewDec(98), hourCandles[0].Low)
+	require.Equal(t, sdk.NewDec(99), hourCandles[0].Close)
+	require.Equal(t, sdk.NewInt(150), hourCandles[0].StockVolume)
+	require.Equal(t, sdk.NewInt(9800+4950), hourCandles[0].MoneyVolume)
+
+	ticker := candleKeeper.GetTicker(input.ctx, symbol)
+	require.NotNil(t, ticker)
+	require.Equal(t, sdk.NewDec(99), ticker.LastPrice)
+	require.Equal(t, sdk.NewDec(98), ticker.Open)
+	require.Equal(t, sdk.NewInt(150), ticker.StockVolume)
+	require.Equal(t, int64(61), ticker.UpdateTime)
+}
+
+func TestEndBlockerContinuousMatching(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(1, 0))
+	input.mk.SetOrderCleanTime(input.ctx, 1)
+	symbol := GetSymbol(stock, dex.CET)
+	orderKeeper := keepers.NewOrderKeeper(input.mk.GetMarketKey(), symbol, types.ModuleCdc)
+	input.mk.SetMarket(input.ctx, MarketInfo{
+		Stock:             stock,
+		Money:             dex.CET,
+		LastExecutedPrice: sdk.NewDec(100),
+		MatchingMode:      ContinuousMatching,
+	})
+
+	seller, _ := simpleAddr("00001")
+	buyer, _ := simpleAddr("00002")
+	sellOrder := Order{
+		LeftStock:   100,
+		Price:       sdk.NewDec(95),
+		Sender:      seller,
+		Sequence:    1,
+		Identify:    1,
+		TradingPair: symbol,
+		Height:      1,
+		Side:        SELL,
+		Freeze:      100,
+	}
+	buyOrder := Order{
+		LeftStock:   100,
+		Price:       sdk.NewDec(100),
+		Sender:      buyer,
+		Sequence:    2,
+		Identify:    1,
+		TradingPair: symbol,
+		Height:      2,
+		Side:        BUY,
+		Freeze:      100 * 100,
+	}
+	orderKeeper.Add(input.ctx, &sellOrder)
+	orderKeeper.Add(input.ctx, &buyOrder)
+
+	// the earlier sell order is the maker, a call auction would choose 100 which is closest to the last price
+	EndBlocker(input.ctx, input.mk)
+	mkInfo, err := input.mk.GetMarketInfo(input.ctx, symbol)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(95).String(), mkInfo.LastExecutedPrice.String())
+	require.Equal(t, 0, len(orderKeeper.GetOlderThan(input.ctx, 10)))
+}
+
+func TestEndBlockerCircuitBreaker(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(1, 0))
+	input.mk.SetOrderCleanTime(input.ctx, 1)
+	params := input.mk.GetParams(input.ctx)
+	params.CircuitBreakerRatio = 10
+	input.mk.SetParams(input.ctx, params)
+	symbol := GetSymbol(stock, dex.CET)
+	orderKeeper := keepers.NewOrderKeeper(input.mk.GetMarketKey(), symbol, types.ModuleCdc)
+	input.mk.SetMarket(input.ctx, MarketInfo{
+		Stock:             stock,
+		Money:             dex.CET,
+		LastExecutedPrice: sdk.NewDec(100),
+	})
+
+	seller, _ := simpleAddr("00001")
+	buyer, _ := simpleAddr("00002")
+	addOrders := func(price int64, height int64) {
+		sellOrder := Order{
+			LeftStock:   100,
+			Price:       sdk.NewDec(price),
+			Sender:      seller,
+			Sequence:    uint64(height),
+			Identify:    1,
+			TradingPair: symbol,
+			Height:      height,
+			Side:        SELL,
+			Freeze:      100,
+		}
+		buyOrder := Order{
+			LeftStock:   100,
+			Price:       sdk.NewDec(price),
+			Sender:      buyer,
+			Sequence:    uint64(height),
+			Identify:    1,
+			TradingPair: symbol,
+			Height:      height,
+			Side:        BUY,
+			Freeze:      100 * price,
+		}
+		orderKeeper.Add(input.ctx, &sellOrder)
+		orderKeeper.Add(input.ctx, &buyOrder)
+	}
+
+	// a move within the threshold keeps the market open
+	addOrders(105, 1)
+	EndBlocker(input.ctx, input.mk)
+	mkInfo, err := input.mk.GetMarketInfo(input.ctx, symbol)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(105).String(), mkInfo.LastExecutedPrice.String())
+	require.False(t, mkInfo.Halted)
+	require.Equal(t, sdk.NewDec(100).String(), mkInfo.BreakerRefPrice.String())
+
+	// the cumulative move from the reference price exceeds 10%
+	input.ctx = input.ctx.WithBlockTime(time.Unix(2, 0))
+	addOrders(112, 2)
+	EndBlocker(input.ctx, input.mk)
+	mkInfo, err = input.mk.GetMarketInfo(input.ctx, symbol)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(112).String(), mkInfo.LastExecutedPrice.String())
+	require.True(t, mkInfo.Halted)
+
+	// the orders of a halted market are not matched
+	input.ctx = input.ctx.WithBlockTime(time.Unix(3, 0))
+	addOrders(113, 3)
+	EndBlocker(input.ctx, input.mk)
+	mkInfo, err = input.mk.GetMarketInfo(input.ctx, symbol)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(112).String(), mkInfo.LastExecutedPrice.String())
+	require.Equal(t, 2, len(orderKeeper.GetOlderThan(input.ctx, 10)))
+}
+
+func TestEndBlockerMakerDealStock(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(1, 0)).WithBlockHeight(2)
+	input.mk.SetOrderCleanTime(input.ctx, 1)
+	symbol := GetSymbol(stock, dex.CET)
+	orderKeeper := keepers.NewOrderKeeper(input.mk.GetMarketKey(), symbol, types.ModuleCdc)
+	input.mk.SetMarket(input.ctx, MarketInfo{
+		Stock:             stock,
+		Money:             dex.CET,
+		LastExecutedPrice: sdk.NewDec(100),
+	})
+
+	seller, _ := simpleAddr("00001")
+	buyer, _ := simpleAddr("00002")
+	sellOrder := Order{
+		LeftStock:   100,
+		Quantity:    100,
+		Price:       sdk.NewDec(100),
+		Sender:      seller,
+		Sequence:    1,
+		Identify:    1,
+		TradingPair: symbol,
+		Height:      1,
+		Side:        SELL,
+		Freeze:      100,
+	}
+	buyOrder := Order{
+		LeftStock:   60,
+		Quantity:    60,
+		Price:       sdk.NewDec(100),
+		Sender:      buyer,
+		Sequence:    2,
+		Identify:    1,
+		TradingPair: symbol,
+		Height:      2,
+		Side:        BUY,
+		Freeze:      60 * 100,
+	}
+	orderKeeper.Add(input.ctx, &sellOrder)
+	orderKeeper.Add(input.ctx, &buyOrder)
+
+	// the sell order rests in the order book from an earlier height, so it is the maker
+	EndBlocker(input.ctx, input.mk)
+	orders := orderKeeper.GetOlderThan(input.ctx, 10)
+	require.Equal(t, 1, len(orders))
+	require.Equal(t, sellOrder.OrderID(), orders[0].OrderID())
+	require.EqualValues(t, 60, orders[0].DealStock)
+	require.EqualValues(t, 60, orders[0].MakerDealStock)
+
+	// both sides record the volume of the deal in CET
+	require.Equal(t, sdk.NewInt(6000), input.mk.GetTradingVolume(input.ctx, seller))
+	require.Equal(t, sdk.NewInt(6000), input.mk.GetTradingVolume(input.ctx, buyer))
+}
+
+func TestEndBlockerSelfTradePrevention(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(1, 0)).WithBlockHeight(2)
+	input.mk.SetOrderCleanTime(input.ctx, 1)
+	symbol := GetSymbol(stock, dex.CET)
+	orderKeeper := keepers.NewOrderKeeper(input.mk.GetMarketKey(), symbol, types.ModuleCdc)
+	input.mk.SetMarket(input.ctx, MarketInfo{
+		Stock:             stock,
+		Money:             dex.CET,
+		LastExecutedPrice: sdk.NewDec(100),
+	})
+
+	trader, _ := simpleAddr("00001")
+	sellOrder := Order{
+		LeftStock:   100,
+		Quantity:    100,
+		Price:       sdk.NewDec(100),
+		Sender:      trader,
+		Sequence:    1,
+		Identify:    1,
+		TradingPair: symbol,
+		Height:      1,
+		Side:        SELL,
+		Freeze:      100,
+	}
+	buyOrder := Order{
+		LeftStock:           60,
+		Quantity:            60,
+		Price:               sdk.NewDec(100),
+		Sender:              trader,
+		Sequence:            2,
+		Identify:            1,
+		TradingPair:         symbol,
+		Height:              2,
+		Side:                BUY,
+		Freeze:              60 * 100,
+		SelfTradePrevention: SelfTradeCancelNewest,
+	}
+	orderKeeper.Add(input.ctx, &sellOrder)
+	orderKeeper.Add(input.ctx, &buyOrder)
+
+	// the newer buy order is canceled instead of trading with the sell order of the same trader
+	EndBlocker(input.ctx, input.mk)
+	orders := orderKeeper.GetOlderThan(input.ctx, 10)
+	require.Equal(t, 1, len(orders))
+	require.Equal(t, sellOrder.OrderID(), orders[0].OrderID())
+	require.EqualValues(t, 100, orders[0].LeftStock)
+	require.EqualValues(t, 0, orders[0].DealStock)
+	require.True(t, input.mk.GetTradingVolume(input.ctx, trader).IsZero())
+}
+
+func TestEndBlockerSelfTradeVolume(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(1, 0)).WithBlockHeight(2)
+	input.mk.SetOrderCleanTime(input.ctx, 1)
+	symbol := GetSymbol(stock, dex.CET)
+	orderKeeper := keepers.NewOrderKeeper(input.mk.GetMarketKey(), symbol, types.ModuleCdc)
+	input.mk.SetMarket(input.ctx, MarketInfo{
+		Stock:             stock,
+		Money:             dex.CET,
+		LastExecutedPrice: sdk.NewDec(100),
+	})
+
+	trader, _ := simpleAddr("00001")
+	sellOrder := Order{
+		LeftStock:   100,
+		Quantity:    100,
+		Price:       sdk.NewDec(100),
+		Sender:      trader,
+		Sequence:    1,
+		Identify:    1,
+		TradingPair: symbol,
+		Height:      1,
+		Side:        SELL,
+		Freeze:      100,
+	}
+	buyOrder := Order{
+		LeftStock:   60,
+		Quantity:    60,
+		Price:       sdk.NewDec(100),
+		Sender:      trader,
+		Sequence:    2,
+		Identify:    1,
+		TradingPair: symbol,
+		Height:      2,
+		Side:        BUY,
+		Freeze:      60 * 100,
+	}
+	orderKeeper.Add(input.ctx, &sellOrder)
+	orderKeeper.Add(input.ctx, &buyOrder)
+
+	// the orders of the same trader deal, but the deal is not counted in the trading volume
+	EndBlocker(input.ctx, input.mk)
+	orders := orderKeeper.GetOlderThan(input.ctx, 10)
+	require.Equal(t, 1, len(orders))
+	require.EqualValues(t, 60, orders[0].DealStock)
+	require.True(t, input.mk.GetTradingVolume(input.ctx, trader).IsZero())
+}
+
+func TestEndBlockerPostOnlyOrderWithSelfTradePrevention(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(1, 0)).WithBlockHeight(2)
+	input.mk.SetOrderCleanTime(input.ctx, 1)
+	symbol := GetSymbol(stock, dex.CET)
+	orderKeeper := keepers.NewOrderKeeper(input.mk.GetMarketKey(), symbol, types.ModuleCdc)
+	input.mk.SetMarket(input.ctx, MarketInfo{
+		Stock:             stock,
+		Money:             dex.CET,
+		LastExecutedPrice: sdk.NewDec(100),
+	})
+
+	trader, _ := simpleAddr("00001")
+	sellOrder := Order{
+		LeftStock:   100,
+		Quantity:    100,
+		Price:       sdk.NewDec(100),
+		Sender:      trader,
+		Sequence:    1,
+		Identify:    1,
+		TradingPair: symbol,
+		Height:      1,
+		Side:        SELL,
+		Freeze:      100,
+	}
+	buyOrder := Order{
+		LeftStock:           150,
+		Quantity:            150,
+		Price:               sdk.NewDec(100),
+		Sender:              trader,
+		Sequence:            2,
+		Identify:            1,
+		TradingPair:         symbol,
+		Height:              2,
+		Side:                BUY,
+		Freeze:              150 * 100,
+		TimeInForce:         PostOnly,
+		SelfTradePrevention: SelfTradeDecrement,
+	}
+	orderKeeper.Add(input.ctx, &sellOrder)
+	orderKeeper.Add(input.ctx, &buyOrder)
+
+	// the new post-only order is only reduced by self-trade prevention, so it rests in the order book
+	EndBlocker(input.ctx, input.mk)
+	orders := orderKeeper.GetOlderThan(input.ctx, 10)
+	require.Equal(t, 1, len(orders))
+	require.Equal(t, buyOrder.OrderID(), orders[0].OrderID())
+	require.EqualValues(t, 50, orders[0].LeftStock)
+	require.EqualValues(t, 0, orders[0].DealStock)
+}
+
+func TestChargeOrderCommissionWithMakerRebate(t *testing.T) {
+	bxKeeper := &mocBankxKeeper{records: make([]string, 0, 10)}
+	mockFeeK := &mockKeeper{}
+	order := newTO("00001", 1, 11051, 100, types.BUY, types.GTE, 10, 3)
+	order.FrozenCommission = 1000
+	order.DealStock = 100
+	order.PaidMakerRebate = 200
+	ctx, _ := newContextAndMarketKey(unitTestChainID)
+	params := types.Params{
+		MarketFeeRate: 10,
+		MakerFeeRate:  -2,
+	}
+	// the rebate paid to the makers has been unfrozen, and it is a part of the commission
+	chargeOrderCommission(ctx, order, &params, bxKeeper, mockFeeK)
+	require.EqualValues(t, fmt.Sprintf("unfreeze 800 cet at %s", order.Sender), bxKeeper.records[0])
+	require.EqualValues(t, 2, len(mockFeeK.records))
+	require.EqualValues(t, fmt.Sprintf("addr : %s, fee : %d", order.Sender, 792), mockFeeK.records[1])
+	require.EqualValues(t, 1000, calOrderActualCommission(ctx, order, &params, mockFeeK))
+
+	// the commission is never less than the rebate which has been paid
+	mockFeeK.cleanRecord()
+	mockFeeK.discount = 90
+	require.EqualValues(t, 200, calOrderActualCommission(ctx, order, &params, mockFeeK))
+	chargeOrderCommission(ctx, order, &params, bxKeeper, mockFeeK)
+	require.EqualValues(t, fmt.Sprintf("addr : %s, fee : %d", order.Sender, 0), mockFeeK.records[0])
+}
+
+func TestEndBlockerPayMakerRebate(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(1, 0)).WithBlockHeight(2)
+	input.mk.SetOrderCleanTime(input.ctx, 1)
+	params := input.mk.GetParams(input.ctx)
+	params.MakerFeeRate = -5
+	input.mk.SetParams(input.ctx, params)
+	symbol := GetSymbol(stock, dex.CET)
+	orderKeeper := keepers.NewOrderKeeper(input.mk.GetMarketKey(), symbol, types.ModuleCdc)
+	input.mk.SetMarket(input.ctx, MarketInfo{
+		Stock:             stock,
+		Money:             dex.CET,
+		LastExecutedPrice: sdk.NewDec(100),
+	})
+
+	seller, _ := simpleAddr("00001")
+	buyer, _ := simpleAddr("00002")
+	sellAccount := input.akp.NewAccountWithAddress(input.ctx, seller)
+	require.Nil(t, sellAccount.SetCoins(sdk.NewCoins(sdk.NewCoin(stock, sdk.NewInt(100)))))
+	input.akp.SetAccount(input.ctx, sellAccount)
+	buyAccount := input.akp.NewAccountWithAddress(input.ctx, buyer)
+	require.Nil(t, buyAccount.SetCoins(sdk.NewCoins(sdk.NewCoin(dex.CET, sdk.NewInt(10000)))))
+	input.akp.SetAccount(input.ctx, buyAccount)
+	bxKeeper := input.mk.GetBankxKeeper()
+	require.Nil(t, bxKeeper.FreezeCoins(input.ctx, seller, dex.NewCoins(stock, 100)))
+	require.Nil(t, bxKeeper.FreezeCoins(input.ctx, buyer, dex.NewCetCoins(60*100+1000)))
+
+	sellOrder := Order{
+		LeftStock:   100,
+		Quantity:    100,
+		Price:       sdk.NewDec(100),
+		Sender:      seller,
+		Sequence:    1,
+		Identify:    1,
+		TradingPair: symbol,
+		Height:      1,
+		Side:        SELL,
+		Freeze:      100,
+		TimeInForce: GTE,
+	}
+	buyOrder := Order{
+		LeftStock:        60,
+		Quantity:         60,
+		Price:            sdk.NewDec(100),
+		Sender:           buyer,
+		Sequence:         2,
+		Identify:         1,
+		TradingPair:      symbol,
+		Height:           2,
+		Side:             BUY,
+		Freeze:           60 * 100,
+		FrozenCommission: 1000,
+		TimeInForce:      GTE,
+	}
+	orderKeeper.Add(input.ctx, &sellOrder)
+	orderKeeper.Add(input.ctx, &buyOrder)
+
+	// the maker gets half of the taker's commission on the deal, which is paid by the taker
+	EndBlocker(input.ctx, input.mk)
+	orders := orderKeeper.GetOlderThan(input.ctx, 10)
+	require.Equal(t, 1, len(orders))
+	require.EqualValues(t, 500, orders[0].MakerRebate)
+	require.EqualValues(t, sdk.NewInt(60*100+500), input.getCoinFromAddr(seller, dex.CET).Amount)
+	require.EqualValues(t, sdk.NewInt(10000-60*100-1000), input.getCoinFromAddr(buyer, dex.CET).Amount)
+	require.True(t, bxKeeper.GetFrozenCoins(input.ctx, buyer).IsZero())
+}
@@ -9,6 +9,13 @@ var (
 	EventTypeKeyCancelOrder          = "cancel_order"
 	EventTypeKeyCancelTradingPair    = "cancel_market"
 	EventTypeKeyModifyPricePrecision = "modify_price_precision"
+	EventTypeKeyModifyOrder          = "modify_order"
+	EventTypeKeyHaltMarket           = "halt_market"
+	EventTypeKeyResumeMarket         = "resume_market"
+
+	EventTypeKeyCreateConditionalOrder  = "create_conditional_order"
+	EventTypeKeyCancelConditionalOrder  = "cancel_conditional_order"
+	EventTypeKeyTriggerConditionalOrder = "trigger_conditional_order"
 
 	AttributeKeyTradingPair      = "trading_pair"
 	AttributeKeyOrder            = "order"
@@ -17,6 +24,7 @@ var (
 	AttributeKeyPricePrecision   = "price_precision"
 	AttributeKeyLastExecutePrice = "last_execute_price"
 	AttributeKeySender           = "sender"
+	AttributeKeyMatchingMode     = "matching_mode"
 
 	AttributeKeySequence    = "sequence"
 	AttributeKeyOrderType   = "order_type"
@@ -38,4 +46,6 @@ var (
 
 	AttributeKeyOldPricePrecision = "old_price_precision"
 	AttributeKeyNewPricePrecision = "new_price_precision"
+
+	AttributeKeyTriggerPrice = "trigger_price"
 )
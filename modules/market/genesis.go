@@ -10,19 +10,25 @@ import (
 )
 
 type GenesisState struct {
-	Params         types.Params       `json:"params"`
-	Orders         []*types.Order     `json:"orders"`
-	MarketInfos    []types.MarketInfo `json:"market_infos"`
-	OrderCleanTime int64              `json:"order_clean_time"`
+	Params            types.Params              `json:"params"`
+	Orders            []*types.Order            `json:"orders"`
+	MarketInfos       []types.MarketInfo        `json:"market_infos"`
+	OrderCleanTime    int64                     `json:"order_clean_time"`
+	ConditionalOrders []*types.ConditionalOrder `json:"conditional_orders"`
+	AccountVolumes    []types.AccountVolume     `json:"account_volumes"`
+	// The order books exported by the export-book query, which are imported into a test network
+	OrderBooks []types.BookSnapshot `json:"order_books,omitempty"`
 }
 
 // NewGenesisState - Create a new genesis state
 func NewGenesisState(params types.Params, orders []*types.Order, infos []types.MarketInfo, cleanTime int64) GenesisState {
 	return GenesisState{
-		Params:         params,
-		Orders:         orders,
-		MarketInfos:    infos,
-		OrderCleanTime: cleanTime,
+		Params:            params,
+		Orders:            orders,
+		MarketInfos:       infos,
+		OrderCleanTime:    cleanTime,
+		ConditionalOrders: []*types.ConditionalOrder{},
+		AccountVolumes:    []types.AccountVolume{},
 	}
 }
 
@@ -43,11 +49,28 @@ func InitGenesis(ctx sdk.Context, keeper keepers.Keeper, data GenesisState) {
 		keeper.SetMarket(ctx, info)
 	}
 	keeper.SetOrderCleanTime(ctx, data.OrderCleanTime)
+
+	for _, order := range data.ConditionalOrders {
+		keeper.SetConditionalOrder(ctx, order)
+	}
+
+	for _, volume := range data.AccountVolumes {
+		keeper.SetAccountVolume(ctx, volume)
+	}
+
+	for _, book := range data.OrderBooks {
+		if err := keeper.ImportBook(ctx, book); err != nil {
+			panic(err)
+		}
+	}
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper
 func ExportGenesis(ctx sdk.Context, k keepers.Keeper) GenesisState {
-	return NewGenesisState(k.GetParams(ctx), k.GetAllOrders(ctx), k.GetAllMarketInfos(ctx), k.GetOrderCleanTime(ctx))
+	state := NewGenesisState(k.GetParams(ctx), k.GetAllOrders(ctx), k.GetAllMarketInfos(ctx), k.GetOrderCleanTime(ctx))
+	state.ConditionalOrders = k.GetAllConditionalOrders(ctx)
+	state.AccountVolumes = k.GetAllAccountVolumes(ctx)
+	return state
 }
 
 // ValidateGenesis performs basic validation of market genesis data returning an
@@ -65,6 +88,12 @@ func (data GenesisState) Validate() error {
 		}
 		tokenSymbols[order.OrderID()] = struct{}{}
 	}
+	for _, order := range data.ConditionalOrders {
+		if _, exists := tokenSymbols[order.OrderID()]; exists {
+			return errors.New("duplicate conditional order found during market ValidateGenesis")
+		}
+		tokenSymbols[order.OrderID()] = struct{}{}
+	}
 
 	infos := make(map[string]struct{})
 	for _, info := range data.MarketInfos {
@@ -74,5 +103,28 @@ func (data GenesisState) Validate() error {
 		}
 		infos[symbol] = struct{}{}
 	}
+
+	for _, volume := range data.AccountVolumes {
+		if volume.Address.Empty() || volume.Day < 0 || !volume.Volume.IsPositive() {
+			return errors.New("invalid account volume found during market ValidateGenesis")
+		}
+	}
+
+	for _, book := range data.OrderBooks {
+		if err := book.Validate(); err != nil {
+			return err
+		}
+		symbol := book.MarketInfo.GetSymbol()
+		if _, exists := infos[symbol]; exists {
+			return errors.New("duplicate market found during market ValidateGenesis")
+		}
+		infos[symbol] = struct{}{}
+		for _, order := range book.Orders {
+			if _, exists := tokenSymbols[order.OrderID()]; exists {
+				return errors.New("duplicate order found during market ValidateGenesis")
+			}
+			tokenSymbols[order.OrderID()] = struct{}{}
+		}
+	}
 	return nil
 }
@@ -18,6 +18,7 @@ func createOrdersAndMarkets(num int) (Order, []*Order, MarketInfo, []MarketInfo)
 		PricePrecision:    9,
 		OrderPrecision:    19,
 		LastExecutedPrice: sdk.NewDec(987),
+		BreakerRefPrice:   sdk.ZeroDec(),
 	}
 	orderInfo := Order{
 		Sender: haveCetAddress,
@@ -63,6 +64,10 @@ func TestExportGenesis(t *testing.T) {
 	input := prepareMockInput(t, false, false)
 	_, orderInfos, _, mkInfos := createOrdersAndMarkets(9)
 	state := NewGenesisState(types.DefaultParams(), orderInfos, mkInfos, 876738)
+	state.AccountVolumes = []types.AccountVolume{
+		{Address: haveCetAddress, Day: 18000, Volume: sdk.NewInt(100)},
+		{Address: haveCetAddress, Day: 18001, Volume: sdk.NewInt(200)},
+	}
 	require.Nil(t, state.Validate())
 	InitGenesis(input.ctx, input.mk, state)
 	orders := make(map[string]Order)
@@ -80,6 +85,7 @@ func TestExportGenesis(t *testing.T) {
 	for i, exMarket := range exportState.MarketInfos {
 		require.EqualValues(t, mkInfos[i], exMarket)
 	}
+	require.EqualValues(t, state.AccountVolumes, exportState.AccountVolumes)
 }
 
 func TestValidateGenesis(t *testing.T) {
@@ -99,4 +105,21 @@ func TestValidateGenesis(t *testing.T) {
 	require.NotNil(t, err)
 	require.EqualValues(t, "duplicate order found during market ValidateGenesis", err.Error())
 
+	state = NewGenesisState(types.DefaultParams(), orderInfos[:1], mkInfos, 876738)
+	state.AccountVolumes = []types.AccountVolume{{Address: haveCetAddress, Day: 18000, Volume: sdk.ZeroInt()}}
+	err = state.Validate()
+	require.NotNil(t, err)
+	require.EqualValues(t, "invalid account volume found during market ValidateGenesis", err.Error())
+
+	// the market of an imported order book must not be in the market infos
+	state = NewGenesisState(types.DefaultParams(), orderInfos[:1], mkInfos, 876738)
+	state.OrderBooks = []types.BookSnapshot{{Version: types.BookSnapshotVersion, MarketInfo: mkInfos[0]}}
+	err = state.Validate()
+	require.NotNil(t, err)
+	require.EqualValues(t, "duplicate market found during market ValidateGenesis", err.Error())
+	state.OrderBooks[0].MarketInfo = mkInfo
+	state.OrderBooks[0].MarketInfo.Stock = "abc"
+	require.Nil(t, state.Validate())
+	state.OrderBooks[0].Version = 0
+	require.NotNil(t, state.Validate())
 }
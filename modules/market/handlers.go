@@ -29,6 +29,20 @@ func NewHandler(k keepers.Keeper) sdk.Handler {
 			return handleMsgCancelTradingPair(ctx, msg, k)
 		case types.MsgModifyPricePrecision:
 			return handleMsgModifyPricePrecision(ctx, msg, k)
+		case types.MsgCreateConditionalOrder:
+			return handleMsgCreateConditionalOrder(ctx, msg, k)
+		case types.MsgCancelConditionalOrder:
+			return handleMsgCancelConditionalOrder(ctx, msg, k)
+		case types.MsgBatchCreateOrders:
+			return handleMsgBatchCreateOrders(ctx, msg, k)
+		case types.MsgCancelOrders:
+			return handleMsgCancelOrders(ctx, msg, k)
+		case types.MsgModifyOrder:
+			return handleMsgModifyOrder(ctx, msg, k)
+		case types.MsgHaltMarket:
+			return handleMsgHaltMarket(ctx, msg, k)
+		case types.MsgResumeMarket:
+			return handleMsgResumeMarket(ctx, msg, k)
 		default:
 			return dex.ErrUnknownRequest(ModuleName, msg)
 		}
@@ -50,6 +64,8 @@ func handleMsgCreateTradingPair(ctx sdk.Context, msg types.MsgCreateTradingPair,
 		PricePrecision:    msg.PricePrecision,
 		LastExecutedPrice: sdk.ZeroDec(),
 		OrderPrecision:    orderPrecision,
+		MatchingMode:      msg.MatchingMode,
+		BreakerRefPrice:   sdk.ZeroDec(),
 	}
 
 	if err := keeper.SetMarket(ctx, info); err != nil {
@@ -73,6 +89,7 @@ func handleMsgCreateTradingPair(ctx sdk.Context, msg types.MsgCreateTradingPair,
 			sdk.NewAttribute(AttributeKeyMoney, msg.Money),
 			sdk.NewAttribute(AttributeKeyPricePrecision, strconv.Itoa(int(info.PricePrecision))),
 			sdk.NewAttribute(AttributeKeyLastExecutePrice, info.LastExecutedPrice.String()),
+			sdk.NewAttribute(AttributeKeyMatchingMode, strconv.Itoa(int(info.MatchingMode))),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -150,7 +167,7 @@ func calOrderCommission(ctx sdk.Context, keeper keepers.QueryMarketInfoAndParams
 }
 
 func calFeatureFeeForExistBlocks(msg types.MsgCreateOrder, marketParam types.Params) int64 {
-	if msg.TimeInForce == types.IOC {
+	if types.IsImmediateTimeInForce(msg.TimeInForce) {
 		return 0
 	}
 	if msg.ExistBlocks < marketParam.GTEOrderLifetime {
@@ -199,6 +216,8 @@ func sendCreateOrderMsg(ctx sdk.Context, keeper keepers.Keeper, order types.Orde
 			FrozenCommission: order.FrozenCommission,
 			FrozenFeatureFee: order.FrozenFeatureFee,
 			Freeze:           order.Freeze,
+
+			SelfTradePrevention: order.SelfTradePrevention,
 		}
 		msgqueue.FillMsgs(ctx, types.CreateOrderInfoKey, createOrderInfo)
 	}
@@ -224,30 +243,88 @@ func getDenomAndOrderAmount(msg types.MsgCreateOrder) (string, int64, sdk.Error)
 }
 
 func handleMsgCreateOrder(ctx sdk.Context, msg types.MsgCreateOrder, keeper keepers.Keeper) sdk.Result {
-
-	denom, amount, err := getDenomAndOrderAmount(msg)
+	seq, err := keeper.QuerySeqWithAddr(ctx, msg.Sender)
 	if err != nil {
 		return err.Result()
 	}
+	order, err := createOrder(ctx, msg, keeper, seq)
+	if err != nil {
+		return err.Result()
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		newCreateOrderEvent(order),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func newCreateOrderEvent(order *types.Order) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeKeyCreateOrder,
+		sdk.NewAttribute(AttributeKeyOrder, order.OrderID()),
+		sdk.NewAttribute(AttributeKeyTradingPair, order.TradingPair),
+		sdk.NewAttribute(AttributeKeyHeight, strconv.FormatInt(order.Height, 10)),
+	)
+}
+
+func handleMsgBatchCreateOrders(ctx sdk.Context, msg types.MsgBatchCreateOrders, keeper keepers.Keeper) sdk.Result {
 	seq, err := keeper.QuerySeqWithAddr(ctx, msg.Sender)
 	if err != nil {
 		return err.Result()
 	}
+	// the batch is atomic, so the orders are created in a cached context which is written only when all of them succeed.
+	// Each order is checked just like a MsgCreateOrder, so its ID can't be taken by an order or a conditional order.
+	cacheCtx, write := ctx.CacheContext()
+	events := make(sdk.Events, 0, len(msg.Orders)+1)
+	for _, orderMsg := range msg.Orders {
+		order, err := createOrder(cacheCtx, orderMsg, keeper, seq)
+		if err != nil {
+			return err.Result()
+		}
+		events = append(events, newCreateOrderEvent(order))
+	}
+	write()
+
+	ctx.EventManager().EmitEvents(append(events,
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	))
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+// create an order with the sequence, freeze its coins and fees, and add it to the order book
+func createOrder(ctx sdk.Context, msg types.MsgCreateOrder, keeper keepers.Keeper, seq uint64) (*types.Order, sdk.Error) {
+	denom, amount, err := getDenomAndOrderAmount(msg)
+	if err != nil {
+		return nil, err
+	}
 	marketParams := keeper.GetParams(ctx)
 	frozenFee, err := calOrderCommission(ctx, keeper, msg)
 	if err != nil {
-		return err.Result()
+		return nil, err
 	}
 	featureFee := calFeatureFeeForExistBlocks(msg, marketParams)
 	totalFee := frozenFee + featureFee
 	if featureFee > types.MaxOrderAmount || frozenFee > types.MaxOrderAmount || totalFee > types.MaxOrderAmount {
-		return types.ErrInvalidOrderAmount("The frozen fee is too large").Result()
+		return nil, types.ErrInvalidOrderAmount("The frozen fee is too large")
 	}
 	if err := checkMsgCreateOrder(ctx, keeper, msg, totalFee, amount, denom, seq); err != nil {
-		return err.Result()
+		return nil, err
 	}
 	existBlocks := msg.ExistBlocks
-	if existBlocks == 0 && msg.TimeInForce == GTE {
+	if existBlocks == 0 && !types.IsImmediateTimeInForce(msg.TimeInForce) {
 		existBlocks = marketParams.GTEOrderLifetime
 	}
 
@@ -269,33 +346,19 @@ func handleMsgCreateOrder(ctx sdk.Context, msg types.MsgCreateOrder, keeper keep
 		Freeze:           amount,
 		DealMoney:        0,
 		DealStock:        0,
+
+		SelfTradePrevention: msg.SelfTradePrevention,
 	}
 
 	ork := keepers.NewOrderKeeper(keeper.GetMarketKey(), order.TradingPair, types.ModuleCdc)
 	if err := ork.Add(ctx, &order); err != nil {
-		return err.Result()
+		return nil, err
 	}
 	if err := handleFeeForCreateOrder(ctx, keeper, amount, denom, order.Sender, frozenFee, featureFee); err != nil {
-		return err.Result()
+		return nil, err
 	}
 	sendCreateOrderMsg(ctx, keeper, order)
-
-	ctx.EventManager().EmitEvents(sdk.Events{
-		sdk.NewEvent(
-			EventTypeKeyCreateOrder,
-			sdk.NewAttribute(AttributeKeyOrder, order.OrderID()),
-			sdk.NewAttribute(AttributeKeyTradingPair, order.TradingPair),
-			sdk.NewAttribute(AttributeKeyHeight, strconv.FormatInt(order.Height, 10)),
-		),
-		sdk.NewEvent(
-			sdk.EventTypeMessage,
-			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
-		),
-	})
-	return sdk.Result{
-		Events: ctx.EventManager().Events(),
-	}
+	return &order, nil
 }
 
 func checkMsgCreateOrder(ctx sdk.Context, keeper keepers.Keeper, msg types.MsgCreateOrder, cetFee int64, amount int64, denom string, seq uint64) sdk.Error {
@@ -315,13 +378,23 @@ func checkMsgCreateOrder(ctx sdk.Context, keeper keepers.Keeper, msg types.MsgCr
 	}
 	orderID := types.AssemblyOrderID(msg.Sender.String(), seq, msg.Identify)
 	globalKeeper := keepers.NewGlobalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
-	if globalKeeper.QueryOrder(ctx, orderID) != nil {
+	conditionalKeeper := keepers.NewConditionalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+	if globalKeeper.QueryOrder(ctx, orderID) != nil || conditionalKeeper.QueryOrder(ctx, orderID) != nil {
 		return types.ErrOrderAlreadyExist(orderID)
 	}
+	return checkOrderInMarket(ctx, keeper, msg)
+}
+
+// check whether the order is acceptable for its market and the tokens' issuers
+func checkOrderInMarket(ctx sdk.Context, keeper keepers.Keeper, msg types.MsgCreateOrder) sdk.Error {
+	stock, money := SplitSymbol(msg.TradingPair)
 	marketInfo, err := keeper.GetMarketInfo(ctx, msg.TradingPair)
 	if err != nil {
 		return types.ErrInvalidMarket(err.Error())
 	}
+	if marketInfo.Halted {
+		return types.ErrMarketHalted(msg.TradingPair)
+	}
 	if p := msg.PricePrecision; p > marketInfo.PricePrecision {
 		return types.ErrInvalidPricePrecision(p)
 	}
@@ -348,23 +421,80 @@ func handleMsgCancelOrder(ctx sdk.Context, msg types.MsgCancelOrder, keeper keep
 	// send msg to kafka
 	sendCancelOrderMsg(ctx, order, &marketParams, keeper)
 	ctx.EventManager().EmitEvents(sdk.Events{
+		newCancelOrderEvent(ctx, order),
 		sdk.NewEvent(
-			EventTypeKeyCancelOrder,
-			sdk.NewAttribute(AttributeKeyOrder, order.OrderID()),
-			sdk.NewAttribute(AttributeKeyDelOrderReason, types.CancelOrderByManual),
-			sdk.NewAttribute(AttributeKeyDelOrderHeight, strconv.Itoa(int(ctx.BlockHeight()))),
-			sdk.NewAttribute(AttributeKeyTradingPair, order.TradingPair),
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
 		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func newCancelOrderEvent(ctx sdk.Context, order *types.Order) sdk.Event {
+	return sdk.NewEvent(
+		EventTypeKeyCancelOrder,
+		sdk.NewAttribute(AttributeKeyOrder, order.OrderID()),
+		sdk.NewAttribute(AttributeKeyDelOrderReason, types.CancelOrderByManual),
+		sdk.NewAttribute(AttributeKeyDelOrderHeight, strconv.Itoa(int(ctx.BlockHeight()))),
+		sdk.NewAttribute(AttributeKeyTradingPair, order.TradingPair),
+	)
+}
+
+func handleMsgCancelOrders(ctx sdk.Context, msg types.MsgCancelOrders, keeper keepers.Keeper) sdk.Result {
+	orderIDs := getOrderIDsToCancel(ctx, msg, keeper)
+	// all the orders are checked before any of them is canceled, so the cancellation is atomic
+	for _, orderID := range orderIDs {
+		if err := checkMsgCancelOrder(ctx, types.MsgCancelOrder{Sender: msg.Sender, OrderID: orderID}, keeper); err != nil {
+			return err.Result()
+		}
+	}
+
+	events := make(sdk.Events, 0, len(orderIDs)+1)
+	for _, orderID := range orderIDs {
+		order, marketParams := DoCancelOrder(ctx, keeper, orderID)
+		sendCancelOrderMsg(ctx, order, &marketParams, keeper)
+		events = append(events, newCancelOrderEvent(ctx, order))
+	}
+	ctx.EventManager().EmitEvents(append(events,
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
 			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
 		),
-	})
+	))
 	return sdk.Result{
 		Events: ctx.EventManager().Events(),
 	}
 }
+
+// return the listed orders and the sender's orders in the trading pair, without duplication
+func getOrderIDsToCancel(ctx sdk.Context, msg types.MsgCancelOrders, keeper keepers.Keeper) []string {
+	orderIDs := make([]string, 0, len(msg.OrderIDs))
+	included := make(map[string]bool, len(msg.OrderIDs))
+	for _, orderID := range msg.OrderIDs {
+		if !included[orderID] {
+			included[orderID] = true
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	if len(msg.TradingPair) == 0 {
+		return orderIDs
+	}
+	globalKeeper := keepers.NewGlobalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+	for _, orderID := range globalKeeper.GetOrdersFromUser(ctx, msg.Sender.String()) {
+		if included[orderID] {
+			continue
+		}
+		if order := globalKeeper.QueryOrder(ctx, orderID); order != nil && order.TradingPair == msg.TradingPair {
+			included[orderID] = true
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+	return orderIDs
+}
 func DoCancelOrder(ctx sdk.Context, keeper keepers.Keeper, orderID string) (*types.Order, types.Params) {
 	marketParams := keeper.GetParams(ctx)
 	bankxKeeper := keeper.GetBankxKeeper()
@@ -394,6 +524,139 @@ func checkMsgCancelOrder(ctx sdk.Context, msg types.MsgCancelOrder, keeper keepe
 	return nil
 }
 
+func handleMsgModifyOrder(ctx sdk.Context, msg types.MsgModifyOrder, keeper keepers.Keeper) sdk.Result {
+	order, err := checkMsgModifyOrder(ctx, msg, keeper)
+	if err != nil {
+		return err.Result()
+	}
+	newOrder := *order
+	if msg.Price != 0 {
+		newOrder.Price = sdk.NewDec(msg.Price).Quo(sdk.NewDec(int64(math.Pow10(int(msg.PricePrecision)))))
+	}
+	if msg.Quantity != 0 {
+		newOrder.LeftStock = msg.Quantity
+	}
+	priceChanged := !newOrder.Price.Equal(order.Price)
+	if !priceChanged && newOrder.LeftStock == order.LeftStock {
+		return types.ErrInvalidModification("neither price nor quantity is modified").Result()
+	}
+	if priceChanged {
+		// the order is re-queued and loses its time priority, but it still expires at the same height,
+		// and its feature fee is still charged from the height where it was created
+		currHeight := ctx.BlockHeight()
+		if order.FeatureFeeHeight == 0 {
+			newOrder.FeatureFeeHeight = order.Height
+		}
+		newOrder.ExistBlocks = order.Height + order.ExistBlocks - currHeight
+		newOrder.Height = currHeight
+	}
+	if err := adjustFrozenCoinsForOrder(ctx, keeper, order, &newOrder); err != nil {
+		return err.Result()
+	}
+
+	ork := keepers.NewOrderKeeper(keeper.GetMarketKey(), order.TradingPair, types.ModuleCdc)
+	if priceChanged {
+		// the keys in the bid list, the ask list and the order queue all change with the price and height
+		if err := ork.Remove(ctx, order); err != nil {
+			return err.Result()
+		}
+		if err := ork.Add(ctx, &newOrder); err != nil {
+			return err.Result()
+		}
+	} else if err := ork.Update(ctx, &newOrder); err != nil {
+		return err.Result()
+	}
+
+	sendModifyOrderMsg(ctx, keeper, order.Price, &newOrder)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyModifyOrder,
+			sdk.NewAttribute(AttributeKeyOrder, newOrder.OrderID()),
+			sdk.NewAttribute(AttributeKeyTradingPair, newOrder.TradingPair),
+			sdk.NewAttribute(AttributeKeyPrice, newOrder.Price.String()),
+			sdk.NewAttribute(AttributeKeyLeftStock, strconv.FormatInt(newOrder.LeftStock, 10)),
+			sdk.NewAttribute(AttributeKeyHeight, strconv.FormatInt(newOrder.Height, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func checkMsgModifyOrder(ctx sdk.Context, msg types.MsgModifyOrder, keeper keepers.Keeper) (*types.Order, sdk.Error) {
+	if err := checkMsgCancelOrder(ctx, types.MsgCancelOrder{Sender: msg.Sender, OrderID: msg.OrderID}, keeper); err != nil {
+		return nil, err
+	}
+	globalKeeper := keepers.NewGlobalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+	order := globalKeeper.QueryOrder(ctx, msg.OrderID)
+	if !types.IsImmediateTimeInForce(order.TimeInForce) && order.Height+order.ExistBlocks <= ctx.BlockHeight() {
+		return nil, types.ErrInvalidModification("the order has expired")
+	}
+	if msg.Quantity != 0 && msg.Quantity > order.LeftStock {
+		return nil, types.ErrInvalidModification("the quantity of an order can only be reduced")
+	}
+	if msg.Price != 0 {
+		// the new price must be acceptable for the market, just like the price of a new order
+		createMsg := types.MsgCreateOrder{
+			Sender:         msg.Sender,
+			TradingPair:    order.TradingPair,
+			PricePrecision: msg.PricePrecision,
+			Quantity:       order.Quantity,
+		}
+		if err := checkOrderInMarket(ctx, keeper, createMsg); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// freeze or unfreeze coins, such that the frozen amount fits the new price and left stock of the order
+func adjustFrozenCoinsForOrder(ctx sdk.Context, keeper keepers.Keeper, order, newOrder *types.Order) sdk.Error {
+	// the amount of money is limited for both sides, just like it is for a new order
+	amount, err := calculateAmountWithDecPrice(newOrder.Price, newOrder.LeftStock)
+	if err != nil {
+		return types.ErrInvalidOrderAmount(err.Error())
+	}
+	newOrder.Freeze = newOrder.LeftStock
+	if newOrder.Side == types.BUY {
+		newOrder.Freeze = amount.RoundInt64()
+	}
+	diff := newOrder.Freeze - order.Freeze
+	if diff == 0 {
+		return nil
+	}
+	denom := order.GetOrderUsedDenom()
+	if diff < 0 {
+		return keeper.UnFreezeCoins(ctx, order.Sender, sdk.Coins{sdk.NewCoin(denom, sdk.NewInt(-diff))})
+	}
+	coins := sdk.Coins{sdk.NewCoin(denom, sdk.NewInt(diff))}
+	if !keeper.HasCoins(ctx, order.Sender, coins) {
+		return types.ErrInsufficientCoins()
+	}
+	return keeper.FreezeCoins(ctx, order.Sender, coins)
+}
+
+func sendModifyOrderMsg(ctx sdk.Context, keeper keepers.Keeper, oldPrice sdk.Dec, order *types.Order) {
+	if keeper.IsSubScribed(types.Topic) {
+		msgInfo := types.ModifyOrderInfo{
+			OrderID:     order.OrderID(),
+			TradingPair: order.TradingPair,
+			Side:        order.Side,
+			OldPrice:    oldPrice,
+			Price:       order.Price,
+			Height:      order.Height,
+			LeftStock:   order.LeftStock,
+			Freeze:      order.Freeze,
+		}
+		msgqueue.FillMsgs(ctx, types.ModifyOrderInfoKey, msgInfo)
+	}
+}
+
 func handleMsgCancelTradingPair(ctx sdk.Context, msg types.MsgCancelTradingPair, keeper keepers.Keeper) sdk.Result {
 	if err := checkMsgCancelTradingPair(keeper, msg, ctx); err != nil {
 		return err.Result()
@@ -446,6 +709,10 @@ func checkMsgCancelTradingPair(keeper keepers.Keeper, msg types.MsgCancelTrading
 
 func calculateAmount(price, quantity int64, pricePrecision byte) (sdk.Dec, error) {
 	actualPrice := sdk.NewDec(price).Quo(sdk.NewDec(int64(math.Pow10(int(pricePrecision)))))
+	return calculateAmountWithDecPrice(actualPrice, quantity)
+}
+
+func calculateAmountWithDecPrice(actualPrice sdk.Dec, quantity int64) (sdk.Dec, error) {
 	money := actualPrice.Mul(sdk.NewDec(quantity)).Add(sdk.NewDec(types.ExtraFrozenMoney)).Ceil()
 	if money.GT(sdk.NewDec(types.MaxOrderAmount)) {
 		return money, fmt.Errorf("exchange amount exceeds max int64 ")
@@ -459,12 +726,8 @@ func handleMsgModifyPricePrecision(ctx sdk.Context, msg types.MsgModifyPricePrec
 	}
 
 	oldInfo, _ := k.GetMarketInfo(ctx, msg.TradingPair)
-	info := types.MarketInfo{
-		Stock:             oldInfo.Stock,
-		Money:             oldInfo.Money,
-		PricePrecision:    msg.PricePrecision,
-		LastExecutedPrice: oldInfo.LastExecutedPrice,
-	}
+	info := oldInfo
+	info.PricePrecision = msg.PricePrecision
 	if err := k.SetMarket(ctx, info); err != nil {
 		return err.Result()
 	}
@@ -504,3 +767,230 @@ func checkMsgModifyPricePrecision(ctx sdk.Context, msg types.MsgModifyPricePreci
 
 	return nil
 }
+
+func handleMsgHaltMarket(ctx sdk.Context, msg types.MsgHaltMarket, k keepers.Keeper) sdk.Result {
+	info, err := checkMarketOperation(ctx, k, msg.TradingPair, msg.Sender)
+	if err != nil {
+		return err.Result()
+	}
+	if info.Halted {
+		return types.ErrMarketHalted(msg.TradingPair).Result()
+	}
+
+	info.Halted = true
+	if err := k.SetMarket(ctx, info); err != nil {
+		return err.Result()
+	}
+
+	sendMarketHaltMsg(ctx, k, info, msg.Sender.String())
+	ctx.EventManager().EmitEvents(sdk.Events{
+		newMarketHaltEvent(EventTypeKeyHaltMarket, info),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func handleMsgResumeMarket(ctx sdk.Context, msg types.MsgResumeMarket, k keepers.Keeper) sdk.Result {
+	info, err := checkMarketOperation(ctx, k, msg.TradingPair, msg.Sender)
+	if err != nil {
+		return err.Result()
+	}
+	if !info.Halted {
+		return types.ErrMarketNotHalted(msg.TradingPair).Result()
+	}
+
+	// the circuit breaker restarts from the current price, or it would halt the market again at once
+	info.Halted = false
+	info.BreakerRefPrice = info.LastExecutedPrice
+	info.BreakerRefTime = ctx.BlockHeader().Time.Unix()
+	if err := k.SetMarket(ctx, info); err != nil {
+		return err.Result()
+	}
+
+	sendMarketHaltMsg(ctx, k, info, msg.Sender.String())
+	ctx.EventManager().EmitEvents(sdk.Events{
+		newMarketHaltEvent(EventTypeKeyResumeMarket, info),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+// only the stock's issuer can halt or resume a market
+func checkMarketOperation(ctx sdk.Context, k keepers.Keeper, symbol string, sender sdk.AccAddress) (types.MarketInfo, sdk.Error) {
+	info, err := k.GetMarketInfo(ctx, symbol)
+	if err != nil {
+		return info, types.ErrInvalidMarket(err.Error())
+	}
+	if !k.IsTokenIssuer(ctx, info.Stock, sender) {
+		return info, types.ErrInvalidTokenIssuer()
+	}
+	return info, nil
+}
+
+func newMarketHaltEvent(eventType string, info types.MarketInfo) sdk.Event {
+	return sdk.NewEvent(
+		eventType,
+		sdk.NewAttribute(AttributeKeyTradingPair, info.GetSymbol()),
+		sdk.NewAttribute(AttributeKeyLastExecutePrice, info.LastExecutedPrice.String()),
+	)
+}
+
+func sendMarketHaltMsg(ctx sdk.Context, k keepers.Keeper, info types.MarketInfo, operator string) {
+	if k.IsSubScribed(types.Topic) {
+		msgqueue.FillMsgs(ctx, types.HaltMarketInfoKey, types.MarketHaltInfo{
+			TradingPair:       info.GetSymbol(),
+			Halted:            info.Halted,
+			Operator:          operator,
+			LastExecutedPrice: info.LastExecutedPrice,
+		})
+	}
+}
+
+func handleMsgCreateConditionalOrder(ctx sdk.Context, msg types.MsgCreateConditionalOrder, keeper keepers.Keeper) sdk.Result {
+	seq, err := keeper.QuerySeqWithAddr(ctx, msg.Sender)
+	if err != nil {
+		return err.Result()
+	}
+	if err := checkMsgCreateConditionalOrder(ctx, keeper, msg, seq); err != nil {
+		return err.Result()
+	}
+	// the order freezes nothing before it is triggered, so the fee of an order without deals is charged now
+	marketParams := keeper.GetParams(ctx)
+	if err := keeper.SubtractFeeAndCollectFee(ctx, msg.Sender, marketParams.FeeForZeroDeal); err != nil {
+		return err.Result()
+	}
+
+	order := types.ConditionalOrder{
+		Sender:         msg.Sender,
+		Sequence:       seq,
+		Identify:       msg.Identify,
+		TradingPair:    msg.TradingPair,
+		OrderType:      msg.OrderType,
+		PricePrecision: msg.PricePrecision,
+		Price:          msg.Price,
+		Quantity:       msg.Quantity,
+		Side:           msg.Side,
+		TimeInForce:    msg.TimeInForce,
+		ExistBlocks:    msg.ExistBlocks,
+		ConditionType:  msg.ConditionType,
+		TriggerPrice:   sdk.NewDec(msg.TriggerPrice).Quo(sdk.NewDec(int64(math.Pow10(int(msg.PricePrecision))))),
+		Height:         ctx.BlockHeight(),
+		ExpireHeight:   ctx.BlockHeight() + marketParams.GTEOrderLifetime,
+
+		SelfTradePrevention: msg.SelfTradePrevention,
+	}
+	keepers.NewConditionalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc).Add(ctx, &order)
+	sendCreateConditionalOrderMsg(ctx, keeper, order)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyCreateConditionalOrder,
+			sdk.NewAttribute(AttributeKeyOrder, order.OrderID()),
+			sdk.NewAttribute(AttributeKeyTradingPair, order.TradingPair),
+			sdk.NewAttribute(AttributeKeyTriggerPrice, order.TriggerPrice.String()),
+			sdk.NewAttribute(AttributeKeyHeight, strconv.FormatInt(order.Height, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+// The coins of the order are not checked here, because they are frozen only when the order is triggered
+func checkMsgCreateConditionalOrder(ctx sdk.Context, keeper keepers.Keeper, msg types.MsgCreateConditionalOrder, seq uint64) sdk.Error {
+	if _, _, err := getDenomAndOrderAmount(msg.ToMsgCreateOrder()); err != nil {
+		return err
+	}
+	orderID := types.AssemblyOrderID(msg.Sender.String(), seq, msg.Identify)
+	globalKeeper := keepers.NewGlobalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+	conditionalKeeper := keepers.NewConditionalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+	if globalKeeper.QueryOrder(ctx, orderID) != nil || conditionalKeeper.QueryOrder(ctx, orderID) != nil {
+		return types.ErrOrderAlreadyExist(orderID)
+	}
+	return checkOrderInMarket(ctx, keeper, msg.ToMsgCreateOrder())
+}
+
+func sendCreateConditionalOrderMsg(ctx sdk.Context, keeper keepers.Keeper, order types.ConditionalOrder) {
+	if keeper.IsSubScribed(types.Topic) {
+		info := types.CreateConditionalOrderInfo{
+			OrderID:       order.OrderID(),
+			Sender:        order.Sender.String(),
+			TradingPair:   order.TradingPair,
+			OrderType:     order.OrderType,
+			Price:         sdk.NewDec(order.Price).Quo(sdk.NewDec(int64(math.Pow10(int(order.PricePrecision))))),
+			Quantity:      order.Quantity,
+			Side:          order.Side,
+			TimeInForce:   order.TimeInForce,
+			Height:        order.Height,
+			ConditionType: order.ConditionType,
+			TriggerPrice:  order.TriggerPrice,
+
+			SelfTradePrevention: order.SelfTradePrevention,
+		}
+		msgqueue.FillMsgs(ctx, types.CreateConditionalOrderInfoKey, info)
+	}
+}
+
+func handleMsgCancelConditionalOrder(ctx sdk.Context, msg types.MsgCancelConditionalOrder, keeper keepers.Keeper) sdk.Result {
+	conditionalKeeper := keepers.NewConditionalOrderKeeper(keeper.GetMarketKey(), types.ModuleCdc)
+	order := conditionalKeeper.QueryOrder(ctx, msg.OrderID)
+	if order == nil {
+		return types.ErrOrderNotFound(msg.OrderID).Result()
+	}
+	if !bytes.Equal(order.Sender, msg.Sender) {
+		return types.ErrNotMatchSender("only order's sender can cancel this order").Result()
+	}
+	if err := conditionalKeeper.Remove(ctx, order); err != nil {
+		return err.Result()
+	}
+	sendCancelConditionalOrderMsg(ctx, keeper, order, types.CancelOrderByManual)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			EventTypeKeyCancelConditionalOrder,
+			sdk.NewAttribute(AttributeKeyOrder, order.OrderID()),
+			sdk.NewAttribute(AttributeKeyDelOrderReason, types.CancelOrderByManual),
+			sdk.NewAttribute(AttributeKeyDelOrderHeight, strconv.Itoa(int(ctx.BlockHeight()))),
+			sdk.NewAttribute(AttributeKeyTradingPair, order.TradingPair),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+	return sdk.Result{
+		Events: ctx.EventManager().Events(),
+	}
+}
+
+func sendCancelConditionalOrderMsg(ctx sdk.Context, keeper keepers.Keeper, order *types.ConditionalOrder, delReason string) {
+	if keeper.IsSubScribed(types.Topic) {
+		info := types.CancelConditionalOrderInfo{
+			OrderID:     order.OrderID(),
+			TradingPair: order.TradingPair,
+			Height:      ctx.BlockHeight(),
+			DelReason:   delReason,
+		}
+		msgqueue.FillMsgs(ctx, types.CancelConditionalOrderInfoKey, info)
+	}
+}
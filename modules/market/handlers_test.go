@@ -280,7 +280,7 @@ func prepareMockInput(t *testing.T, addrForbid, tokenForbid bool) testInput {
 	bk := prepareBankxKeeper(keys, cdc, ctx)
 	paramsKeeper := params.NewKeeper(cdc, keys.keyParams, keys.tkeyParams, params.DefaultCodespace)
 	mk := keepers.NewKeeper(keys.marketKey, ak, bk, cdc,
-		msgqueue.NewProducer(nil), paramsKeeper.Subspace(types.StoreKey), akp, &mockKeeper{})
+		msgqueue.NewProducer(nil), paramsKeeper.Subspace(types.StoreKey), akp, &mockKeeper{}, nil)
 	types.RegisterCodec(cdc)
 
 	parameters := types.DefaultParams()
@@ -1519,3 +1519,465 @@ func (m *MockQueryMarketInfoAndParams) GetMarketVolume(ctx sdk.Context, stock, m
 func (m *MockQueryMarketInfoAndParams) GetMarketInfo(ctx sdk.Context, tradingPair string) (types.MarketInfo, error) {
 	return types.MarketInfo{}, nil
 }
+
+func TestConditionalOrder(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	ret := createCetMarket(input, stock, 0)
+	require.Equal(t, true, ret.IsOK(), "create market should succeed")
+
+	msg := types.MsgCreateConditionalOrder{
+		Sender:         haveCetAddress,
+		Identify:       1,
+		TradingPair:    GetSymbol(stock, dex.CET),
+		OrderType:      types.LimitOrder,
+		PricePrecision: 8,
+		Price:          100,
+		Quantity:       10000000,
+		Side:           types.SELL,
+		TimeInForce:    types.GTE,
+		ConditionType:  types.StopLimit,
+		TriggerPrice:   120,
+
+		SelfTradePrevention: types.SelfTradeCancelOldest,
+	}
+	seq, err := input.mk.QuerySeqWithAddr(input.ctx, msg.Sender)
+	require.Nil(t, err)
+	orderID := types.AssemblyOrderID(msg.Sender.String(), seq, msg.Identify)
+
+	// nothing is frozen before the order is triggered, but the creation fee is charged
+	oldCoin := input.getCoinFromAddr(haveCetAddress, stock)
+	oldCet := input.getCoinFromAddr(haveCetAddress, dex.CET)
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "create conditional order should succeed ; ", ret.Log)
+	require.Equal(t, oldCoin, input.getCoinFromAddr(haveCetAddress, stock))
+	fee := sdk.NewCoin(dex.CET, sdk.NewInt(input.mk.GetParams(input.ctx).FeeForZeroDeal))
+	require.Equal(t, oldCet.Sub(fee), input.getCoinFromAddr(haveCetAddress, dex.CET))
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, types.CodeOrderAlreadyExist, ret.Code)
+	// a normal order can't take the ID of a conditional order either
+	ret = input.handler(input.ctx, msg.ToMsgCreateOrder())
+	require.Equal(t, types.CodeOrderAlreadyExist, ret.Code)
+
+	conditionalKeeper := keepers.NewConditionalOrderKeeper(input.keys.marketKey, input.cdc)
+	glk := keepers.NewGlobalOrderKeeper(input.keys.marketKey, input.cdc)
+	require.NotNil(t, conditionalKeeper.QueryOrder(input.ctx, orderID))
+
+	// a stop-limit sell order is not triggered by a rising price
+	require.Equal(t, 10, triggerConditionalOrders(input.ctx, input.mk, msg.TradingPair, sdk.NewDecWithPrec(130, 8), 10))
+	require.NotNil(t, conditionalKeeper.QueryOrder(input.ctx, orderID))
+	require.Nil(t, glk.QueryOrder(input.ctx, orderID))
+
+	// the order waits for the next blocks when no more orders can be triggered in this block
+	require.Equal(t, 0, triggerConditionalOrders(input.ctx, input.mk, msg.TradingPair, sdk.NewDecWithPrec(120, 8), 0))
+	require.NotNil(t, conditionalKeeper.QueryOrder(input.ctx, orderID))
+	require.Equal(t, []string{msg.TradingPair}, conditionalKeeper.GetTriggerPendingMarkets(input.ctx))
+	mi, _ := input.mk.GetMarketInfo(input.ctx, msg.TradingPair)
+	mi.LastExecutedPrice = sdk.NewDecWithPrec(120, 8)
+	input.mk.SetMarket(input.ctx, mi)
+	triggerPendingConditionalOrders(input.ctx, input.mk, 10)
+	require.Nil(t, conditionalKeeper.QueryOrder(input.ctx, orderID))
+	require.Equal(t, 0, len(conditionalKeeper.GetTriggerPendingMarkets(input.ctx)))
+	order := glk.QueryOrder(input.ctx, orderID)
+	require.NotNil(t, order)
+	require.Equal(t, true, isSameOrderAndMsg(order, msg.ToMsgCreateOrder()))
+	require.Equal(t, types.SelfTradeCancelOldest, order.SelfTradePrevention)
+	frozen := sdk.NewCoin(stock, sdk.NewInt(msg.Quantity))
+	require.Equal(t, true, IsEqual(oldCoin, input.getCoinFromAddr(haveCetAddress, stock), frozen))
+
+	// the order is dropped when its sender can not afford it at trigger time
+	msg.Identify = 2
+	msg.Quantity = issueAmount * 10
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "create conditional order should succeed ; ", ret.Log)
+	orderID = types.AssemblyOrderID(msg.Sender.String(), seq, msg.Identify)
+	triggerConditionalOrders(input.ctx, input.mk, msg.TradingPair, sdk.NewDecWithPrec(100, 8), 10)
+	require.Nil(t, conditionalKeeper.QueryOrder(input.ctx, orderID))
+	require.Nil(t, glk.QueryOrder(input.ctx, orderID))
+
+	// an expired order is removed instead of being triggered
+	msg.Identify = 4
+	msg.Quantity = 10000000
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "create conditional order should succeed ; ", ret.Log)
+	orderID = types.AssemblyOrderID(msg.Sender.String(), seq, msg.Identify)
+	expiredCtx := input.ctx.WithBlockHeight(conditionalKeeper.QueryOrder(input.ctx, orderID).ExpireHeight)
+	triggerConditionalOrders(expiredCtx, input.mk, msg.TradingPair, sdk.NewDecWithPrec(100, 8), 10)
+	require.Nil(t, conditionalKeeper.QueryOrder(input.ctx, orderID))
+	require.Nil(t, glk.QueryOrder(input.ctx, orderID))
+
+	// cancel a conditional order
+	msg.Identify = 3
+	msg.Quantity = 10000000
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "create conditional order should succeed ; ", ret.Log)
+	orderID = types.AssemblyOrderID(msg.Sender.String(), seq, msg.Identify)
+	ret = input.handler(input.ctx, types.MsgCancelConditionalOrder{Sender: notHaveCetAddress, OrderID: orderID})
+	require.Equal(t, types.CodeNotMatchSender, ret.Code)
+	ret = input.handler(input.ctx, types.MsgCancelConditionalOrder{Sender: haveCetAddress, OrderID: orderID})
+	require.Equal(t, true, ret.IsOK(), "cancel conditional order should succeed ; ", ret.Log)
+	require.Nil(t, conditionalKeeper.QueryOrder(input.ctx, orderID))
+	ret = input.handler(input.ctx, types.MsgCancelConditionalOrder{Sender: haveCetAddress, OrderID: orderID})
+	require.Equal(t, types.CodeOrderNotFound, ret.Code)
+}
+
+func TestPostOnlyAndFOKOrder(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(1, 0))
+	input.mk.SetOrderCleanTime(input.ctx, 1)
+	ret := createCetMarket(input, stock, 0)
+	require.Equal(t, true, ret.IsOK(), "create market should succeed")
+	glk := keepers.NewGlobalOrderKeeper(input.keys.marketKey, input.cdc)
+	seq, err := input.mk.QuerySeqWithAddr(input.ctx, haveCetAddress)
+	require.Nil(t, err)
+
+	msg := types.MsgCreateOrder{
+		Sender:         haveCetAddress,
+		Identify:       1,
+		TradingPair:    GetSymbol(stock, dex.CET),
+		OrderType:      types.LimitOrder,
+		PricePrecision: 8,
+		Price:          100,
+		Quantity:       10000000,
+		Side:           types.SELL,
+		TimeInForce:    types.GTE,
+	}
+	input.ctx = input.ctx.WithBlockHeight(10)
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "create GTE order should succeed ; ", ret.Log)
+	sellOrderID := types.AssemblyOrderID(msg.Sender.String(), seq, msg.Identify)
+	EndBlocker(input.ctx, input.mk)
+
+	// a post-only order which would take liquidity and an FOK order which could not be fully filled are canceled
+	oldCet := input.getCoinFromAddr(haveCetAddress, dex.CET)
+	input.ctx = input.ctx.WithBlockHeight(11)
+	msg.Side = types.BUY
+	msg.Identify = 2
+	msg.TimeInForce = types.PostOnly
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "create post-only order should succeed ; ", ret.Log)
+	msg.Identify = 3
+	msg.Quantity = 20000000
+	msg.TimeInForce = types.FOK
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "create FOK order should succeed ; ", ret.Log)
+	EndBlocker(input.ctx, input.mk)
+	require.Nil(t, glk.QueryOrder(input.ctx, types.AssemblyOrderID(msg.Sender.String(), seq, 2)))
+	require.Nil(t, glk.QueryOrder(input.ctx, types.AssemblyOrderID(msg.Sender.String(), seq, 3)))
+	require.EqualValues(t, 10000000, glk.QueryOrder(input.ctx, sellOrderID).LeftStock)
+	// the frozen money is returned, and only the fee for zero deal is charged
+	zeroDealFee := dex.NewCetCoin(2 * input.mk.GetParams(input.ctx).FeeForZeroDeal)
+	require.Equal(t, true, IsEqual(oldCet, input.getCoinFromAddr(haveCetAddress, dex.CET), zeroDealFee))
+
+	// an FOK order which can be fully filled is matched, and a post-only order rests in the order book
+	input.ctx = input.ctx.WithBlockHeight(12)
+	msg.Identify = 4
+	msg.Quantity = 10000000
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "create FOK order should succeed ; ", ret.Log)
+	msg.Identify = 5
+	msg.Price = 90
+	msg.TimeInForce = types.PostOnly
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "create post-only order should succeed ; ", ret.Log)
+	EndBlocker(input.ctx, input.mk)
+	require.Nil(t, glk.QueryOrder(input.ctx, types.AssemblyOrderID(msg.Sender.String(), seq, 4)))
+	require.Nil(t, glk.QueryOrder(input.ctx, sellOrderID))
+	order := glk.QueryOrder(input.ctx, types.AssemblyOrderID(msg.Sender.String(), seq, 5))
+	require.NotNil(t, order)
+	require.EqualValues(t, input.mk.GetParams(input.ctx).GTEOrderLifetime, order.ExistBlocks)
+	mkInfo, mkErr := input.mk.GetMarketInfo(input.ctx, msg.TradingPair)
+	require.Nil(t, mkErr)
+	require.Equal(t, sdk.NewDecWithPrec(100, 8), mkInfo.LastExecutedPrice)
+}
+
+func TestBatchCreateAndCancelOrders(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	ret := createCetMarket(input, stock, 0)
+	require.Equal(t, true, ret.IsOK(), "create market should succeed")
+	glk := keepers.NewGlobalOrderKeeper(input.keys.marketKey, input.cdc)
+	seq, err := input.mk.QuerySeqWithAddr(input.ctx, haveCetAddress)
+	require.Nil(t, err)
+
+	order := types.MsgCreateOrder{
+		Sender:         haveCetAddress,
+		TradingPair:    GetSymbol(stock, dex.CET),
+		OrderType:      types.LimitOrder,
+		PricePrecision: 8,
+		Price:          100,
+		Quantity:       10000000,
+		Side:           types.SELL,
+		TimeInForce:    types.GTE,
+	}
+	msg := types.MsgBatchCreateOrders{Sender: haveCetAddress}
+	for i := 1; i <= 3; i++ {
+		order.Identify = byte(i)
+		msg.Orders = append(msg.Orders, order)
+	}
+	oldCoin := input.getCoinFromAddr(haveCetAddress, stock)
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "batch create orders should succeed ; ", ret.Log)
+	for i := 1; i <= 3; i++ {
+		require.NotNil(t, glk.QueryOrder(input.ctx, types.AssemblyOrderID(haveCetAddress.String(), seq, byte(i))))
+	}
+	frozen := sdk.NewCoin(stock, sdk.NewInt(3*order.Quantity))
+	require.Equal(t, true, IsEqual(oldCoin, input.getCoinFromAddr(haveCetAddress, stock), frozen))
+
+	// no order is created if any of them fails
+	msg.Orders[0].Identify = 4
+	msg.Orders[1].Identify = 5
+	msg.Orders[1].Quantity = issueAmount * 10
+	msg.Orders[2].Identify = 6
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, types.CodeInsufficientCoin, ret.Code)
+	for i := 4; i <= 6; i++ {
+		require.Nil(t, glk.QueryOrder(input.ctx, types.AssemblyOrderID(haveCetAddress.String(), seq, byte(i))))
+	}
+	require.Equal(t, true, IsEqual(oldCoin, input.getCoinFromAddr(haveCetAddress, stock), frozen))
+
+	// an order can't take the ID of a conditional order
+	conditionalMsg := types.MsgCreateConditionalOrder{
+		Sender:         haveCetAddress,
+		Identify:       6,
+		TradingPair:    order.TradingPair,
+		OrderType:      types.LimitOrder,
+		PricePrecision: 8,
+		Price:          100,
+		Quantity:       10000000,
+		Side:           types.SELL,
+		TimeInForce:    types.GTE,
+		ConditionType:  types.StopLimit,
+		TriggerPrice:   90,
+	}
+	ret = input.handler(input.ctx, conditionalMsg)
+	require.Equal(t, true, ret.IsOK(), "create conditional order should succeed ; ", ret.Log)
+	msg.Orders[1].Quantity = order.Quantity
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, types.CodeOrderAlreadyExist, ret.Code)
+	for i := 4; i <= 6; i++ {
+		require.Nil(t, glk.QueryOrder(input.ctx, types.AssemblyOrderID(haveCetAddress.String(), seq, byte(i))))
+	}
+	require.Equal(t, true, IsEqual(oldCoin, input.getCoinFromAddr(haveCetAddress, stock), frozen))
+
+	// no order is canceled if any of them can not be canceled
+	orderID1 := types.AssemblyOrderID(haveCetAddress.String(), seq, 1)
+	cancelMsg := types.MsgCancelOrders{
+		Sender:   haveCetAddress,
+		OrderIDs: []string{orderID1, types.AssemblyOrderID(haveCetAddress.String(), seq, 4)},
+	}
+	ret = input.handler(input.ctx, cancelMsg)
+	require.Equal(t, types.CodeOrderNotFound, ret.Code)
+	require.NotNil(t, glk.QueryOrder(input.ctx, orderID1))
+
+	cancelMsg.OrderIDs = []string{orderID1, orderID1}
+	ret = input.handler(input.ctx, cancelMsg)
+	require.Equal(t, true, ret.IsOK(), "cancel orders should succeed ; ", ret.Log)
+	require.Nil(t, glk.QueryOrder(input.ctx, orderID1))
+	require.Equal(t, 2, len(glk.GetOrdersFromUser(input.ctx, haveCetAddress.String())))
+
+	// only the sender of the orders can cancel them
+	cancelMsg.Sender = notHaveCetAddress
+	cancelMsg.OrderIDs = []string{types.AssemblyOrderID(haveCetAddress.String(), seq, 2)}
+	ret = input.handler(input.ctx, cancelMsg)
+	require.Equal(t, types.CodeNotMatchSender, ret.Code)
+
+	// cancel all the orders in a trading pair
+	cancelMsg.Sender = haveCetAddress
+	cancelMsg.OrderIDs = nil
+	cancelMsg.TradingPair = order.TradingPair
+	ret = input.handler(input.ctx, cancelMsg)
+	require.Equal(t, true, ret.IsOK(), "cancel orders should succeed ; ", ret.Log)
+	require.Equal(t, 0, len(glk.GetOrdersFromUser(input.ctx, haveCetAddress.String())))
+	require.Equal(t, oldCoin, input.getCoinFromAddr(haveCetAddress, stock))
+}
+
+func TestModifyOrder(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	ret := createCetMarket(input, stock, 0)
+	require.Equal(t, true, ret.IsOK(), "create market should succeed")
+	glk := keepers.NewGlobalOrderKeeper(input.keys.marketKey, input.cdc)
+	orderKeeper := keepers.NewOrderKeeper(input.keys.marketKey, GetSymbol(stock, dex.CET), input.cdc)
+	seq, err := input.mk.QuerySeqWithAddr(input.ctx, haveCetAddress)
+	require.Nil(t, err)
+
+	createMsg := types.MsgCreateOrder{
+		Sender:         haveCetAddress,
+		Identify:       1,
+		TradingPair:    GetSymbol(stock, dex.CET),
+		OrderType:      types.LimitOrder,
+		PricePrecision: 8,
+		Price:          100,
+		Quantity:       10000000,
+		Side:           types.SELL,
+		TimeInForce:    types.GTE,
+	}
+	input.ctx = input.ctx.WithBlockHeight(10)
+	oldCoin := input.getCoinFromAddr(haveCetAddress, stock)
+	ret = input.handler(input.ctx, createMsg)
+	require.Equal(t, true, ret.IsOK(), "create order should succeed ; ", ret.Log)
+	orderID := types.AssemblyOrderID(haveCetAddress.String(), seq, 1)
+	existBlocks := glk.QueryOrder(input.ctx, orderID).ExistBlocks
+
+	// reducing the quantity keeps the height
+	input.ctx = input.ctx.WithBlockHeight(20)
+	msg := types.MsgModifyOrder{
+		Sender:         haveCetAddress,
+		OrderID:        orderID,
+		PricePrecision: 8,
+		Quantity:       4000000,
+	}
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "modify order should succeed ; ", ret.Log)
+	order := glk.QueryOrder(input.ctx, orderID)
+	require.EqualValues(t, 4000000, order.LeftStock)
+	require.EqualValues(t, 4000000, order.Freeze)
+	require.EqualValues(t, 10, order.Height)
+	frozen := sdk.NewCoin(stock, sdk.NewInt(4000000))
+	require.Equal(t, true, IsEqual(oldCoin, input.getCoinFromAddr(haveCetAddress, stock), frozen))
+
+	msg.Quantity = 5000000
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, types.CodeInvalidModification, ret.Code)
+	msg.Quantity = 4000000
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, types.CodeInvalidModification, ret.Code)
+
+	// only the sender can modify the order
+	msg.Sender = notHaveCetAddress
+	msg.Price = 120
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, types.CodeNotMatchSender, ret.Code)
+
+	// changing the price re-queues the order at the current height, and it still expires at the same height
+	msg.Sender = haveCetAddress
+	msg.Quantity = 0
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "modify order should succeed ; ", ret.Log)
+	order = glk.QueryOrder(input.ctx, orderID)
+	require.Equal(t, sdk.NewDecWithPrec(120, 8), order.Price)
+	require.EqualValues(t, 20, order.Height)
+	require.EqualValues(t, 10+existBlocks, order.Height+order.ExistBlocks)
+	require.EqualValues(t, 0, len(orderKeeper.GetOrdersAtHeight(input.ctx, 10)))
+	require.EqualValues(t, 1, len(orderKeeper.GetOrdersAtHeight(input.ctx, 20)))
+	require.Equal(t, true, IsEqual(oldCoin, input.getCoinFromAddr(haveCetAddress, stock), frozen))
+	// the feature fee is still charged from the height where the order was created
+	require.EqualValues(t, 10, order.FeatureFeeHeight)
+
+	// the money amount of a sell order is also limited
+	msg.Price = 1e12
+	msg.PricePrecision = 0
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, types.CodeInvalidOrderAmount, ret.Code)
+	msg.PricePrecision = 8
+	msg.Price = 120
+
+	// the frozen money of a buy order changes with its price
+	createMsg.Identify = 2
+	createMsg.Side = types.BUY
+	ret = input.handler(input.ctx, createMsg)
+	require.Equal(t, true, ret.IsOK(), "create order should succeed ; ", ret.Log)
+	orderID = types.AssemblyOrderID(haveCetAddress.String(), seq, 2)
+	oldCet := input.getCoinFromAddr(haveCetAddress, dex.CET)
+	msg.OrderID = orderID
+	msg.Price = 300
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "modify order should succeed ; ", ret.Log)
+	require.EqualValues(t, 30, glk.QueryOrder(input.ctx, orderID).Freeze)
+	require.Equal(t, true, IsEqual(oldCet, input.getCoinFromAddr(haveCetAddress, dex.CET), dex.NewCetCoin(20)))
+
+	msg.Price = 0
+	msg.Quantity = 5000000
+	ret = input.handler(input.ctx, msg)
+	require.Equal(t, true, ret.IsOK(), "modify order should succeed ; ", ret.Log)
+	require.EqualValues(t, 15, glk.QueryOrder(input.ctx, orderID).Freeze)
+	require.Equal(t, true, IsEqual(oldCet, input.getCoinFromAddr(haveCetAddress, dex.CET), dex.NewCetCoin(5)))
+}
+
+func TestHaltAndResumeMarket(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	ret := createCetMarket(input, stock, 0)
+	require.Equal(t, true, ret.IsOK(), "create market should succeed")
+	symbol := GetSymbol(stock, dex.CET)
+
+	orderMsg := types.MsgCreateOrder{
+		Sender:         haveCetAddress,
+		Identify:       1,
+		TradingPair:    symbol,
+		OrderType:      types.LimitOrder,
+		PricePrecision: 8,
+		Price:          100,
+		Quantity:       10000000,
+		Side:           types.SELL,
+		TimeInForce:    types.GTE,
+	}
+
+	// only the stock's issuer can halt the market
+	haltMsg := types.MsgHaltMarket{Sender: notHaveCetAddress, TradingPair: symbol}
+	ret = input.handler(input.ctx, haltMsg)
+	require.Equal(t, types.CodeInvalidTokenIssuer, ret.Code)
+	haltMsg.Sender = haveCetAddress
+	ret = input.handler(input.ctx, haltMsg)
+	require.Equal(t, true, ret.IsOK(), "halt market should succeed ; ", ret.Log)
+	ret = input.handler(input.ctx, haltMsg)
+	require.Equal(t, types.CodeMarketHalted, ret.Code)
+
+	ret = input.handler(input.ctx, orderMsg)
+	require.Equal(t, types.CodeMarketHalted, ret.Code)
+
+	// other changes of the market keep it halted
+	ret = input.handler(input.ctx, types.MsgModifyPricePrecision{Sender: haveCetAddress, TradingPair: symbol, PricePrecision: 10})
+	require.Equal(t, true, ret.IsOK(), "modify price precision should succeed ; ", ret.Log)
+	info, err := input.mk.GetMarketInfo(input.ctx, symbol)
+	require.Nil(t, err)
+	require.True(t, info.Halted)
+
+	resumeMsg := types.MsgResumeMarket{Sender: notHaveCetAddress, TradingPair: symbol}
+	ret = input.handler(input.ctx, resumeMsg)
+	require.Equal(t, types.CodeInvalidTokenIssuer, ret.Code)
+	resumeMsg.Sender = haveCetAddress
+	ret = input.handler(input.ctx, resumeMsg)
+	require.Equal(t, true, ret.IsOK(), "resume market should succeed ; ", ret.Log)
+	ret = input.handler(input.ctx, resumeMsg)
+	require.Equal(t, types.CodeMarketNotHalted, ret.Code)
+
+	ret = input.handler(input.ctx, orderMsg)
+	require.Equal(t, true, ret.IsOK(), "create order should succeed ; ", ret.Log)
+}
+
+func TestHaltMarketWithImmediateOrder(t *testing.T) {
+	input := prepareMockInput(t, false, false)
+	input.ctx = input.ctx.WithBlockTime(time.Unix(1, 0)).WithBlockHeight(10)
+	input.mk.SetOrderCleanTime(input.ctx, 1)
+	ret := createCetMarket(input, stock, 0)
+	require.Equal(t, true, ret.IsOK(), "create market should succeed")
+	symbol := GetSymbol(stock, dex.CET)
+	glk := keepers.NewGlobalOrderKeeper(input.keys.marketKey, input.cdc)
+	seq, err := input.mk.QuerySeqWithAddr(input.ctx, haveCetAddress)
+	require.Nil(t, err)
+
+	// an IOC order and the halt of its market in the same block
+	oldCoin := input.getCoinFromAddr(haveCetAddress, stock)
+	orderMsg := types.MsgCreateOrder{
+		Sender:         haveCetAddress,
+		Identify:       1,
+		TradingPair:    symbol,
+		OrderType:      types.LimitOrder,
+		PricePrecision: 8,
+		Price:          100,
+		Quantity:       10000000,
+		Side:           types.SELL,
+		TimeInForce:    types.IOC,
+	}
+	ret = input.handler(input.ctx, orderMsg)
+	require.Equal(t, true, ret.IsOK(), "create IOC order should succeed ; ", ret.Log)
+	ret = input.handler(input.ctx, types.MsgHaltMarket{Sender: haveCetAddress, TradingPair: symbol})
+	require.Equal(t, true, ret.IsOK(), "halt market should succeed ; ", ret.Log)
+	EndBlocker(input.ctx, input.mk)
+
+	// the IOC order is removed with its coins unfrozen, and doesn't rest in the order book after the resume
+	input.ctx = input.ctx.WithBlockHeight(11)
+	ret = input.handler(input.ctx, types.MsgResumeMarket{Sender: haveCetAddress, TradingPair: symbol})
+	require.Equal(t, true, ret.IsOK(), "resume market should succeed ; ", ret.Log)
+	EndBlocker(input.ctx, input.mk)
+	require.Nil(t, glk.QueryOrder(input.ctx, types.AssemblyOrderID(haveCetAddress.String(), seq, 1)))
+	require.Equal(t, oldCoin, input.getCoinFromAddr(haveCetAddress, stock))
+}
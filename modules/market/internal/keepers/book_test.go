@@ -0,0 +1,84 @@
+package keepers_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/market/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/market/internal/types"
+	"github.com/coinexchain/cet-sdk/testapp"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+func TestExportAndImportBook(t *testing.T) {
+	testApp := testapp.NewTestApp()
+	ctx := testApp.NewCtx().WithBlockHeight(10)
+	symbol := dex.GetSymbol("eth", dex.CET)
+	info := types.MarketInfo{
+		Stock:             "eth",
+		Money:             dex.CET,
+		PricePrecision:    8,
+		LastExecutedPrice: sdk.NewDec(12),
+		BreakerRefPrice:   sdk.ZeroDec(),
+	}
+	addr := sdk.AccAddress("addr1_______________")
+	order := &types.Order{
+		Sender:           addr,
+		Sequence:         1,
+		TradingPair:      symbol,
+		OrderType:        types.LimitOrder,
+		Price:            sdk.NewDec(10),
+		Quantity:         100,
+		Side:             types.BUY,
+		TimeInForce:      types.GTE,
+		Height:           5,
+		ExistBlocks:      1000,
+		FrozenCommission: 3,
+		LeftStock:        100,
+		Freeze:           1000,
+	}
+	mk := testApp.MarketKeeper
+	mk.SetMarket(ctx, info)
+	require.Nil(t, mk.SetOrder(ctx, order))
+	keepers.NewDelistKeeper(mk.GetMarketKey()).AddDelistRequest(ctx, 300, symbol)
+
+	querier := keepers.NewQuerier(mk)
+	reqBytes := testApp.Cdc.MustMarshalJSON(keepers.NewQueryMarketParam(symbol))
+	resBytes, err := querier(ctx, []string{keepers.QueryExportBook}, abci.RequestQuery{Data: reqBytes})
+	require.Nil(t, err)
+	var book types.BookSnapshot
+	testApp.Cdc.MustUnmarshalJSON(resBytes, &book)
+	require.EqualValues(t, types.BookSnapshotVersion, book.Version)
+	require.EqualValues(t, 10, book.Height)
+	require.Equal(t, info, book.MarketInfo)
+	require.Equal(t, []*types.Order{order}, book.Orders)
+	require.EqualValues(t, 300, book.DelistTime)
+
+	reqBytes = testApp.Cdc.MustMarshalJSON(keepers.NewQueryMarketParam("btc/cet"))
+	_, err = querier(ctx, []string{keepers.QueryExportBook}, abci.RequestQuery{Data: reqBytes})
+	require.Equal(t, types.CodeInvalidMarket, err.Code())
+
+	// the coins frozen by the order must be frozen in the test network
+	testApp = testapp.NewTestApp()
+	ctx = testApp.NewCtx()
+	mk = testApp.MarketKeeper
+	err = mk.ImportBook(ctx, book)
+	require.Equal(t, types.CodeInvalidBookSnapshot, err.Code())
+	testApp.BankxKeeper.MockAddFrozenCoins(ctx, addr, dex.NewCetCoins(1002))
+	err = mk.ImportBook(ctx, book)
+	require.Equal(t, types.CodeInvalidBookSnapshot, err.Code())
+	testApp.BankxKeeper.MockAddFrozenCoins(ctx, addr, dex.NewCetCoins(1))
+	require.Nil(t, mk.ImportBook(ctx, book))
+
+	require.True(t, mk.IsMarketExist(ctx, symbol))
+	require.Equal(t, []*types.Order{order}, mk.GetAllOrders(ctx))
+	require.EqualValues(t, 300, keepers.NewDelistKeeper(mk.GetMarketKey()).GetDelistRequestTime(ctx, symbol))
+	require.Equal(t, types.CodeRepeatTradingPair, mk.ImportBook(ctx, book).Code())
+
+	book.Version = types.BookSnapshotVersion + 1
+	require.Equal(t, types.CodeInvalidBookSnapshot, mk.ImportBook(ctx, book).Code())
+}
@@ -0,0 +1,148 @@
+package keepers
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/market/internal/types"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+// CandleKeeper stores the OHLCV candles of each trading pair, which are bucketed by span and start time,
+// and the 24-hour ticker of each trading pair.
+type CandleKeeper struct {
+	marketKey sdk.StoreKey
+	codec     *codec.Codec
+}
+
+func NewCandleKeeper(key sdk.StoreKey, codec *codec.Codec) *CandleKeeper {
+	return &CandleKeeper{
+		marketKey: key,
+		codec:     codec,
+	}
+}
+
+func candleKeyPrefix(symbol string, span int64) []byte {
+	return dex.ConcatKeys(CandleKeyPrefix, []byte(symbol), []byte{0x0}, int64ToBigEndianBytes(span))
+}
+
+func candleKey(symbol string, span, startTime int64) []byte {
+	return dex.ConcatKeys(candleKeyPrefix(symbol, span), int64ToBigEndianBytes(startTime))
+}
+
+func tickerKey(symbol string) []byte {
+	return dex.ConcatKeys(TickerKeyPrefix, []byte(symbol))
+}
+
+func (keeper *CandleKeeper) GetCandle(ctx sdk.Context, symbol string, span, startTime int64) *types.Candle {
+	store := ctx.KVStore(keeper.marketKey)
+	bz := store.Get(candleKey(symbol, span, startTime))
+	if len(bz) == 0 {
+		return nil
+	}
+	candle := &types.Candle{}
+	keeper.codec.MustUnmarshalBinaryBare(bz, candle)
+	return candle
+}
+
+func (keeper *CandleKeeper) setCandle(ctx sdk.Context, candle *types.Candle) {
+	store := ctx.KVStore(keeper.marketKey)
+	store.Set(candleKey(candle.TradingPair, candle.Span, candle.StartTime), keeper.codec.MustMarshalBinaryBare(candle))
+}
+
+// Merge the deals in a block, whose StartTime is the block time, into the candles of every span.
+// When a new bucket is started, the buckets older than retentionCount spans are removed.
+// A non-positive retentionCount keeps all the buckets.
+func (keeper *CandleKeeper) RecordDeals(ctx sdk.Context, deals types.Candle, retentionCount int64) {
+	if deals.IsEmpty() {
+		return
+	}
+	for _, span := range types.CandleSpans {
+		startTime := types.CandleStartTime(deals.StartTime, span)
+		candle := keeper.GetCandle(ctx, deals.TradingPair, span, startTime)
+		if candle == nil {
+			newCandle := types.NewCandle(deals.TradingPair, span, startTime)
+			candle = &newCandle
+			if retentionCount > 0 {
+				keeper.removeCandlesBefore(ctx, deals.TradingPair, span, startTime-(retentionCount-1)*span)
+			}
+		}
+		candle.Merge(deals)
+		keeper.setCandle(ctx, candle)
+	}
+}
+
+func (keeper *CandleKeeper) removeCandlesBefore(ctx sdk.Context, symbol string, span, startTime int64) {
+	if startTime <= 0 {
+		return
+	}
+	store := ctx.KVStore(keeper.marketKey)
+	keeper.removeKeys(store, candleKeyPrefix(symbol, span), candleKey(symbol, span, startTime))
+}
+
+func (keeper *CandleKeeper) removeKeys(store sdk.KVStore, start, end []byte) {
+	var keys [][]byte
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// Return at most count candles of a span, in ascending order of start time, beginning from the bucket containing since
+func (keeper *CandleKeeper) GetCandles(ctx sdk.Context, symbol string, span, since int64, count int) []types.Candle {
+	if since < 0 {
+		since = 0
+	}
+	store := ctx.KVStore(keeper.marketKey)
+	start := candleKey(symbol, span, types.CandleStartTime(since, span))
+	end := sdk.PrefixEndBytes(candleKeyPrefix(symbol, span))
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+	candles := make([]types.Candle, 0, count)
+	for ; iter.Valid() && len(candles) < count; iter.Next() {
+		var candle types.Candle
+		keeper.codec.MustUnmarshalBinaryBare(iter.Value(), &candle)
+		candles = append(candles, candle)
+	}
+	return candles
+}
+
+// Aggregate the hourly candles in the ticker window ending at the unix time now
+func (keeper *CandleKeeper) RollTicker(ctx sdk.Context, symbol string, lastPrice sdk.Dec, now int64) types.Ticker {
+	summary := types.NewCandle(symbol, types.TickerHours*types.CandleSpanHour, types.TickerStartTime(now))
+	for _, candle := range keeper.GetCandles(ctx, symbol, types.CandleSpanHour, summary.StartTime, types.TickerHours) {
+		if candle.StartTime > now {
+			break
+		}
+		summary.Merge(candle)
+	}
+	return types.NewTicker(summary, lastPrice, now)
+}
+
+func (keeper *CandleKeeper) GetTicker(ctx sdk.Context, symbol string) *types.Ticker {
+	store := ctx.KVStore(keeper.marketKey)
+	bz := store.Get(tickerKey(symbol))
+	if len(bz) == 0 {
+		return nil
+	}
+	ticker := &types.Ticker{}
+	keeper.codec.MustUnmarshalBinaryBare(bz, ticker)
+	return ticker
+}
+
+func (keeper *CandleKeeper) SetTicker(ctx sdk.Context, ticker types.Ticker) {
+	store := ctx.KVStore(keeper.marketKey)
+	store.Set(tickerKey(ticker.TradingPair), keeper.codec.MustMarshalBinaryBare(ticker))
+}
+
+// Remove the candles and the ticker of a trading pair
+func (keeper *CandleKeeper) RemoveMarket(ctx sdk.Context, symbol string) {
+	store := ctx.KVStore(keeper.marketKey)
+	prefix := dex.ConcatKeys(CandleKeyPrefix, []byte(symbol), []byte{0x0})
+	keeper.removeKeys(store, prefix, sdk.PrefixEndBytes(prefix))
+	store.Delete(tickerKey(symbol))
+}
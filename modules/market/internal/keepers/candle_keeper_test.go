@@ -0,0 +1,113 @@
+package keepers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/market/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/market/internal/types"
+	"github.com/coinexchain/cet-sdk/testapp"
+)
+
+func newDeals(symbol string, blockTime int64, price int64, amount int64) types.Candle {
+	deals := types.NewCandle(symbol, 0, blockTime)
+	deals.Update(sdk.NewDec(price), amount, price*amount)
+	return deals
+}
+
+func TestCandleKeeper(t *testing.T) {
+	testApp := testapp.NewTestApp()
+	ctx := testApp.NewCtx()
+	ck := keepers.NewCandleKeeper(testApp.MarketKeeper.GetMarketKey(), types.ModuleCdc)
+	base := int64(1577836800) // 2020-01-01 00:00:00 UTC
+
+	ck.RecordDeals(ctx, newDeals("eth/cet", base+10, 10, 1), 2)
+	ck.RecordDeals(ctx, newDeals("eth/cet", base+20, 12, 2), 2)
+	ck.RecordDeals(ctx, newDeals("eth/cet", base+30, 9, 3), 2)
+	ck.RecordDeals(ctx, newDeals("btc/cet", base+30, 100, 1), 2)
+	candle := ck.GetCandle(ctx, "eth/cet", types.CandleSpanMinute, base)
+	require.NotNil(t, candle)
+	require.Equal(t, sdk.NewDec(10), candle.Open)
+	require.Equal(t, sdk.NewDec(12), candle.High)
+	require.Equal(t, sdk.NewDec(9), candle.Low)
+	require.Equal(t, sdk.NewDec(9), candle.Close)
+	require.Equal(t, sdk.NewInt(6), candle.StockVolume)
+	require.Equal(t, sdk.NewInt(10+24+27), candle.MoneyVolume)
+
+	// only the latest two minute candles are kept
+	ck.RecordDeals(ctx, newDeals("eth/cet", base+60, 11, 1), 2)
+	ck.RecordDeals(ctx, newDeals("eth/cet", base+150, 13, 1), 2)
+	candles := ck.GetCandles(ctx, "eth/cet", types.CandleSpanMinute, 0, 10)
+	require.Equal(t, 2, len(candles))
+	require.Equal(t, base+60, candles[0].StartTime)
+	require.Equal(t, base+120, candles[1].StartTime)
+	candles = ck.GetCandles(ctx, "eth/cet", types.CandleSpanMinute, base+130, 10)
+	require.Equal(t, 1, len(candles))
+	require.Equal(t, 1, len(ck.GetCandles(ctx, "eth/cet", types.CandleSpanHour, 0, 10)))
+	require.Equal(t, 1, len(ck.GetCandles(ctx, "btc/cet", types.CandleSpanMinute, 0, 10)))
+
+	// the ticker only contains the hourly candles in the latest 24 hours
+	ck.RecordDeals(ctx, newDeals("eth/cet", base+25*3600, 20, 5), 100)
+	ticker := ck.RollTicker(ctx, "eth/cet", sdk.NewDec(20), base+25*3600)
+	require.Equal(t, base+2*3600, ticker.StartTime)
+	require.Equal(t, sdk.NewDec(20), ticker.Open)
+	require.Equal(t, sdk.NewInt(5), ticker.StockVolume)
+	ticker = ck.RollTicker(ctx, "eth/cet", sdk.NewDec(20), base+23*3600)
+	require.Equal(t, sdk.NewDec(10), ticker.Open)
+	require.Equal(t, sdk.NewDec(13), ticker.High)
+	require.Equal(t, sdk.NewDec(9), ticker.Low)
+	require.Equal(t, sdk.NewInt(8), ticker.StockVolume)
+
+	ck.RemoveMarket(ctx, "eth/cet")
+	require.Equal(t, 0, len(ck.GetCandles(ctx, "eth/cet", types.CandleSpanHour, 0, 10)))
+	require.Equal(t, 1, len(ck.GetCandles(ctx, "btc/cet", types.CandleSpanMinute, 0, 10)))
+}
+
+func TestQueryTickerAndCandles(t *testing.T) {
+	testApp := testapp.NewTestApp()
+	base := int64(1577836800)
+	ctx := testApp.NewCtx().WithBlockTime(time.Unix(base+3600, 0))
+	ck := keepers.NewCandleKeeper(testApp.MarketKeeper.GetMarketKey(), types.ModuleCdc)
+	ck.RecordDeals(ctx, newDeals("eth/cet", base, 10, 1), 100)
+	ck.RecordDeals(ctx, newDeals("eth/cet", base+60, 11, 2), 100)
+	ck.SetTicker(ctx, ck.RollTicker(ctx, "eth/cet", sdk.NewDec(11), base+60))
+	querier := keepers.NewQuerier(testApp.MarketKeeper)
+
+	reqBytes := testApp.Cdc.MustMarshalJSON(keepers.NewQueryMarketParam("eth/cet"))
+	resBytes, err := querier(ctx, []string{keepers.QueryTicker}, abci.RequestQuery{Data: reqBytes})
+	require.Nil(t, err)
+	var ticker types.Ticker
+	testApp.Cdc.MustUnmarshalJSON(resBytes, &ticker)
+	require.Equal(t, sdk.NewDec(11), ticker.LastPrice)
+	require.Equal(t, sdk.NewInt(3), ticker.StockVolume)
+
+	// the ticker is rolled to the time of the query
+	ctx = ctx.WithBlockTime(time.Unix(base+24*3600, 0))
+	resBytes, err = querier(ctx, []string{keepers.QueryTicker}, abci.RequestQuery{Data: reqBytes})
+	require.Nil(t, err)
+	testApp.Cdc.MustUnmarshalJSON(resBytes, &ticker)
+	require.Equal(t, sdk.NewDec(11), ticker.LastPrice)
+	require.Equal(t, sdk.NewDec(11), ticker.Open)
+	require.True(t, ticker.StockVolume.IsZero())
+
+	reqBytes = testApp.Cdc.MustMarshalJSON(keepers.NewQueryMarketParam("btc/cet"))
+	_, err = querier(ctx, []string{keepers.QueryTicker}, abci.RequestQuery{Data: reqBytes})
+	require.NotNil(t, err)
+
+	reqBytes = testApp.Cdc.MustMarshalJSON(keepers.NewQueryCandlesParam("eth/cet", types.CandleSpanMinute, base+1, 0))
+	resBytes, err = querier(ctx, []string{keepers.QueryCandles}, abci.RequestQuery{Data: reqBytes})
+	require.Nil(t, err)
+	var candles []types.Candle
+	testApp.Cdc.MustUnmarshalJSON(resBytes, &candles)
+	require.Equal(t, 2, len(candles))
+	require.Equal(t, sdk.NewDec(11), candles[1].Close)
+
+	reqBytes = testApp.Cdc.MustMarshalJSON(keepers.NewQueryCandlesParam("eth/cet", 120, 0, 0))
+	_, err = querier(ctx, []string{keepers.QueryCandles}, abci.RequestQuery{Data: reqBytes})
+	require.NotNil(t, err)
+}
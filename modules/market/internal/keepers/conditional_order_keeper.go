@@ -0,0 +1,160 @@
+package keepers
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/market/internal/types"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+// ConditionalOrderKeeper stores the dormant conditional orders. Each order is indexed by its trigger price in
+// one of two lists: the orders triggered by a rising price and the orders triggered by a falling price.
+type ConditionalOrderKeeper struct {
+	marketKey sdk.StoreKey
+	codec     *codec.Codec
+}
+
+func NewConditionalOrderKeeper(key sdk.StoreKey, codec *codec.Codec) *ConditionalOrderKeeper {
+	return &ConditionalOrderKeeper{
+		marketKey: key,
+		codec:     codec,
+	}
+}
+
+func conditionalOrderKey(orderID string) []byte {
+	return dex.ConcatKeys(ConditionalOrderKeyPrefix, []byte{0x0}, []byte(orderID))
+}
+
+func triggerListKey(order *types.ConditionalOrder) []byte {
+	prefix := TriggerDownKeyPrefix
+	if order.TriggeredWhenPriceRises() {
+		prefix = TriggerUpKeyPrefix
+	}
+	return dex.ConcatKeys(
+		prefix,
+		[]byte(order.TradingPair),
+		[]byte{0x0},
+		types.DecToBigEndianBytes(order.TriggerPrice),
+		[]byte(order.OrderID()),
+	)
+}
+
+func (keeper *ConditionalOrderKeeper) Add(ctx sdk.Context, order *types.ConditionalOrder) {
+	store := ctx.KVStore(keeper.marketKey)
+	store.Set(conditionalOrderKey(order.OrderID()), keeper.codec.MustMarshalBinaryBare(order))
+	store.Set(triggerListKey(order), []byte{})
+}
+
+func (keeper *ConditionalOrderKeeper) Remove(ctx sdk.Context, order *types.ConditionalOrder) sdk.Error {
+	store := ctx.KVStore(keeper.marketKey)
+	key := conditionalOrderKey(order.OrderID())
+	if !store.Has(key) {
+		return types.ErrNoExistKeyInStore()
+	}
+	store.Delete(key)
+	store.Delete(triggerListKey(order))
+	return nil
+}
+
+func (keeper *ConditionalOrderKeeper) QueryOrder(ctx sdk.Context, orderID string) *types.ConditionalOrder {
+	store := ctx.KVStore(keeper.marketKey)
+	bz := store.Get(conditionalOrderKey(orderID))
+	if len(bz) == 0 {
+		return nil
+	}
+	order := &types.ConditionalOrder{}
+	keeper.codec.MustUnmarshalBinaryBare(bz, order)
+	return order
+}
+
+// Return at most limit orders in a market which are triggered by the price, and whether there are more of them
+func (keeper *ConditionalOrderKeeper) GetTriggeredOrders(ctx sdk.Context, symbol string, price sdk.Dec, limit int) ([]*types.ConditionalOrder, bool) {
+	priceBytes := types.DecToBigEndianBytes(price)
+	// rising price: TriggerPrice <= price
+	upStart := dex.ConcatKeys(TriggerUpKeyPrefix, []byte(symbol), []byte{0x0})
+	upEnd := dex.ConcatKeys(TriggerUpKeyPrefix, []byte(symbol), []byte{0x0}, priceBytes, []byte{0xFF})
+	// falling price: TriggerPrice >= price
+	downStart := dex.ConcatKeys(TriggerDownKeyPrefix, []byte(symbol), []byte{0x0}, priceBytes)
+	downEnd := dex.ConcatKeys(TriggerDownKeyPrefix, []byte(symbol), []byte{0x1})
+
+	// one more order is read to tell whether there are more
+	result := keeper.getOrdersInRange(ctx, upStart, upEnd, len(upStart)+types.DecByteCount, limit+1)
+	result = append(result, keeper.getOrdersInRange(ctx, downStart, downEnd, len(downStart), limit+1-len(result))...)
+	if len(result) > limit {
+		return result[:limit], true
+	}
+	return result, false
+}
+
+// Return all the conditional orders of a market
+func (keeper *ConditionalOrderKeeper) GetOrdersInMarket(ctx sdk.Context, symbol string) []*types.ConditionalOrder {
+	upStart := dex.ConcatKeys(TriggerUpKeyPrefix, []byte(symbol), []byte{0x0})
+	upEnd := dex.ConcatKeys(TriggerUpKeyPrefix, []byte(symbol), []byte{0x1})
+	downStart := dex.ConcatKeys(TriggerDownKeyPrefix, []byte(symbol), []byte{0x0})
+	downEnd := dex.ConcatKeys(TriggerDownKeyPrefix, []byte(symbol), []byte{0x1})
+
+	result := keeper.getOrdersInRange(ctx, upStart, upEnd, len(upStart)+types.DecByteCount, -1)
+	return append(result, keeper.getOrdersInRange(ctx, downStart, downEnd, len(downStart)+types.DecByteCount, -1)...)
+}
+
+// getOrdersInRange returns at most limit orders in the range of keys, or all of them if limit is negative
+func (keeper *ConditionalOrderKeeper) getOrdersInRange(ctx sdk.Context, start, end []byte, orderIDPos int, limit int) []*types.ConditionalOrder {
+	store := ctx.KVStore(keeper.marketKey)
+	var orderIDList []string
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid() && limit != 0; iter.Next() {
+		limit--
+		orderIDList = append(orderIDList, string(iter.Key()[orderIDPos:]))
+	}
+	result := make([]*types.ConditionalOrder, 0, len(orderIDList))
+	for _, orderID := range orderIDList {
+		if order := keeper.QueryOrder(ctx, orderID); order != nil {
+			result = append(result, order)
+		}
+	}
+	return result
+}
+
+// Get all the conditional orders out. Only use it for dumping state.
+func (keeper *ConditionalOrderKeeper) GetAllOrders(ctx sdk.Context) []*types.ConditionalOrder {
+	store := ctx.KVStore(keeper.marketKey)
+	result := make([]*types.ConditionalOrder, 0)
+	start := dex.ConcatKeys(ConditionalOrderKeyPrefix, []byte{0x0})
+	end := dex.ConcatKeys(ConditionalOrderKeyPrefix, []byte{0x1})
+	iter := store.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		order := &types.ConditionalOrder{}
+		keeper.codec.MustUnmarshalBinaryBare(iter.Value(), order)
+		result = append(result, order)
+	}
+	return result
+}
+
+func triggerPendingKey(symbol string) []byte {
+	return dex.ConcatKeys(TriggerPendingKeyPrefix, []byte(symbol))
+}
+
+// A market is marked as pending when some of its triggered orders wait for the next blocks
+func (keeper *ConditionalOrderKeeper) SetTriggerPending(ctx sdk.Context, symbol string, pending bool) {
+	store := ctx.KVStore(keeper.marketKey)
+	if pending {
+		store.Set(triggerPendingKey(symbol), []byte{})
+	} else {
+		store.Delete(triggerPendingKey(symbol))
+	}
+}
+
+// Return the markets marked as pending, in the order of their symbols
+func (keeper *ConditionalOrderKeeper) GetTriggerPendingMarkets(ctx sdk.Context) []string {
+	store := ctx.KVStore(keeper.marketKey)
+	var symbols []string
+	iter := sdk.KVStorePrefixIterator(store, TriggerPendingKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		symbols = append(symbols, string(iter.Key()[len(TriggerPendingKeyPrefix):]))
+	}
+	return symbols
+}
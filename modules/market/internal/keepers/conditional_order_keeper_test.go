@@ -0,0 +1,76 @@
+package keepers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/market/internal/types"
+)
+
+func newConditionalOrder(identify byte, side, conditionType byte, triggerPrice int64) *types.ConditionalOrder {
+	addr, _ := sdk.AccAddressFromHex("0123456789012345678901234567890123423456")
+	return &types.ConditionalOrder{
+		Sender:        addr,
+		Sequence:      1,
+		Identify:      identify,
+		TradingPair:   "abc/cet",
+		Side:          side,
+		ConditionType: conditionType,
+		TriggerPrice:  sdk.NewDec(triggerPrice),
+	}
+}
+
+func TestConditionalOrderKeeper(t *testing.T) {
+	ctx, keys := newContextAndMarketKey(unitChainID)
+	keeper := NewConditionalOrderKeeper(keys.marketKey, types.ModuleCdc)
+
+	stopBuy := newConditionalOrder(1, types.BUY, types.StopLimit, 110)
+	stopSell := newConditionalOrder(2, types.SELL, types.StopLimit, 90)
+	profitBuy := newConditionalOrder(3, types.BUY, types.TakeProfit, 80)
+	profitSell := newConditionalOrder(4, types.SELL, types.TakeProfit, 120)
+	otherMarket := newConditionalOrder(5, types.SELL, types.StopLimit, 100)
+	otherMarket.TradingPair = "abd/cet"
+	for _, order := range []*types.ConditionalOrder{stopBuy, stopSell, profitBuy, profitSell, otherMarket} {
+		keeper.Add(ctx, order)
+	}
+	require.Equal(t, 5, len(keeper.GetAllOrders(ctx)))
+	require.Equal(t, 4, len(keeper.GetOrdersInMarket(ctx, "abc/cet")))
+	require.Equal(t, stopSell.OrderID(), keeper.QueryOrder(ctx, stopSell.OrderID()).OrderID())
+
+	orders, more := keeper.GetTriggeredOrders(ctx, "abc/cet", sdk.NewDec(100), 10)
+	require.Equal(t, 0, len(orders))
+	require.False(t, more)
+	orders, _ = keeper.GetTriggeredOrders(ctx, "abc/cet", sdk.NewDec(110), 10)
+	require.Equal(t, 1, len(orders))
+	require.Equal(t, stopBuy.OrderID(), orders[0].OrderID())
+	orders, _ = keeper.GetTriggeredOrders(ctx, "abc/cet", sdk.NewDec(130), 10)
+	require.Equal(t, 2, len(orders))
+	orders, _ = keeper.GetTriggeredOrders(ctx, "abc/cet", sdk.NewDec(90), 10)
+	require.Equal(t, 1, len(orders))
+	require.Equal(t, stopSell.OrderID(), orders[0].OrderID())
+	orders, more = keeper.GetTriggeredOrders(ctx, "abc/cet", sdk.NewDec(70), 10)
+	require.Equal(t, 2, len(orders))
+	require.False(t, more)
+	// no more than the limit is returned
+	orders, more = keeper.GetTriggeredOrders(ctx, "abc/cet", sdk.NewDec(70), 1)
+	require.Equal(t, 1, len(orders))
+	require.True(t, more)
+	orders, more = keeper.GetTriggeredOrders(ctx, "abc/cet", sdk.NewDec(70), 0)
+	require.Equal(t, 0, len(orders))
+	require.True(t, more)
+
+	keeper.SetTriggerPending(ctx, "abd/cet", true)
+	keeper.SetTriggerPending(ctx, "abc/cet", true)
+	require.Equal(t, []string{"abc/cet", "abd/cet"}, keeper.GetTriggerPendingMarkets(ctx))
+	keeper.SetTriggerPending(ctx, "abc/cet", false)
+	require.Equal(t, []string{"abd/cet"}, keeper.GetTriggerPendingMarkets(ctx))
+
+	require.Nil(t, keeper.Remove(ctx, stopSell))
+	require.NotNil(t, keeper.Remove(ctx, stopSell))
+	require.Nil(t, keeper.QueryOrder(ctx, stopSell.OrderID()))
+	orders, _ = keeper.GetTriggeredOrders(ctx, "abc/cet", sdk.NewDec(70), 10)
+	require.Equal(t, 1, len(orders))
+}
@@ -1,6 +1,8 @@
 package keepers
 
 import (
+	"encoding/binary"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	dex "github.com/coinexchain/cet-sdk/types"
@@ -42,6 +44,16 @@ func (keeper *DelistKeeper) HasDelistRequest(ctx sdk.Context, symbol string) boo
 	return store.Has(append(DelistRevKey, []byte(symbol)...))
 }
 
+// Return the time of the pending delist request of a market, and zero if there is no request
+func (keeper *DelistKeeper) GetDelistRequestTime(ctx sdk.Context, symbol string) int64 {
+	store := ctx.KVStore(keeper.marketKey)
+	bz := store.Get(append(DelistRevKey, []byte(symbol)...))
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
 //include the specific time
 func (keeper *DelistKeeper) GetDelistSymbolsBeforeTime(ctx sdk.Context, time int64) []string {
 	store := ctx.KVStore(keeper.marketKey)
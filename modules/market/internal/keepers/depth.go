@@ -0,0 +1,109 @@
+package keepers
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/market/internal/types"
+)
+
+const (
+	DefaultDepthCount = 20
+	MaxDepthCount     = 200
+)
+
+type QueryDepthParam struct {
+	TradingPair string `json:"trading_pair"`
+	// The number of decimal places of the aggregated prices. A negative value means the price precision of the market.
+	Precision int `json:"precision"`
+	// The maximum number of price levels on each side. Zero means DefaultDepthCount.
+	Count int `json:"count"`
+}
+
+func NewQueryDepthParam(symbol string, precision, count int) QueryDepthParam {
+	return QueryDepthParam{
+		TradingPair: symbol,
+		Precision:   precision,
+		Count:       count,
+	}
+}
+
+// Check the param against the price precision of the market, and return the precision and count of the levels
+func (param QueryDepthParam) GetPrecisionAndCount(pricePrecision byte) (byte, int, sdk.Error) {
+	precision := pricePrecision
+	if param.Precision >= 0 {
+		if param.Precision > int(pricePrecision) {
+			return 0, 0, types.ErrInvalidPricePrecision(byte(param.Precision))
+		}
+		precision = byte(param.Precision)
+	}
+	count := param.Count
+	if count == 0 {
+		count = DefaultDepthCount
+	}
+	if count < 0 || count > MaxDepthCount {
+		return 0, 0, sdk.ErrUnknownRequest("The count of depth levels should be in [1, 200]")
+	}
+	return precision, count, nil
+}
+
+type DepthLevel struct {
+	Price    sdk.Dec `json:"price"`
+	Quantity sdk.Int `json:"quantity"`
+	// the total quantity of this level and all the better levels
+	CumulativeQuantity sdk.Int `json:"cumulative_quantity"`
+}
+
+type QueryDepthResult struct {
+	Bids []DepthLevel `json:"bids"`
+	Asks []DepthLevel `json:"asks"`
+}
+
+// DepthAggregator merges the orders on one side of an order book into price levels.
+// The bid prices are rounded down and the ask prices are rounded up to the precision,
+// so a level never looks better than the orders in it. The orders must be added from
+// the best price to the worst.
+type DepthAggregator struct {
+	isBid  bool
+	step   sdk.Dec
+	count  int
+	levels []DepthLevel
+}
+
+func NewDepthAggregator(isBid bool, precision byte, count int) *DepthAggregator {
+	return &DepthAggregator{
+		isBid:  isBid,
+		step:   sdk.NewDecWithPrec(1, int64(precision)),
+		count:  count,
+		levels: make([]DepthLevel, 0, count),
+	}
+}
+
+// Add an order's price and amount. It returns false when the levels are full and the remaining orders are not needed.
+func (da *DepthAggregator) Add(price sdk.Dec, amount int64) bool {
+	steps := price.Quo(da.step)
+	if da.isBid {
+		steps = steps.TruncateDec()
+	} else {
+		steps = steps.Ceil()
+	}
+	levelPrice := steps.Mul(da.step)
+	last := len(da.levels) - 1
+	if last >= 0 && da.levels[last].Price.Equal(levelPrice) {
+		da.levels[last].Quantity = da.levels[last].Quantity.AddRaw(amount)
+		return true
+	}
+	if len(da.levels) == da.count {
+		return false
+	}
+	da.levels = append(da.levels, DepthLevel{Price: levelPrice, Quantity: sdk.NewInt(amount)})
+	return true
+}
+
+func (da *DepthAggregator) Levels() []DepthLevel {
+	total := sdk.ZeroInt()
+	for i := range da.levels {
+		total = total.Add(da.levels[i].Quantity)
+		da.levels[i].CumulativeQuantity = total
+	}
+	return da.levels
+}
@@ -3,6 +3,8 @@ package keepers
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"math"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -31,12 +33,13 @@ type Keeper struct {
 	msgProducer   msgqueue.MsgSender
 	ak            auth.AccountKeeper
 	authX         types.ExpectedAuthXKeeper
+	ork           types.ExpectedOracleKeeper
 }
 
 func NewKeeper(key sdk.StoreKey, axkVal types.ExpectedAssetStatusKeeper,
 	bnkVal types.ExpectedBankxKeeper, cdcVal *codec.Codec,
 	msgKeeperVal msgqueue.MsgSender, paramstore params.Subspace,
-	ak auth.AccountKeeper, authX types.ExpectedAuthXKeeper) Keeper {
+	ak auth.AccountKeeper, authX types.ExpectedAuthXKeeper, ork types.ExpectedOracleKeeper) Keeper {
 
 	return Keeper{
 		paramSubspace: paramstore.WithKeyTable(types.ParamKeyTable()),
@@ -49,6 +52,7 @@ func NewKeeper(key sdk.StoreKey, axkVal types.ExpectedAssetStatusKeeper,
 		msgProducer:   msgKeeperVal,
 		ak:            ak,
 		authX:         authX,
+		ork:           ork,
 	}
 }
 
@@ -100,6 +104,10 @@ func (k Keeper) UnFreezeCoins(ctx sdk.Context, acc sdk.AccAddress, amt sdk.Coins
 	return k.bnk.UnFreezeCoins(ctx, acc, amt)
 }
 
+func (k Keeper) GetFrozenCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return k.bnk.GetFrozenCoins(ctx, addr)
+}
+
 func (k Keeper) IsTokenIssuer(ctx sdk.Context, denom string, addr sdk.AccAddress) bool {
 	return k.axk.IsTokenIssuer(ctx, denom, addr)
 }
@@ -160,6 +168,13 @@ func (k Keeper) GetRebateRatioBase(ctx sdk.Context) int64 {
 	return k.authX.GetRebateRatioBase(ctx)
 }
 
+// RecordPrice feeds the executed price of a market to the oracle, if the keeper has one
+func (k Keeper) RecordPrice(ctx sdk.Context, symbol string, price sdk.Dec) {
+	if k.ork != nil {
+		k.ork.RecordPrice(ctx, symbol, price)
+	}
+}
+
 // -----------------------------------------------------------------------------
 // Params
 
@@ -190,6 +205,99 @@ func (k Keeper) GetAllOrders(ctx sdk.Context) []*types.Order {
 	return NewGlobalOrderKeeper(k.marketKey, k.cdc).GetAllOrders(ctx)
 }
 
+func (k Keeper) SetConditionalOrder(ctx sdk.Context, order *types.ConditionalOrder) {
+	NewConditionalOrderKeeper(k.marketKey, k.cdc).Add(ctx, order)
+}
+
+// Record the trading volume of an account in the current day, converted into CET
+func (k Keeper) AddTradingVolume(ctx sdk.Context, addr sdk.AccAddress, stock, money string, stockAmount, moneyAmount int64) {
+	volume := k.GetMarketVolume(ctx, stock, money, sdk.NewDec(stockAmount), sdk.NewDec(moneyAmount))
+	day := types.GetDayOfUnixTime(ctx.BlockHeader().Time.Unix())
+	NewVolumeKeeper(k.marketKey, k.cdc).AddVolume(ctx, addr, day, volume.TruncateInt())
+}
+
+// Return the trading volume of an account in the latest types.VolumeWindowDays days
+func (k Keeper) GetTradingVolume(ctx sdk.Context, addr sdk.AccAddress) sdk.Int {
+	day := types.GetDayOfUnixTime(ctx.BlockHeader().Time.Unix())
+	return NewVolumeKeeper(k.marketKey, k.cdc).GetVolume(ctx, addr, day)
+}
+
+// Return the discount in percent on an account's commission, which is decided by its trading volume
+func (k Keeper) GetFeeDiscount(ctx sdk.Context, addr sdk.AccAddress) int64 {
+	_, discount := types.GetFeeTier(k.GetParams(ctx).FeeTiers, k.GetTradingVolume(ctx, addr))
+	return discount
+}
+
+func (k Keeper) SetAccountVolume(ctx sdk.Context, volume types.AccountVolume) {
+	NewVolumeKeeper(k.marketKey, k.cdc).SetVolume(ctx, volume)
+}
+
+func (k Keeper) GetAllAccountVolumes(ctx sdk.Context) []types.AccountVolume {
+	return NewVolumeKeeper(k.marketKey, k.cdc).GetAllVolumes(ctx)
+}
+
+func (k Keeper) GetAllConditionalOrders(ctx sdk.Context) []*types.ConditionalOrder {
+	return NewConditionalOrderKeeper(k.marketKey, k.cdc).GetAllOrders(ctx)
+}
+
+// Export the order book of a trading pair at the current height
+func (k Keeper) ExportBook(ctx sdk.Context, symbol string) (types.BookSnapshot, sdk.Error) {
+	info, err := k.GetMarketInfo(ctx, symbol)
+	if err != nil {
+		return types.BookSnapshot{}, types.ErrInvalidMarket(err.Error())
+	}
+	return types.BookSnapshot{
+		Version:    types.BookSnapshotVersion,
+		Height:     ctx.BlockHeight(),
+		MarketInfo: info,
+		Orders:     NewOrderKeeper(k.marketKey, symbol, k.cdc).GetOlderThan(ctx, math.MaxInt64),
+		DelistTime: NewDelistKeeper(k.marketKey).GetDelistRequestTime(ctx, symbol),
+	}, nil
+}
+
+// Import an exported order book into a test network. The market must not exist, and the coins
+// frozen by all the orders of each sender must have been frozen in bankx.
+func (k Keeper) ImportBook(ctx sdk.Context, book types.BookSnapshot) sdk.Error {
+	if err := book.Validate(); err != nil {
+		return types.ErrInvalidBookSnapshot(err.Error())
+	}
+	symbol := book.MarketInfo.GetSymbol()
+	if k.IsMarketExist(ctx, symbol) {
+		return types.ErrRepeatTradingPair()
+	}
+	frozenInOrders := make(map[string]sdk.Coins)
+	for _, order := range book.Orders {
+		sender := order.Sender.String()
+		if _, ok := frozenInOrders[sender]; !ok {
+			frozenInOrders[sender] = sdk.Coins{}
+		}
+		frozenInOrders[sender] = frozenInOrders[sender].Add(order.GetFrozenCoins())
+	}
+	for _, order := range k.GetAllOrders(ctx) {
+		if coins, ok := frozenInOrders[order.Sender.String()]; ok {
+			frozenInOrders[order.Sender.String()] = coins.Add(order.GetFrozenCoins())
+		}
+	}
+	for _, order := range book.Orders {
+		frozen := k.GetFrozenCoins(ctx, order.Sender)
+		if !frozen.IsAllGTE(frozenInOrders[order.Sender.String()]) {
+			return types.ErrInvalidBookSnapshot(fmt.Sprintf("the frozen coins %s of %s are less than %s in orders",
+				frozen, order.Sender, frozenInOrders[order.Sender.String()]))
+		}
+	}
+
+	k.SetMarket(ctx, book.MarketInfo)
+	for _, order := range book.Orders {
+		if err := k.SetOrder(ctx, order); err != nil {
+			return err
+		}
+	}
+	if book.DelistTime != 0 {
+		NewDelistKeeper(k.marketKey).AddDelistRequest(ctx, book.DelistTime, symbol)
+	}
+	return nil
+}
+
 // -----------------------------------------------
 // market info
 
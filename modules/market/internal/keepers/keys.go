@@ -1,7 +1,14 @@
 package keepers
 
 var (
-	MarketIdentifierPrefix = []byte{0x15}
-	DelistKey              = []byte{0x40}
-	DelistRevKey           = []byte{0x42}
+	MarketIdentifierPrefix    = []byte{0x15}
+	ConditionalOrderKeyPrefix = []byte{0x16}
+	TriggerUpKeyPrefix        = []byte{0x17}
+	TriggerDownKeyPrefix      = []byte{0x18}
+	CandleKeyPrefix           = []byte{0x19}
+	TickerKeyPrefix           = []byte{0x1A}
+	VolumeKeyPrefix           = []byte{0x1B}
+	TriggerPendingKeyPrefix   = []byte{0x1C}
+	DelistKey                 = []byte{0x40}
+	DelistRevKey              = []byte{0x42}
 )
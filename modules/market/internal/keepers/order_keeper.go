@@ -59,6 +59,7 @@ type OrderKeeper interface {
 	GetOlderThan(ctx sdk.Context, height int64) []*types.Order
 	GetOrdersAtHeight(ctx sdk.Context, height int64) []*types.Order
 	GetMatchingCandidates(ctx sdk.Context) []*types.Order
+	IterateOrdersFromBestPrice(ctx sdk.Context, side byte, process func(order *types.Order) bool)
 	GetSymbol() string
 }
 
@@ -308,6 +309,27 @@ func (keeper *PersistentOrderKeeper) GetMatchingCandidates(ctx sdk.Context) []*t
 	return result
 }
 
+// Iterate the bid list or the ask list from the best price to the worst, until process returns false
+func (keeper *PersistentOrderKeeper) IterateOrdersFromBestPrice(ctx sdk.Context, side byte, process func(order *types.Order) bool) {
+	store := ctx.KVStore(keeper.marketKey)
+	priceEndPos := len(keeper.symbol) + 2 + types.DecByteCount
+	var iter sdk.Iterator
+	if side == types.BID {
+		iter = store.ReverseIterator(dex.ConcatKeys(BidListKeyPrefix, []byte(keeper.symbol), []byte{0x0}),
+			dex.ConcatKeys(BidListKeyPrefix, []byte(keeper.symbol), []byte{0x1}))
+	} else {
+		iter = store.Iterator(dex.ConcatKeys(AskListKeyPrefix, []byte(keeper.symbol), []byte{0x0}),
+			dex.ConcatKeys(AskListKeyPrefix, []byte(keeper.symbol), []byte{0x1}))
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		order := keeper.getOrder(ctx, string(iter.Key()[priceEndPos:]))
+		if order != nil && !process(order) {
+			return
+		}
+	}
+}
+
 ////////////////////////////////////////////////
 
 // Global order keep can lookup a order, given its ID or the prefix of its ID, i.e. the sender's address
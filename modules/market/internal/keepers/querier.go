@@ -21,6 +21,16 @@ const (
 	QueryUserOrders        = "user-order-list"
 	QueryWaitCancelMarkets = "wait-cancel-markets"
 	QueryParameters        = "parameters"
+	QueryDepth             = "depth"
+	QueryTicker            = "ticker"
+	QueryCandles           = "candles"
+	QueryFeeTier           = "fee-tier"
+	QueryExportBook        = "export-book"
+)
+
+const (
+	DefaultCandleCount = 100
+	MaxCandleCount     = 1000
 )
 
 // creates a querier for asset REST endpoints
@@ -41,6 +51,16 @@ func NewQuerier(mk Keeper) sdk.Querier {
 			return queryUserOrderList(ctx, req, mk)
 		case QueryWaitCancelMarkets:
 			return queryWaitCancelMarkets(ctx, req, mk)
+		case QueryDepth:
+			return queryDepth(ctx, req, mk)
+		case QueryTicker:
+			return queryTicker(ctx, req, mk)
+		case QueryCandles:
+			return queryCandles(ctx, req, mk)
+		case QueryFeeTier:
+			return queryFeeTier(ctx, req, mk)
+		case QueryExportBook:
+			return queryExportBook(ctx, req, mk)
 		default:
 			return nil, sdk.ErrUnknownRequest("query symbol : " + path[0])
 		}
@@ -75,6 +95,8 @@ type QueryMarketInfo struct {
 	PricePrecision    string         `json:"price_precision"`
 	LastExecutedPrice sdk.Dec        `json:"last_executed_price"`
 	OrderPrecision    string         `json:"order_precision"`
+	MatchingMode      string         `json:"matching_mode,omitempty"`
+	Halted            bool           `json:"halted,omitempty"`
 	// AutoSwap fields
 	StockAmmReserve       sdk.Int `json:"stock_amm_reserve"`
 	MoneyAmmReserve       sdk.Int `json:"money_amm_reserve"`
@@ -101,6 +123,8 @@ func queryMarket(ctx sdk.Context, req abci.RequestQuery, mk Keeper) ([]byte, sdk
 		PricePrecision:    strconv.Itoa(int(info.PricePrecision)),
 		LastExecutedPrice: info.LastExecutedPrice,
 		OrderPrecision:    strconv.Itoa(int(info.OrderPrecision)),
+		MatchingMode:      strconv.Itoa(int(info.MatchingMode)),
+		Halted:            info.Halted,
 	}
 	bz, err := codec.MarshalJSONIndent(mk.cdc, queryInfo)
 	if err != nil {
@@ -123,6 +147,8 @@ func queryMarketList(ctx sdk.Context, req abci.RequestQuery, mk Keeper) ([]byte,
 			PricePrecision:    strconv.Itoa(int(info.PricePrecision)),
 			LastExecutedPrice: info.LastExecutedPrice,
 			OrderPrecision:    strconv.Itoa(int(info.OrderPrecision)),
+			MatchingMode:      strconv.Itoa(int(info.MatchingMode)),
+			Halted:            info.Halted,
 		}
 	}
 	bz, err := codec.MarshalJSONIndent(mk.cdc, mInfoList)
@@ -149,11 +175,17 @@ type ResOrder struct {
 	FrozenFeatureFee int64          `json:"frozen_feature_fee"`   // DEX2
 	FrozenFee        int64          `json:"frozen_fee,omitempty"` // DEX2: -> frozen_commission
 
+	SelfTradePrevention byte `json:"self_trade_prevention,omitempty"`
+
 	// These fields will change when order was filled/canceled.
 	LeftStock int64 `json:"left_stock"`
 	Freeze    int64 `json:"freeze"`
 	DealStock int64 `json:"deal_stock"`
 	DealMoney int64 `json:"deal_money"`
+
+	MakerDealStock  int64 `json:"maker_deal_stock"`
+	MakerRebate     int64 `json:"maker_rebate"`
+	PaidMakerRebate int64 `json:"paid_maker_rebate"`
 }
 
 func convertResOrderFromOrder(order *types.Order) *ResOrder {
@@ -177,6 +209,11 @@ func convertResOrderFromOrder(order *types.Order) *ResOrder {
 		Freeze:           order.Freeze,
 		DealStock:        order.DealStock,
 		DealMoney:        order.DealMoney,
+		MakerDealStock:   order.MakerDealStock,
+		MakerRebate:      order.MakerRebate,
+		PaidMakerRebate:  order.PaidMakerRebate,
+
+		SelfTradePrevention: order.SelfTradePrevention,
 	}
 }
 
@@ -269,3 +306,147 @@ func queryWaitCancelMarkets(ctx sdk.Context, req abci.RequestQuery, mk Keeper) (
 	}
 	return bz, nil
 }
+
+func queryDepth(ctx sdk.Context, req abci.RequestQuery, mk Keeper) ([]byte, sdk.Error) {
+	var param QueryDepthParam
+	if err := mk.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, types.ErrFailedParseParam()
+	}
+	info, err := mk.GetMarketInfo(ctx, param.TradingPair)
+	if err != nil {
+		return nil, types.ErrInvalidMarket("Maybe the market have been deleted or not exist")
+	}
+	precision, count, sdkErr := param.GetPrecisionAndCount(info.PricePrecision)
+	if sdkErr != nil {
+		return nil, sdkErr
+	}
+
+	k := NewOrderKeeper(mk.marketKey, param.TradingPair, mk.cdc)
+	res := QueryDepthResult{}
+	for _, side := range []byte{types.BID, types.ASK} {
+		aggregator := NewDepthAggregator(side == types.BID, precision, count)
+		k.IterateOrdersFromBestPrice(ctx, side, func(order *types.Order) bool {
+			return aggregator.Add(order.Price, order.LeftStock)
+		})
+		if side == types.BID {
+			res.Bids = aggregator.Levels()
+		} else {
+			res.Asks = aggregator.Levels()
+		}
+	}
+	bz, err := codec.MarshalJSONIndent(mk.cdc, res)
+	if err != nil {
+		return nil, types.ErrFailedMarshal()
+	}
+	return bz, nil
+}
+
+func queryTicker(ctx sdk.Context, req abci.RequestQuery, mk Keeper) ([]byte, sdk.Error) {
+	var param QueryMarketParam
+	if err := mk.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, types.ErrFailedParseParam()
+	}
+	ck := NewCandleKeeper(mk.marketKey, mk.cdc)
+	ticker := ck.GetTicker(ctx, param.TradingPair)
+	if ticker == nil {
+		return nil, types.ErrNoExistKeyInStore()
+	}
+	// the stored ticker is updated only when there are deals, so it may be out of its window now
+	now := ctx.BlockHeader().Time.Unix()
+	if ticker.StartTime != types.TickerStartTime(now) {
+		rolled := ck.RollTicker(ctx, param.TradingPair, ticker.LastPrice, now)
+		ticker = &rolled
+	}
+	bz, err := codec.MarshalJSONIndent(mk.cdc, ticker)
+	if err != nil {
+		return nil, types.ErrFailedMarshal()
+	}
+	return bz, nil
+}
+
+type QueryCandlesParam struct {
+	TradingPair string `json:"trading_pair"`
+	// The span of the candles in seconds, which is one of 60, 3600 and 86400
+	Span int64 `json:"span"`
+	// The candles start from the one containing this unix time
+	Since int64 `json:"since"`
+	// The maximum number of candles. Zero means DefaultCandleCount.
+	Count int `json:"count"`
+}
+
+func NewQueryCandlesParam(symbol string, span, since int64, count int) QueryCandlesParam {
+	return QueryCandlesParam{
+		TradingPair: symbol,
+		Span:        span,
+		Since:       since,
+		Count:       count,
+	}
+}
+
+func queryCandles(ctx sdk.Context, req abci.RequestQuery, mk Keeper) ([]byte, sdk.Error) {
+	var param QueryCandlesParam
+	if err := mk.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, types.ErrFailedParseParam()
+	}
+	if !types.IsValidCandleSpan(param.Span) {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("Invalid candle span : %d", param.Span))
+	}
+	if param.Count == 0 {
+		param.Count = DefaultCandleCount
+	}
+	if param.Count < 0 || param.Count > MaxCandleCount || param.Since < 0 {
+		return nil, sdk.ErrUnknownRequest("The count of candles should be in [1, 1000] and the since time should not be negative")
+	}
+	candles := NewCandleKeeper(mk.marketKey, mk.cdc).GetCandles(ctx, param.TradingPair, param.Span, param.Since, param.Count)
+	bz, err := codec.MarshalJSONIndent(mk.cdc, candles)
+	if err != nil {
+		return nil, types.ErrFailedMarshal()
+	}
+	return bz, nil
+}
+
+type QueryFeeTierParam struct {
+	Address sdk.AccAddress `json:"address"`
+}
+
+type QueryFeeTierResult struct {
+	Address sdk.AccAddress `json:"address"`
+	// the trading volume in sato CET in the latest types.VolumeWindowDays days
+	Volume sdk.Int `json:"volume"`
+	// the 1-based index of the fee tier, zero means no tier is reached
+	Tier     int   `json:"tier"`
+	Discount int64 `json:"discount"`
+}
+
+func queryFeeTier(ctx sdk.Context, req abci.RequestQuery, mk Keeper) ([]byte, sdk.Error) {
+	var param QueryFeeTierParam
+	if err := mk.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, types.ErrFailedParseParam()
+	}
+	result := QueryFeeTierResult{
+		Address: param.Address,
+		Volume:  mk.GetTradingVolume(ctx, param.Address),
+	}
+	result.Tier, result.Discount = types.GetFeeTier(mk.GetParams(ctx).FeeTiers, result.Volume)
+	bz, err := codec.MarshalJSONIndent(mk.cdc, result)
+	if err != nil {
+		return nil, types.ErrFailedMarshal()
+	}
+	return bz, nil
+}
+
+func queryExportBook(ctx sdk.Context, req abci.RequestQuery, mk Keeper) ([]byte, sdk.Error) {
+	var param QueryMarketParam
+	if err := mk.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, types.ErrFailedParseParam()
+	}
+	book, err := mk.ExportBook(ctx, param.TradingPair)
+	if err != nil {
+		return nil, err
+	}
+	bz, e := codec.MarshalJSONIndent(mk.cdc, book)
+	if e != nil {
+		return nil, types.ErrFailedMarshal()
+	}
+	return bz, nil
+}
@@ -196,3 +196,85 @@ func TestQueryWaitCancelMarkets(t *testing.T) {
 	require.Equal(t, 1, len(res))
 	require.Equal(t, "foo/bar", res[0])
 }
+
+func TestQueryDepth(t *testing.T) {
+	// setup
+	testApp := testapp.NewTestApp()
+	ctx := testApp.NewCtx()
+	testApp.MarketKeeper.SetParams(ctx, types.DefaultParams())
+	createMarket(ctx, testApp, "eth", "cet", 2, sdk.NewDec(10))
+	_, _, addr := testutil.KeyPubAddr()
+	orders := []struct {
+		side  byte
+		price string
+		left  int64
+	}{
+		{types.BID, "9.99", 100},
+		{types.BID, "9.91", 200},
+		{types.BID, "9.89", 300},
+		{types.BID, "8.5", 400},
+		{types.ASK, "10.01", 10},
+		{types.ASK, "10.1", 20},
+		{types.ASK, "10.11", 30},
+	}
+	for i, o := range orders {
+		order := types.Order{
+			TradingPair: "eth/cet",
+			Sender:      addr,
+			Sequence:    uint64(i),
+			Side:        o.side,
+			Price:       sdk.MustNewDecFromStr(o.price),
+			Quantity:    o.left,
+			LeftStock:   o.left,
+		}
+		require.Nil(t, testApp.MarketKeeper.SetOrder(ctx, &order))
+	}
+	querier := keepers.NewQuerier(testApp.MarketKeeper)
+	queryDepth := func(precision, count int) (keepers.QueryDepthResult, sdk.Error) {
+		reqBytes := testApp.Cdc.MustMarshalJSON(keepers.NewQueryDepthParam("eth/cet", precision, count))
+		resBytes, err := querier(ctx, []string{keepers.QueryDepth}, abci.RequestQuery{Data: reqBytes})
+		var res keepers.QueryDepthResult
+		if err == nil {
+			testApp.Cdc.MustUnmarshalJSON(resBytes, &res)
+		}
+		return res, err
+	}
+	checkLevel := func(level keepers.DepthLevel, price string, quantity, cumulative int64) {
+		require.Equal(t, sdk.MustNewDecFromStr(price), level.Price)
+		require.Equal(t, sdk.NewInt(quantity), level.Quantity)
+		require.Equal(t, sdk.NewInt(cumulative), level.CumulativeQuantity)
+	}
+
+	// the price precision of the market
+	res, err := queryDepth(-1, 0)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(res.Bids))
+	require.Equal(t, 3, len(res.Asks))
+	checkLevel(res.Bids[0], "9.99", 100, 100)
+	checkLevel(res.Bids[3], "8.5", 400, 1000)
+	checkLevel(res.Asks[0], "10.01", 10, 10)
+	checkLevel(res.Asks[2], "10.11", 30, 60)
+
+	// bids are rounded down and asks are rounded up
+	res, err = queryDepth(1, 2)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(res.Bids))
+	require.Equal(t, 2, len(res.Asks))
+	checkLevel(res.Bids[0], "9.9", 300, 300)
+	checkLevel(res.Bids[1], "9.8", 300, 600)
+	checkLevel(res.Asks[0], "10.1", 30, 30)
+	checkLevel(res.Asks[1], "10.2", 30, 60)
+
+	res, err = queryDepth(0, 0)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(res.Bids))
+	require.Equal(t, 1, len(res.Asks))
+	checkLevel(res.Bids[0], "9", 600, 600)
+	checkLevel(res.Asks[0], "11", 60, 60)
+
+	// invalid params
+	_, err = queryDepth(3, 0)
+	require.NotNil(t, err)
+	_, err = queryDepth(-1, keepers.MaxDepthCount+1)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,116 @@
+package keepers
+
+import (
+	"encoding/binary"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/market/internal/types"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+// VolumeKeeper stores the daily trading volume of each account, measured in sato CET.
+// Only the buckets in the latest types.VolumeWindowDays days are kept.
+type VolumeKeeper struct {
+	marketKey sdk.StoreKey
+	codec     *codec.Codec
+}
+
+func NewVolumeKeeper(key sdk.StoreKey, codec *codec.Codec) *VolumeKeeper {
+	return &VolumeKeeper{
+		marketKey: key,
+		codec:     codec,
+	}
+}
+
+func volumeKeyPrefix(addr sdk.AccAddress) []byte {
+	return dex.ConcatKeys(VolumeKeyPrefix, []byte{byte(len(addr))}, addr)
+}
+
+func volumeKey(addr sdk.AccAddress, day int64) []byte {
+	return dex.ConcatKeys(volumeKeyPrefix(addr), int64ToBigEndianBytes(day))
+}
+
+func (keeper *VolumeKeeper) getDayVolume(store sdk.KVStore, key []byte) sdk.Int {
+	bz := store.Get(key)
+	if len(bz) == 0 {
+		return sdk.ZeroInt()
+	}
+	var volume sdk.Int
+	keeper.codec.MustUnmarshalBinaryBare(bz, &volume)
+	return volume
+}
+
+// Add the volume to the bucket of the day, and remove the buckets out of the window
+func (keeper *VolumeKeeper) AddVolume(ctx sdk.Context, addr sdk.AccAddress, day int64, volume sdk.Int) {
+	if !volume.IsPositive() || day < 0 {
+		return
+	}
+	store := ctx.KVStore(keeper.marketKey)
+	key := volumeKey(addr, day)
+	total := keeper.getDayVolume(store, key)
+	if total.IsZero() {
+		keeper.removeVolumesBefore(store, addr, day-types.VolumeWindowDays+1)
+	}
+	store.Set(key, keeper.codec.MustMarshalBinaryBare(total.Add(volume)))
+}
+
+func (keeper *VolumeKeeper) removeVolumesBefore(store sdk.KVStore, addr sdk.AccAddress, day int64) {
+	if day <= 0 {
+		return
+	}
+	var keys [][]byte
+	iter := store.Iterator(volumeKeyPrefix(addr), volumeKey(addr, day))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// Return the total volume of an account in the window of types.VolumeWindowDays days ending at the day
+func (keeper *VolumeKeeper) GetVolume(ctx sdk.Context, addr sdk.AccAddress, day int64) sdk.Int {
+	if day < 0 {
+		return sdk.ZeroInt()
+	}
+	start := day - types.VolumeWindowDays + 1
+	if start < 0 {
+		start = 0
+	}
+	store := ctx.KVStore(keeper.marketKey)
+	iter := store.Iterator(volumeKey(addr, start), volumeKey(addr, day+1))
+	defer iter.Close()
+	total := sdk.ZeroInt()
+	for ; iter.Valid(); iter.Next() {
+		var volume sdk.Int
+		keeper.codec.MustUnmarshalBinaryBare(iter.Value(), &volume)
+		total = total.Add(volume)
+	}
+	return total
+}
+
+func (keeper *VolumeKeeper) SetVolume(ctx sdk.Context, volume types.AccountVolume) {
+	store := ctx.KVStore(keeper.marketKey)
+	store.Set(volumeKey(volume.Address, volume.Day), keeper.codec.MustMarshalBinaryBare(volume.Volume))
+}
+
+func (keeper *VolumeKeeper) GetAllVolumes(ctx sdk.Context) []types.AccountVolume {
+	store := ctx.KVStore(keeper.marketKey)
+	iter := sdk.KVStorePrefixIterator(store, VolumeKeyPrefix)
+	defer iter.Close()
+	volumes := make([]types.AccountVolume, 0, 100)
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		addrLen := int(key[1])
+		volume := types.AccountVolume{
+			Address: sdk.AccAddress(append([]byte{}, key[2:2+addrLen]...)),
+			Day:     int64(binary.BigEndian.Uint64(key[2+addrLen:])),
+		}
+		keeper.codec.MustUnmarshalBinaryBare(iter.Value(), &volume.Volume)
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
@@ -0,0 +1,67 @@
+package keepers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/market/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/market/internal/types"
+	"github.com/coinexchain/cet-sdk/testapp"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+func TestVolumeKeeper(t *testing.T) {
+	testApp := testapp.NewTestApp()
+	ctx := testApp.NewCtx()
+	vk := keepers.NewVolumeKeeper(testApp.MarketKeeper.GetMarketKey(), types.ModuleCdc)
+	addr1 := sdk.AccAddress("addr1")
+	addr2 := sdk.AccAddress("addr2")
+	day := int64(18000)
+
+	vk.AddVolume(ctx, addr1, day, sdk.NewInt(100))
+	vk.AddVolume(ctx, addr1, day, sdk.NewInt(50))
+	vk.AddVolume(ctx, addr1, day+10, sdk.NewInt(200))
+	vk.AddVolume(ctx, addr2, day, sdk.NewInt(1))
+	vk.AddVolume(ctx, addr2, day, sdk.ZeroInt())
+	require.Equal(t, sdk.NewInt(350), vk.GetVolume(ctx, addr1, day+10))
+	require.Equal(t, sdk.NewInt(150), vk.GetVolume(ctx, addr1, day+5))
+	require.Equal(t, sdk.NewInt(200), vk.GetVolume(ctx, addr1, day+types.VolumeWindowDays))
+	require.Equal(t, sdk.NewInt(1), vk.GetVolume(ctx, addr2, day))
+	require.Equal(t, 3, len(vk.GetAllVolumes(ctx)))
+
+	// the buckets out of the window are removed when a new day starts
+	vk.AddVolume(ctx, addr1, day+types.VolumeWindowDays, sdk.NewInt(1))
+	volumes := vk.GetAllVolumes(ctx)
+	require.Equal(t, 3, len(volumes))
+	require.Equal(t, types.AccountVolume{Address: addr1, Day: day + 10, Volume: sdk.NewInt(200)}, volumes[0])
+	require.Equal(t, types.AccountVolume{Address: addr1, Day: day + types.VolumeWindowDays, Volume: sdk.NewInt(1)}, volumes[1])
+	require.Equal(t, types.AccountVolume{Address: addr2, Day: day, Volume: sdk.NewInt(1)}, volumes[2])
+}
+
+func TestQueryFeeTier(t *testing.T) {
+	testApp := testapp.NewTestApp()
+	ctx := testApp.NewCtx().WithBlockTime(time.Unix(1577836800, 0))
+	addr := sdk.AccAddress("addr1_______________")
+	params := types.DefaultParams()
+	params.FeeTiers = []types.FeeTier{{Volume: 1e13, Discount: 10}, {Volume: 1e14, Discount: 20}}
+	testApp.MarketKeeper.SetParams(ctx, params)
+	testApp.MarketKeeper.AddTradingVolume(ctx, addr, "eth", dex.CET, 1, 5e12)
+	testApp.MarketKeeper.AddTradingVolume(ctx, addr, dex.CET, "usdt", 6e12, 1)
+	require.EqualValues(t, 10, testApp.MarketKeeper.GetFeeDiscount(ctx, addr))
+
+	querier := keepers.NewQuerier(testApp.MarketKeeper)
+	reqBytes := testApp.Cdc.MustMarshalJSON(keepers.QueryFeeTierParam{Address: addr})
+	resBytes, err := querier(ctx, []string{keepers.QueryFeeTier}, abci.RequestQuery{Data: reqBytes})
+	require.Nil(t, err)
+	var result keepers.QueryFeeTierResult
+	testApp.Cdc.MustUnmarshalJSON(resBytes, &result)
+	require.Equal(t, addr, result.Address)
+	require.Equal(t, sdk.NewInt(11e12), result.Volume)
+	require.Equal(t, 1, result.Tier)
+	require.EqualValues(t, 10, result.Discount)
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+// The version of the JSON format of BookSnapshot, which must be increased when the format is changed
+const BookSnapshotVersion = 1
+
+// BookSnapshot is the order book of a trading pair at a height. It is exported from a node
+// and can be imported into the genesis of a test network to replay the book there.
+type BookSnapshot struct {
+	Version    int64      `json:"version"`
+	Height     int64      `json:"height"`
+	MarketInfo MarketInfo `json:"market_info"`
+	Orders     []*Order   `json:"orders"`
+	// The effective time of the pending delist request, zero means no request
+	DelistTime int64 `json:"delist_time,omitempty"`
+}
+
+func (book BookSnapshot) Validate() error {
+	if book.Version != BookSnapshotVersion {
+		return fmt.Errorf("unsupported book snapshot version %d, expected %d", book.Version, BookSnapshotVersion)
+	}
+	info := book.MarketInfo
+	symbol := info.GetSymbol()
+	if !IsValidTradingPair([]string{info.Stock, info.Money}) {
+		return fmt.Errorf("invalid trading pair %s in book snapshot", symbol)
+	}
+	if info.LastExecutedPrice.IsNil() || info.LastExecutedPrice.IsNegative() {
+		return fmt.Errorf("invalid last executed price of %s in book snapshot", symbol)
+	}
+	if book.DelistTime < 0 {
+		return fmt.Errorf("invalid delist time %d of %s in book snapshot", book.DelistTime, symbol)
+	}
+	orderIDs := make(map[string]struct{}, len(book.Orders))
+	for _, order := range book.Orders {
+		if order.TradingPair != symbol {
+			return fmt.Errorf("order %s does not belong to %s", order.OrderID(), symbol)
+		}
+		if _, exists := orderIDs[order.OrderID()]; exists {
+			return fmt.Errorf("duplicate order %s in book snapshot", order.OrderID())
+		}
+		orderIDs[order.OrderID()] = struct{}{}
+		if (order.Side != BUY && order.Side != SELL) || order.Price.IsNil() || !order.Price.IsPositive() ||
+			order.LeftStock < 0 || order.Freeze < 0 || order.FrozenCommission < 0 || order.FrozenFeatureFee < 0 ||
+			order.PaidMakerRebate < 0 || order.PaidMakerRebate > order.FrozenCommission {
+			return fmt.Errorf("invalid order %s in book snapshot", order.OrderID())
+		}
+	}
+	return nil
+}
+
+// The coins frozen by an order in bankx, including its frozen commission and feature fee,
+// but not the rebates which have been paid to the makers
+func (or *Order) GetFrozenCoins() sdk.Coins {
+	coins := sdk.NewCoins(sdk.NewInt64Coin(or.GetOrderUsedDenom(), or.Freeze))
+	return coins.Add(sdk.NewCoins(sdk.NewInt64Coin(dex.CET, or.FrozenCommission-or.PaidMakerRebate+or.FrozenFeatureFee)))
+}
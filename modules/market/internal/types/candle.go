@@ -0,0 +1,142 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The spans of candles, in seconds
+const (
+	CandleSpanMinute int64 = 60
+	CandleSpanHour   int64 = 60 * 60
+	CandleSpanDay    int64 = 24 * 60 * 60
+
+	// A ticker summarizes the deals in the latest 24 hours, which are aggregated from hourly candles
+	TickerHours = 24
+)
+
+var CandleSpans = []int64{CandleSpanMinute, CandleSpanHour, CandleSpanDay}
+
+// The names of the candle spans used by the clients
+var CandleSpanNames = map[string]int64{
+	"1m": CandleSpanMinute,
+	"1h": CandleSpanHour,
+	"1d": CandleSpanDay,
+}
+
+func IsValidCandleSpan(span int64) bool {
+	for _, s := range CandleSpans {
+		if s == span {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the start time of the candle bucket which contains the unix time t
+func CandleStartTime(t, span int64) int64 {
+	return t - t%span
+}
+
+// Candle contains the open/high/low/close prices and the volumes of the deals in a period.
+// The period starts at StartTime and lasts for Span seconds.
+type Candle struct {
+	TradingPair string  `json:"trading_pair"`
+	Span        int64   `json:"span"`
+	StartTime   int64   `json:"start_time"`
+	Open        sdk.Dec `json:"open"`
+	High        sdk.Dec `json:"high"`
+	Low         sdk.Dec `json:"low"`
+	Close       sdk.Dec `json:"close"`
+	StockVolume sdk.Int `json:"stock_volume"`
+	MoneyVolume sdk.Int `json:"money_volume"`
+}
+
+func NewCandle(symbol string, span, startTime int64) Candle {
+	return Candle{
+		TradingPair: symbol,
+		Span:        span,
+		StartTime:   startTime,
+		Open:        sdk.ZeroDec(),
+		High:        sdk.ZeroDec(),
+		Low:         sdk.ZeroDec(),
+		Close:       sdk.ZeroDec(),
+		StockVolume: sdk.ZeroInt(),
+		MoneyVolume: sdk.ZeroInt(),
+	}
+}
+
+// A candle without any deals
+func (c *Candle) IsEmpty() bool {
+	return c.Open.IsNil() || c.Open.IsZero()
+}
+
+// Record a deal, which must be later than the deals already recorded
+func (c *Candle) Update(price sdk.Dec, stockAmount, moneyAmount int64) {
+	c.Merge(Candle{
+		Open:        price,
+		High:        price,
+		Low:         price,
+		Close:       price,
+		StockVolume: sdk.NewInt(stockAmount),
+		MoneyVolume: sdk.NewInt(moneyAmount),
+	})
+}
+
+// Merge the deals of another candle, which must be later than the deals already recorded
+func (c *Candle) Merge(other Candle) {
+	if other.IsEmpty() {
+		return
+	}
+	if c.IsEmpty() {
+		c.Open = other.Open
+		c.High = other.High
+		c.Low = other.Low
+	} else {
+		c.High = sdk.MaxDec(c.High, other.High)
+		c.Low = sdk.MinDec(c.Low, other.Low)
+	}
+	c.Close = other.Close
+	c.StockVolume = c.StockVolume.Add(other.StockVolume)
+	c.MoneyVolume = c.MoneyVolume.Add(other.MoneyVolume)
+}
+
+// Ticker summarizes the deals of a trading pair from StartTime to UpdateTime,
+// which is a window of TickerHours hours, aligned to the hourly candles.
+type Ticker struct {
+	TradingPair string  `json:"trading_pair"`
+	StartTime   int64   `json:"start_time"`
+	UpdateTime  int64   `json:"update_time"`
+	LastPrice   sdk.Dec `json:"last_price"`
+	Open        sdk.Dec `json:"open"`
+	High        sdk.Dec `json:"high"`
+	Low         sdk.Dec `json:"low"`
+	StockVolume sdk.Int `json:"stock_volume"`
+	MoneyVolume sdk.Int `json:"money_volume"`
+}
+
+// Return the start time of the ticker window which ends at the unix time t
+func TickerStartTime(t int64) int64 {
+	return CandleStartTime(t, CandleSpanHour) - (TickerHours-1)*CandleSpanHour
+}
+
+// Build a ticker from the merged hourly candles in its window. If there are no deals in the window,
+// all the prices are the last price.
+func NewTicker(summary Candle, lastPrice sdk.Dec, updateTime int64) Ticker {
+	ticker := Ticker{
+		TradingPair: summary.TradingPair,
+		StartTime:   summary.StartTime,
+		UpdateTime:  updateTime,
+		LastPrice:   lastPrice,
+		Open:        lastPrice,
+		High:        lastPrice,
+		Low:         lastPrice,
+		StockVolume: summary.StockVolume,
+		MoneyVolume: summary.MoneyVolume,
+	}
+	if !summary.IsEmpty() {
+		ticker.Open = summary.Open
+		ticker.High = summary.High
+		ticker.Low = summary.Low
+	}
+	return ticker
+}
@@ -14,9 +14,19 @@ func init() {
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(Order{}, "market/Order", nil)
 	cdc.RegisterConcrete(MarketInfo{}, "market/TradingPair", nil)
+	cdc.RegisterConcrete(ConditionalOrder{}, "market/ConditionalOrder", nil)
+	cdc.RegisterConcrete(Candle{}, "market/Candle", nil)
+	cdc.RegisterConcrete(Ticker{}, "market/Ticker", nil)
 	cdc.RegisterConcrete(MsgCreateTradingPair{}, "market/MsgCreateTradingPair", nil)
 	cdc.RegisterConcrete(MsgCreateOrder{}, "market/MsgCreateOrder", nil)
 	cdc.RegisterConcrete(MsgCancelOrder{}, "market/MsgCancelOrder", nil)
 	cdc.RegisterConcrete(MsgCancelTradingPair{}, "market/MsgCancelTradingPair", nil)
 	cdc.RegisterConcrete(MsgModifyPricePrecision{}, "market/MsgModifyPricePrecision", nil)
+	cdc.RegisterConcrete(MsgCreateConditionalOrder{}, "market/MsgCreateConditionalOrder", nil)
+	cdc.RegisterConcrete(MsgCancelConditionalOrder{}, "market/MsgCancelConditionalOrder", nil)
+	cdc.RegisterConcrete(MsgBatchCreateOrders{}, "market/MsgBatchCreateOrders", nil)
+	cdc.RegisterConcrete(MsgCancelOrders{}, "market/MsgCancelOrders", nil)
+	cdc.RegisterConcrete(MsgModifyOrder{}, "market/MsgModifyOrder", nil)
+	cdc.RegisterConcrete(MsgHaltMarket{}, "market/MsgHaltMarket", nil)
+	cdc.RegisterConcrete(MsgResumeMarket{}, "market/MsgResumeMarket", nil)
 }
@@ -0,0 +1,81 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// A stop-limit order is triggered when the price moves against the order's side:
+	// a buy order when the price rises to TriggerPrice, a sell order when it falls to TriggerPrice.
+	StopLimit byte = 1
+	// A take-profit order is triggered when the price moves in favor of the order's side:
+	// a buy order when the price falls to TriggerPrice, a sell order when it rises to TriggerPrice.
+	TakeProfit byte = 2
+)
+
+// ConditionalOrder is a dormant limit order, which is converted into a normal order
+// once the market's LastExecutedPrice crosses TriggerPrice. No coins are frozen before it is triggered,
+// instead a creation fee is charged, and the order is removed if it is not triggered before ExpireHeight.
+type ConditionalOrder struct {
+	Sender         sdk.AccAddress `json:"sender"`
+	Sequence       uint64         `json:"sequence"`
+	Identify       byte           `json:"identify"`
+	TradingPair    string         `json:"trading_pair"`
+	OrderType      byte           `json:"order_type"`
+	PricePrecision byte           `json:"price_precision"`
+	Price          int64          `json:"price"`
+	Quantity       int64          `json:"quantity"`
+	Side           byte           `json:"side"`
+	TimeInForce    int64          `json:"time_in_force"`
+	ExistBlocks    int64          `json:"exist_blocks"`
+	ConditionType  byte           `json:"condition_type"`
+	TriggerPrice   sdk.Dec        `json:"trigger_price"`
+	Height         int64          `json:"height"`
+
+	ExpireHeight        int64 `json:"expire_height"`
+	SelfTradePrevention byte  `json:"self_trade_prevention,omitempty"`
+}
+
+// The order ID of a conditional order is also used by the order created when it is triggered
+func (co *ConditionalOrder) OrderID() string {
+	return AssemblyOrderID(co.Sender.String(), co.Sequence, co.Identify)
+}
+
+// TriggeredWhenPriceRises returns true if the order is triggered by a price at or above TriggerPrice,
+// and false if it is triggered by a price at or below TriggerPrice
+func (co *ConditionalOrder) TriggeredWhenPriceRises() bool {
+	return (co.ConditionType == StopLimit && co.Side == BUY) ||
+		(co.ConditionType == TakeProfit && co.Side == SELL)
+}
+
+func (co *ConditionalOrder) IsExpired(height int64) bool {
+	return co.ExpireHeight <= height
+}
+
+func (co *ConditionalOrder) IsTriggered(price sdk.Dec) bool {
+	if price.IsZero() {
+		return false
+	}
+	if co.TriggeredWhenPriceRises() {
+		return price.GTE(co.TriggerPrice)
+	}
+	return price.LTE(co.TriggerPrice)
+}
+
+// ToMsgCreateOrder returns the message which is handled just like a MsgCreateOrder when this order is triggered
+func (co *ConditionalOrder) ToMsgCreateOrder() MsgCreateOrder {
+	return MsgCreateOrder{
+		Sender:         co.Sender,
+		Identify:       co.Identify,
+		TradingPair:    co.TradingPair,
+		OrderType:      co.OrderType,
+		PricePrecision: co.PricePrecision,
+		Price:          co.Price,
+		Quantity:       co.Quantity,
+		Side:           co.Side,
+		TimeInForce:    co.TimeInForce,
+		ExistBlocks:    co.ExistBlocks,
+
+		SelfTradePrevention: co.SelfTradePrevention,
+	}
+}
@@ -29,10 +29,56 @@ const (
 	GTE          = 3
 	IOC          = 4
 	LIMIT        = 2
+	// A post-only order rests in the order book like a GTE order, but it is canceled
+	// instead of being matched if it would take liquidity in the block it was created
+	PostOnly = 5
+	// A fill-or-kill order is canceled without any deal unless it can be fully filled
+	// in the block it was created
+	FOK = 6
 )
 
+// IsImmediateTimeInForce returns true for the orders which are removed from the order book
+// at the end of the block they were created in, i.e. the IOC and FOK orders
+func IsImmediateTimeInForce(timeInForce int64) bool {
+	return timeInForce == IOC || timeInForce == FOK
+}
+
+const (
+	// The orders are matched in a call auction at the end of each block, and all the deals share a single price
+	CallAuctionMatching byte = 0
+	// The orders are matched by price-time priority, and each deal happens at the price of the earlier order
+	ContinuousMatching byte = 1
+)
+
+func IsValidMatchingMode(mode byte) bool {
+	return mode == CallAuctionMatching || mode == ContinuousMatching
+}
+
+// When an order would trade with another order of the same owner, the self-trade prevention mode
+// of the newer one of the two orders decides what happens instead of the trade
+const (
+	// The two orders trade as usual
+	SelfTradeAllowed byte = 0
+	// The newer order is canceled
+	SelfTradeCancelNewest byte = 1
+	// The older order is canceled
+	SelfTradeCancelOldest byte = 2
+	// Both the orders are canceled
+	SelfTradeCancelBoth byte = 3
+	// Both the orders are decremented by the smaller amount of them, and the one left with no amount is canceled
+	SelfTradeDecrement byte = 4
+)
+
+func IsValidSelfTradePrevention(mode byte) bool {
+	return mode <= SelfTradeDecrement
+}
+
 const (
 	IntegrationNetSubString       = "coinex-integrationtest"
 	MaxOrderAmount          int64 = 1e18
 	MaxOrderPrecision       byte  = 8
+	MaxBatchSize                  = 100
+
+	// at most so many conditional orders are triggered in a block, the others wait for the next blocks
+	MaxTriggeredConditionalOrders = 100
 )
@@ -37,6 +37,15 @@ const (
 	CodeOrderAlreadyExist      sdk.CodeType = 630
 	CodeDelistRequestExist     sdk.CodeType = 632
 	CodeInvalidMarket          sdk.CodeType = 633
+	CodeInvalidConditionType   sdk.CodeType = 634
+	CodeInvalidTriggerPrice    sdk.CodeType = 635
+	CodeInvalidBatchSize       sdk.CodeType = 636
+	CodeInvalidModification    sdk.CodeType = 637
+	CodeInvalidMatchingMode    sdk.CodeType = 638
+	CodeMarketHalted           sdk.CodeType = 639
+	CodeMarketNotHalted        sdk.CodeType = 640
+	CodeInvalidSelfTrade       sdk.CodeType = 641
+	CodeInvalidBookSnapshot    sdk.CodeType = 642
 )
 
 func ErrFailedParseParam() sdk.Error {
@@ -52,7 +61,7 @@ func ErrInvalidExistBlocks(eb int64) sdk.Error {
 }
 
 func ErrInvalidTimeInForce(tif int64) sdk.Error {
-	return sdk.NewError(CodeSpaceMarket, CodeInvalidTimeInForce, fmt.Sprintf("Invalid timeInForce : %d; The valid value : 3, 4", tif))
+	return sdk.NewError(CodeSpaceMarket, CodeInvalidTimeInForce, fmt.Sprintf("Invalid timeInForce : %d; The valid value : 3, 4, 5, 6", tif))
 }
 
 func ErrDelistNotAllowed(s string) sdk.Error {
@@ -158,3 +167,39 @@ func ErrOrderAmountTooSmall(err string) sdk.Error {
 func ErrDelistRequestExist(market string) sdk.Error {
 	return sdk.NewError(CodeSpaceMarket, CodeDelistRequestExist, "The delist request for %s already exists", market)
 }
+
+func ErrInvalidConditionType(ct byte) sdk.Error {
+	return sdk.NewError(CodeSpaceMarket, CodeInvalidConditionType, fmt.Sprintf("Invalid condition type : %d; The valid value : 1, 2", ct))
+}
+
+func ErrInvalidTriggerPrice(price int64) sdk.Error {
+	return sdk.NewError(CodeSpaceMarket, CodeInvalidTriggerPrice, "Invalid trigger price : %d", price)
+}
+
+func ErrInvalidBatchSize(size int) sdk.Error {
+	return sdk.NewError(CodeSpaceMarket, CodeInvalidBatchSize, "Invalid batch size : %d; The range of expected values [1, %d]", size, MaxBatchSize)
+}
+
+func ErrInvalidModification(s string) sdk.Error {
+	return sdk.NewError(CodeSpaceMarket, CodeInvalidModification, s)
+}
+
+func ErrInvalidMatchingMode(mode byte) sdk.Error {
+	return sdk.NewError(CodeSpaceMarket, CodeInvalidMatchingMode, fmt.Sprintf("Invalid matching mode : %d; The valid value : 0, 1", mode))
+}
+
+func ErrInvalidSelfTradePrevention(mode byte) sdk.Error {
+	return sdk.NewError(CodeSpaceMarket, CodeInvalidSelfTrade, fmt.Sprintf("Invalid self-trade prevention mode : %d; The valid value : 0, 1, 2, 3, 4", mode))
+}
+
+func ErrInvalidBookSnapshot(s string) sdk.Error {
+	return sdk.NewError(CodeSpaceMarket, CodeInvalidBookSnapshot, s)
+}
+
+func ErrMarketHalted(symbol string) sdk.Error {
+	return sdk.NewError(CodeSpaceMarket, CodeMarketHalted, "The trading of %s is halted", symbol)
+}
+
+func ErrMarketNotHalted(symbol string) sdk.Error {
+	return sdk.NewError(CodeSpaceMarket, CodeMarketNotHalted, "The trading of %s is not halted", symbol)
+}
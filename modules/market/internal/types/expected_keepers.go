@@ -20,6 +20,7 @@ type ExpectedBankxKeeper interface {
 	SendCoins(ctx sdk.Context, from sdk.AccAddress, to sdk.AccAddress, amt sdk.Coins) sdk.Error // to tranfer coins
 	FreezeCoins(ctx sdk.Context, acc sdk.AccAddress, amt sdk.Coins) sdk.Error                   // freeze some coins when creating orders
 	UnFreezeCoins(ctx sdk.Context, acc sdk.AccAddress, amt sdk.Coins) sdk.Error                 // unfreeze coins and then orders can be executed
+	GetFrozenCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
 }
 
 // Asset Keeper will implement the interface
@@ -33,6 +34,7 @@ type ExpectedAssetStatusKeeper interface {
 
 type ExpectedChargeFeeKeeper interface {
 	SubtractFeeAndCollectFee(ctx sdk.Context, addr sdk.AccAddress, amt int64) sdk.Error
+	GetFeeDiscount(ctx sdk.Context, addr sdk.AccAddress) int64
 }
 
 type ExpectedAuthXKeeper interface {
@@ -40,3 +42,9 @@ type ExpectedAuthXKeeper interface {
 	GetRebateRatio(ctx sdk.Context) int64
 	GetRebateRatioBase(ctx sdk.Context) int64
 }
+
+// Oracle Keeper will implement the interface
+type ExpectedOracleKeeper interface {
+	RecordPrice(ctx sdk.Context, symbol string, price sdk.Dec)                 // record the executed price of a trading pair
+	GetTWAP(ctx sdk.Context, symbol string, window int64) (sdk.Dec, sdk.Error) // the time-weighted average price over the last window seconds
+}
@@ -14,6 +14,12 @@ type MarketInfo struct {
 	PricePrecision    byte    `json:"price_precision"`
 	LastExecutedPrice sdk.Dec `json:"last_executed_price"`
 	OrderPrecision    byte    `json:"order_precision"`
+	MatchingMode      byte    `json:"matching_mode"`
+	// A halted market does not accept new orders and is skipped when matching
+	Halted bool `json:"halted"`
+	// The reference price and its unix time for the circuit breaker
+	BreakerRefPrice sdk.Dec `json:"breaker_ref_price"`
+	BreakerRefTime  int64   `json:"breaker_ref_time"`
 }
 
 func GetGranularityOfOrder(orderPrecision byte) int64 {
@@ -26,3 +32,21 @@ func GetGranularityOfOrder(orderPrecision byte) int64 {
 func (msg MarketInfo) GetSymbol() string {
 	return dex.GetSymbol(msg.Stock, msg.Money)
 }
+
+// Halt the market if the price has moved more than ratio percent from the reference price
+// within window seconds. Otherwise, when the window expires, the reference is reset to
+// oldPrice, which is the price before the deals at now. A non-positive ratio disables the breaker.
+func (msg *MarketInfo) CheckCircuitBreaker(oldPrice sdk.Dec, now, window, ratio int64) bool {
+	if msg.BreakerRefPrice.IsNil() || msg.BreakerRefPrice.IsZero() || now-msg.BreakerRefTime >= window {
+		msg.BreakerRefPrice = oldPrice
+		msg.BreakerRefTime = now
+	}
+	if ratio <= 0 || msg.BreakerRefPrice.IsZero() {
+		return false
+	}
+	change := msg.LastExecutedPrice.Sub(msg.BreakerRefPrice).Abs().MulInt64(100)
+	if change.GT(msg.BreakerRefPrice.MulInt64(ratio)) {
+		msg.Halted = true
+	}
+	return msg.Halted
+}
@@ -19,6 +19,13 @@ const (
 	CreateOrderInfoKey  = "create_order_info"
 	FillOrderInfoKey    = "fill_order_info"
 	CancelOrderInfoKey  = "del_order_info"
+
+	CreateConditionalOrderInfoKey  = "create_conditional_order_info"
+	TriggerConditionalOrderInfoKey = "trigger_conditional_order_info"
+	CancelConditionalOrderInfoKey  = "del_conditional_order_info"
+
+	ModifyOrderInfoKey = "modify_order_info"
+	HaltMarketInfoKey  = "halt_market_info"
 )
 
 // cancel order of reasons
@@ -29,6 +36,11 @@ const (
 	CancelOrderByIocType       = "IOC order cancel "
 	CancelOrderByNoEnoughMoney = "Insufficient freeze money"
 	CancelOrderByNotKnow       = "Don't know"
+	CancelOrderByTriggerFailed = "Failed to create the triggered order"
+	CancelOrderByDelist        = "The market was delisted"
+	CancelOrderByPostOnly      = "Post-only order would take liquidity"
+	CancelOrderByFokType       = "FOK order could not be fully filled"
+	CancelOrderBySelfTrade     = "Self-trade prevention"
 )
 
 // /////////////////////////////////////////////////////////
@@ -42,6 +54,7 @@ type MsgCreateTradingPair struct {
 	Creator        sdk.AccAddress `json:"creator"`
 	PricePrecision byte           `json:"price_precision"`
 	OrderPrecision byte           `json:"order_precision"` // not used
+	MatchingMode   byte           `json:"matching_mode"`
 }
 
 func NewMsgCreateTradingPair(stock, money string, creator sdk.AccAddress, pricePrecision byte, orderPrecision byte) MsgCreateTradingPair {
@@ -82,6 +95,9 @@ func (msg MsgCreateTradingPair) ValidateBasic() sdk.Error {
 	if msg.Money == msg.Stock {
 		return ErrStockAndMoneyAreSame()
 	}
+	if !IsValidMatchingMode(msg.MatchingMode) {
+		return ErrInvalidMatchingMode(msg.MatchingMode)
+	}
 	return nil
 }
 
@@ -109,6 +125,8 @@ type MsgCreateOrder struct {
 	Side           byte           `json:"side"`
 	TimeInForce    int64          `json:"time_in_force"`
 	ExistBlocks    int64          `json:"exist_blocks"`
+	// SelfTradePrevention decides what happens when the order would trade with another order of the sender
+	SelfTradePrevention byte `json:"self_trade_prevention,omitempty"`
 }
 
 func (msg *MsgCreateOrder) SetAccAddress(address sdk.AccAddress) {
@@ -141,12 +159,15 @@ func (msg MsgCreateOrder) ValidateBasic() sdk.Error {
 	if msg.Side != BUY && msg.Side != SELL {
 		return ErrInvalidTradeSide()
 	}
-	if msg.TimeInForce != GTE && msg.TimeInForce != IOC {
+	if msg.TimeInForce != GTE && msg.TimeInForce != IOC && msg.TimeInForce != PostOnly && msg.TimeInForce != FOK {
 		return ErrInvalidTimeInForce(msg.TimeInForce)
 	}
 	if msg.ExistBlocks < 0 {
 		return ErrInvalidExistBlocks(msg.ExistBlocks)
 	}
+	if !IsValidSelfTradePrevention(msg.SelfTradePrevention) {
+		return ErrInvalidSelfTradePrevention(msg.SelfTradePrevention)
+	}
 
 	return nil
 }
@@ -312,3 +333,323 @@ func (msg MsgModifyPricePrecision) GetSignBytes() []byte {
 func (msg MsgModifyPricePrecision) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Sender}
 }
+
+// /////////////////////////////////////////////////////////
+// MsgCreateConditionalOrder
+
+var _ sdk.Msg = MsgCreateConditionalOrder{}
+
+type MsgCreateConditionalOrder struct {
+	Sender         sdk.AccAddress `json:"sender"`
+	Identify       byte           `json:"identify"`
+	TradingPair    string         `json:"trading_pair"`
+	OrderType      byte           `json:"order_type"`
+	PricePrecision byte           `json:"price_precision"`
+	Price          int64          `json:"price"`
+	Quantity       int64          `json:"quantity"`
+	Side           byte           `json:"side"`
+	TimeInForce    int64          `json:"time_in_force"`
+	ExistBlocks    int64          `json:"exist_blocks"`
+	ConditionType  byte           `json:"condition_type"`
+	TriggerPrice   int64          `json:"trigger_price"` // uses the same precision as Price
+	// SelfTradePrevention is used by the order created when this order is triggered
+	SelfTradePrevention byte `json:"self_trade_prevention,omitempty"`
+}
+
+func (msg *MsgCreateConditionalOrder) SetAccAddress(address sdk.AccAddress) {
+	msg.Sender = address
+}
+
+func (msg MsgCreateConditionalOrder) Route() string { return RouterKey }
+
+func (msg MsgCreateConditionalOrder) Type() string { return "create_conditional_order" }
+
+func (msg MsgCreateConditionalOrder) ValidateBasic() sdk.Error {
+	if err := msg.ToMsgCreateOrder().ValidateBasic(); err != nil {
+		return err
+	}
+	// a triggered IOC order could not be matched in the block it was created
+	if msg.TimeInForce != GTE {
+		return ErrInvalidTimeInForce(msg.TimeInForce)
+	}
+	if msg.ConditionType != StopLimit && msg.ConditionType != TakeProfit {
+		return ErrInvalidConditionType(msg.ConditionType)
+	}
+	if msg.TriggerPrice <= 0 {
+		return ErrInvalidTriggerPrice(msg.TriggerPrice)
+	}
+	return nil
+}
+
+func (msg MsgCreateConditionalOrder) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCreateConditionalOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+func (msg MsgCreateConditionalOrder) ToMsgCreateOrder() MsgCreateOrder {
+	return MsgCreateOrder{
+		Sender:         msg.Sender,
+		Identify:       msg.Identify,
+		TradingPair:    msg.TradingPair,
+		OrderType:      msg.OrderType,
+		PricePrecision: msg.PricePrecision,
+		Price:          msg.Price,
+		Quantity:       msg.Quantity,
+		Side:           msg.Side,
+		TimeInForce:    msg.TimeInForce,
+		ExistBlocks:    msg.ExistBlocks,
+
+		SelfTradePrevention: msg.SelfTradePrevention,
+	}
+}
+
+// /////////////////////////////////////////////////////////
+// MsgCancelConditionalOrder
+
+var _ sdk.Msg = MsgCancelConditionalOrder{}
+
+type MsgCancelConditionalOrder struct {
+	Sender  sdk.AccAddress `json:"sender"`
+	OrderID string         `json:"order_id"`
+}
+
+func (msg *MsgCancelConditionalOrder) SetAccAddress(address sdk.AccAddress) {
+	msg.Sender = address
+}
+
+func (msg MsgCancelConditionalOrder) Route() string { return RouterKey }
+
+func (msg MsgCancelConditionalOrder) Type() string { return "cancel_conditional_order" }
+
+func (msg MsgCancelConditionalOrder) ValidateBasic() sdk.Error {
+	if err := sdk.VerifyAddressFormat(msg.Sender); err != nil {
+		return ErrInvalidAddress()
+	}
+	return ValidateOrderID(msg.OrderID)
+}
+
+func (msg MsgCancelConditionalOrder) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCancelConditionalOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// /////////////////////////////////////////////////////////
+// MsgBatchCreateOrders
+
+// MsgBatchCreateOrders creates several orders at once. All the orders share the sequence of the
+// transaction, so they must have different identifies. The batch is atomic: if any order fails,
+// none of them is created.
+type MsgBatchCreateOrders struct {
+	Sender sdk.AccAddress   `json:"sender"`
+	Orders []MsgCreateOrder `json:"orders"`
+}
+
+func (msg *MsgBatchCreateOrders) SetAccAddress(address sdk.AccAddress) {
+	msg.Sender = address
+	for i := range msg.Orders {
+		msg.Orders[i].Sender = address
+	}
+}
+
+func (msg MsgBatchCreateOrders) Route() string { return RouterKey }
+
+func (msg MsgBatchCreateOrders) Type() string { return "batch_create_orders" }
+
+func (msg MsgBatchCreateOrders) ValidateBasic() sdk.Error {
+	if err := sdk.VerifyAddressFormat(msg.Sender); err != nil {
+		return ErrInvalidAddress()
+	}
+	if len(msg.Orders) == 0 || len(msg.Orders) > MaxBatchSize {
+		return ErrInvalidBatchSize(len(msg.Orders))
+	}
+	identifies := make(map[byte]struct{}, len(msg.Orders))
+	for _, order := range msg.Orders {
+		if !msg.Sender.Equals(order.Sender) {
+			return ErrNotMatchSender("all the orders in a batch must have the same sender")
+		}
+		if err := order.ValidateBasic(); err != nil {
+			return err
+		}
+		if _, ok := identifies[order.Identify]; ok {
+			return ErrOrderAlreadyExist(AssemblyOrderID(msg.Sender.String(), 0, order.Identify))
+		}
+		identifies[order.Identify] = struct{}{}
+	}
+	return nil
+}
+
+func (msg MsgBatchCreateOrders) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgBatchCreateOrders) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// /////////////////////////////////////////////////////////
+// MsgCancelOrders
+
+// MsgCancelOrders cancels the orders listed in OrderIDs. If TradingPair is not empty, all the sender's
+// orders in this trading pair are also canceled. The cancellation is atomic: if any listed order can
+// not be canceled, none of them is canceled.
+type MsgCancelOrders struct {
+	Sender      sdk.AccAddress `json:"sender"`
+	OrderIDs    []string       `json:"order_ids"`
+	TradingPair string         `json:"trading_pair"`
+}
+
+func (msg *MsgCancelOrders) SetAccAddress(address sdk.AccAddress) {
+	msg.Sender = address
+}
+
+func (msg MsgCancelOrders) Route() string { return RouterKey }
+
+func (msg MsgCancelOrders) Type() string { return "cancel_orders" }
+
+func (msg MsgCancelOrders) ValidateBasic() sdk.Error {
+	if err := sdk.VerifyAddressFormat(msg.Sender); err != nil {
+		return ErrInvalidAddress()
+	}
+	if len(msg.OrderIDs) > MaxBatchSize || (len(msg.OrderIDs) == 0 && len(msg.TradingPair) == 0) {
+		return ErrInvalidBatchSize(len(msg.OrderIDs))
+	}
+	if len(msg.TradingPair) != 0 && !IsValidTradingPair(strings.Split(msg.TradingPair, SymbolSeparator)) {
+		return ErrInvalidSymbol()
+	}
+	for _, id := range msg.OrderIDs {
+		if err := ValidateOrderID(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (msg MsgCancelOrders) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCancelOrders) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// /////////////////////////////////////////////////////////
+// MsgModifyOrder
+
+// MsgModifyOrder changes the price and/or reduces the left stock of an open order.
+// A zero Price keeps the current price, and a zero Quantity keeps the current left stock.
+type MsgModifyOrder struct {
+	Sender         sdk.AccAddress `json:"sender"`
+	OrderID        string         `json:"order_id"`
+	PricePrecision byte           `json:"price_precision"`
+	Price          int64          `json:"price"`
+	Quantity       int64          `json:"quantity"`
+}
+
+func (msg *MsgModifyOrder) SetAccAddress(address sdk.AccAddress) {
+	msg.Sender = address
+}
+
+func (msg MsgModifyOrder) Route() string { return RouterKey }
+
+func (msg MsgModifyOrder) Type() string { return "modify_order" }
+
+func (msg MsgModifyOrder) ValidateBasic() sdk.Error {
+	if err := sdk.VerifyAddressFormat(msg.Sender); err != nil {
+		return ErrInvalidAddress()
+	}
+	if err := ValidateOrderID(msg.OrderID); err != nil {
+		return err
+	}
+	if msg.Price == 0 && msg.Quantity == 0 {
+		return ErrInvalidModification("neither price nor quantity is modified")
+	}
+	if p := msg.PricePrecision; p > MaxTokenPricePrecision {
+		return ErrInvalidPricePrecision(p)
+	}
+	if msg.Price < 0 {
+		return ErrInvalidPrice(msg.Price)
+	}
+	if msg.Quantity < 0 {
+		return ErrOrderAmountTooSmall(fmt.Sprintf("%d", msg.Quantity))
+	}
+	return nil
+}
+
+func (msg MsgModifyOrder) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgModifyOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// /////////////////////////////////////////////////////////
+// MsgHaltMarket and MsgResumeMarket
+
+// MsgHaltMarket stops the trading of a market, which can only be sent by the stock's issuer
+type MsgHaltMarket struct {
+	Sender      sdk.AccAddress `json:"sender"`
+	TradingPair string         `json:"trading_pair"`
+}
+
+func (msg *MsgHaltMarket) SetAccAddress(address sdk.AccAddress) {
+	msg.Sender = address
+}
+
+func (msg MsgHaltMarket) Route() string { return RouterKey }
+
+func (msg MsgHaltMarket) Type() string { return "halt_market" }
+
+func (msg MsgHaltMarket) ValidateBasic() sdk.Error {
+	return validateMarketOperation(msg.Sender, msg.TradingPair)
+}
+
+func (msg MsgHaltMarket) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgHaltMarket) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgResumeMarket restarts the trading of a halted market, which can only be sent by the stock's issuer
+type MsgResumeMarket struct {
+	Sender      sdk.AccAddress `json:"sender"`
+	TradingPair string         `json:"trading_pair"`
+}
+
+func (msg *MsgResumeMarket) SetAccAddress(address sdk.AccAddress) {
+	msg.Sender = address
+}
+
+func (msg MsgResumeMarket) Route() string { return RouterKey }
+
+func (msg MsgResumeMarket) Type() string { return "resume_market" }
+
+func (msg MsgResumeMarket) ValidateBasic() sdk.Error {
+	return validateMarketOperation(msg.Sender, msg.TradingPair)
+}
+
+func (msg MsgResumeMarket) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgResumeMarket) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+func validateMarketOperation(sender sdk.AccAddress, tradingPair string) sdk.Error {
+	if err := sdk.VerifyAddressFormat(sender); err != nil {
+		return ErrInvalidAddress()
+	}
+	if !IsValidTradingPair(strings.Split(tradingPair, SymbolSeparator)) {
+		return ErrInvalidSymbol()
+	}
+	return nil
+}
@@ -36,6 +36,8 @@ type CreateOrderInfo struct {
 	FrozenCommission int64   `json:"frozen_commission"`
 	FrozenFeatureFee int64   `json:"frozen_feature_fee"`
 	Freeze           int64   `json:"freeze"`
+
+	SelfTradePrevention byte `json:"self_trade_prevention,omitempty"`
 }
 
 type FillOrderInfo struct {
@@ -65,7 +67,8 @@ type CancelOrderInfo struct {
 	// Del infos
 	DelReason string `json:"del_reason"`
 
-	// Fields of amount
+	// Fields of amount, UsedCommission includes the rebates paid to the makers when the order was a taker,
+	// and MakerRebate is the rebates received when it was a maker
 	UsedCommission    int64  `json:"used_commission"`
 	UsedFeatureFee    int64  `json:"used_feature_fee"`
 	RebateAmount      int64  `json:"rebate_amount"`
@@ -74,6 +77,8 @@ type CancelOrderInfo struct {
 	RemainAmount      int64  `json:"remain_amount"`
 	DealStock         int64  `json:"deal_stock"`
 	DealMoney         int64  `json:"deal_money"`
+	MakerDealStock    int64  `json:"maker_deal_stock"`
+	MakerRebate       int64  `json:"maker_rebate"`
 }
 
 type ModifyPricePrecisionInfo struct {
@@ -82,3 +87,56 @@ type ModifyPricePrecisionInfo struct {
 	OldPricePrecision byte   `json:"old_price_precision"`
 	NewPricePrecision byte   `json:"new_price_precision"`
 }
+
+type CreateConditionalOrderInfo struct {
+	OrderID       string  `json:"order_id"`
+	Sender        string  `json:"sender"`
+	TradingPair   string  `json:"trading_pair"`
+	OrderType     byte    `json:"order_type"`
+	Price         sdk.Dec `json:"price"`
+	Quantity      int64   `json:"quantity"`
+	Side          byte    `json:"side"`
+	TimeInForce   int64   `json:"time_in_force"`
+	Height        int64   `json:"height"`
+	ConditionType byte    `json:"condition_type"`
+	TriggerPrice  sdk.Dec `json:"trigger_price"`
+
+	SelfTradePrevention byte `json:"self_trade_prevention,omitempty"`
+}
+
+type TriggerConditionalOrderInfo struct {
+	OrderID      string  `json:"order_id"`
+	TradingPair  string  `json:"trading_pair"`
+	Height       int64   `json:"height"`
+	TriggerPrice sdk.Dec `json:"trigger_price"`
+	// the last executed price which triggered this order
+	ExecutedPrice sdk.Dec `json:"executed_price"`
+}
+
+type CancelConditionalOrderInfo struct {
+	OrderID     string `json:"order_id"`
+	TradingPair string `json:"trading_pair"`
+	Height      int64  `json:"height"`
+	DelReason   string `json:"del_reason"`
+}
+
+type ModifyOrderInfo struct {
+	OrderID     string  `json:"order_id"`
+	TradingPair string  `json:"trading_pair"`
+	Side        byte    `json:"side"`
+	OldPrice    sdk.Dec `json:"old_price"`
+	Price       sdk.Dec `json:"price"`
+	// the height of the order, which is changed only when the price is changed
+	Height    int64 `json:"height"`
+	LeftStock int64 `json:"left_stock"`
+	Freeze    int64 `json:"freeze"`
+}
+
+// MarketHaltInfo is sent when a market is halted or resumed. The Operator is empty
+// when the market is halted by the circuit breaker.
+type MarketHaltInfo struct {
+	TradingPair       string  `json:"trading_pair"`
+	Halted            bool    `json:"halted"`
+	Operator          string  `json:"operator"`
+	LastExecutedPrice sdk.Dec `json:"last_executed_price"`
+}
@@ -78,6 +78,19 @@ func TestMsgCreateOrder(t *testing.T) {
 	msg.ExistBlocks = 10000
 	err = msg.ValidateBasic()
 	require.EqualValues(t, nil, err)
+	msg.TimeInForce = PostOnly
+	require.Nil(t, msg.ValidateBasic())
+	msg.TimeInForce = FOK
+	require.Nil(t, msg.ValidateBasic())
+	msg.TimeInForce = FOK + 1
+	require.EqualValues(t, CodeInvalidTimeInForce, msg.ValidateBasic().Code())
+
+	// Self-trade prevention
+	msg.TimeInForce = GTE
+	msg.SelfTradePrevention = SelfTradeDecrement
+	require.Nil(t, msg.ValidateBasic())
+	msg.SelfTradePrevention = SelfTradeDecrement + 1
+	require.EqualValues(t, CodeInvalidSelfTrade, msg.ValidateBasic().Code())
 }
 
 func TestMsgCancelOrder(t *testing.T) {
@@ -147,8 +160,14 @@ func TestMsgCreateTradingPair(t *testing.T) {
 	err = msg.ValidateBasic()
 	require.EqualValues(t, CodeInvalidPricePrecision, err.Code())
 
-	// Success
+	// Invalid matching mode
 	msg.PricePrecision = MaxTokenPricePrecision - 1
+	msg.MatchingMode = ContinuousMatching + 1
+	err = msg.ValidateBasic()
+	require.EqualValues(t, CodeInvalidMatchingMode, err.Code())
+
+	// Success
+	msg.MatchingMode = ContinuousMatching
 	err = msg.ValidateBasic()
 	require.EqualValues(t, nil, err)
 }
@@ -227,3 +246,126 @@ func TestMsgModifyPricePrecision(t *testing.T) {
 	err = msg.ValidateBasic()
 	require.EqualValues(t, ErrInvalidPricePrecision(msg.PricePrecision), err)
 }
+
+func TestMsgCreateConditionalOrder(t *testing.T) {
+	addr, failed := sdk.AccAddressFromHex("0123456789012345678901234567890123423456")
+	require.Nil(t, failed)
+	msg := MsgCreateConditionalOrder{
+		Sender:         addr,
+		TradingPair:    "chs/cet",
+		OrderType:      LimitOrder,
+		PricePrecision: 8,
+		Price:          10,
+		Quantity:       100,
+		Side:           BUY,
+		TimeInForce:    IOC,
+	}
+	require.EqualValues(t, CodeInvalidTimeInForce, msg.ValidateBasic().Code())
+
+	msg.TimeInForce = GTE
+	require.EqualValues(t, CodeInvalidConditionType, msg.ValidateBasic().Code())
+
+	msg.ConditionType = TakeProfit
+	require.EqualValues(t, CodeInvalidTriggerPrice, msg.ValidateBasic().Code())
+
+	msg.TriggerPrice = 8
+	require.Nil(t, msg.ValidateBasic())
+
+	msg.SelfTradePrevention = SelfTradeDecrement + 1
+	require.EqualValues(t, CodeInvalidSelfTrade, msg.ValidateBasic().Code())
+	msg.SelfTradePrevention = SelfTradeDecrement
+	require.Nil(t, msg.ValidateBasic())
+
+	msg.Price = 0
+	require.EqualValues(t, CodeInvalidPrice, msg.ValidateBasic().Code())
+}
+
+func TestMsgBatchCreateOrders(t *testing.T) {
+	addr, failed := sdk.AccAddressFromHex("0123456789012345678901234567890123423456")
+	require.Nil(t, failed)
+	order := MsgCreateOrder{
+		TradingPair:    "abc/cet",
+		OrderType:      LIMIT,
+		PricePrecision: 8,
+		Price:          100,
+		Quantity:       100,
+		Side:           BUY,
+		TimeInForce:    GTE,
+		Identify:       1,
+	}
+	msg := MsgBatchCreateOrders{Sender: addr}
+	require.EqualValues(t, CodeInvalidBatchSize, msg.ValidateBasic().Code())
+
+	msg.Orders = []MsgCreateOrder{order, order}
+	require.EqualValues(t, CodeNotMatchSender, msg.ValidateBasic().Code())
+
+	msg.SetAccAddress(addr)
+	require.EqualValues(t, CodeOrderAlreadyExist, msg.ValidateBasic().Code())
+
+	msg.Orders[1].Identify = 2
+	msg.Orders[1].Price = 0
+	require.EqualValues(t, CodeInvalidPrice, msg.ValidateBasic().Code())
+
+	msg.Orders[1].Price = 100
+	require.Nil(t, msg.ValidateBasic())
+}
+
+func TestMsgCancelOrders(t *testing.T) {
+	addr, failed := sdk.AccAddressFromHex("0123456789012345678901234567890123423456")
+	require.Nil(t, failed)
+	msg := MsgCancelOrders{Sender: addr}
+	require.EqualValues(t, CodeInvalidBatchSize, msg.ValidateBasic().Code())
+
+	msg.TradingPair = "abc"
+	require.EqualValues(t, CodeInvalidSymbol, msg.ValidateBasic().Code())
+
+	msg.TradingPair = "abc/cet"
+	require.Nil(t, msg.ValidateBasic())
+
+	msg.OrderIDs = []string{"invalid"}
+	require.EqualValues(t, CodeInvalidOrderID, msg.ValidateBasic().Code())
+
+	msg.OrderIDs = []string{AssemblyOrderID(addr.String(), 1, 1)}
+	msg.TradingPair = ""
+	require.Nil(t, msg.ValidateBasic())
+}
+
+func TestMsgModifyOrder(t *testing.T) {
+	addr, failed := sdk.AccAddressFromHex("0123456789012345678901234567890123423456")
+	require.Nil(t, failed)
+	msg := MsgModifyOrder{Sender: addr, OrderID: "invalid"}
+	require.EqualValues(t, CodeInvalidOrderID, msg.ValidateBasic().Code())
+
+	msg.OrderID = AssemblyOrderID(addr.String(), 1, 1)
+	require.EqualValues(t, CodeInvalidModification, msg.ValidateBasic().Code())
+
+	msg.Price = -1
+	require.EqualValues(t, CodeInvalidPrice, msg.ValidateBasic().Code())
+
+	msg.Price = 100
+	msg.PricePrecision = MaxTokenPricePrecision + 1
+	require.EqualValues(t, CodeInvalidPricePrecision, msg.ValidateBasic().Code())
+
+	msg.PricePrecision = 8
+	msg.Quantity = -1
+	require.EqualValues(t, CodeInvalidOrderAmount, msg.ValidateBasic().Code())
+
+	msg.Quantity = 100
+	require.Nil(t, msg.ValidateBasic())
+}
+
+func TestMsgHaltAndResumeMarket(t *testing.T) {
+	addr, failed := sdk.AccAddressFromHex("0123456789012345678901234567890123423456")
+	require.Nil(t, failed)
+	halt := MsgHaltMarket{Sender: addr, TradingPair: "abc/cet"}
+	require.Nil(t, halt.ValidateBasic())
+	require.Equal(t, "halt_market", halt.Type())
+	resume := MsgResumeMarket{Sender: addr, TradingPair: "abc/cet"}
+	require.Nil(t, resume.ValidateBasic())
+	require.Equal(t, "resume_market", resume.Type())
+
+	halt.Sender = []byte("superman")
+	require.EqualValues(t, ErrInvalidAddress(), halt.ValidateBasic())
+	resume.TradingPair = "abc-cet"
+	require.EqualValues(t, ErrInvalidSymbol(), resume.ValidateBasic())
+}
@@ -23,12 +23,21 @@ type Order struct {
 	ExistBlocks      int64          `json:"exist_blocks"`
 	FrozenFeatureFee int64          `json:"frozen_feature_fee"`   // DEX2
 	FrozenFee        int64          `json:"frozen_fee,omitempty"` // DEX2: -> frozen_commission
+	// The height from which the feature fee is charged, if the order was re-queued at a new height
+	FeatureFeeHeight int64 `json:"feature_fee_height,omitempty"`
+	// The self-trade prevention mode, see SelfTradeAllowed and the other modes
+	SelfTradePrevention byte `json:"self_trade_prevention,omitempty"`
 
 	// These fields will change when order was filled/canceled.
 	LeftStock int64 `json:"left_stock"`
 	Freeze    int64 `json:"freeze"`
 	DealStock int64 `json:"deal_stock"`
 	DealMoney int64 `json:"deal_money"`
+	// The part of DealStock which was dealt when this order was resting in the order book as a maker
+	MakerDealStock int64 `json:"maker_deal_stock"`
+	// The CET received as a maker's rebate, and the CET paid from the frozen commission to the makers as a taker
+	MakerRebate     int64 `json:"maker_rebate"`
+	PaidMakerRebate int64 `json:"paid_maker_rebate"`
 }
 
 func (or *Order) OrderID() string {
@@ -36,10 +45,19 @@ func (or *Order) OrderID() string {
 	return orderID
 }
 
-func (or *Order) CalActualOrderCommissionInt64(feeForZeroDeal int64) int64 {
+// The frozen commission is calculated with the taker fee rate. The stock dealt as a taker is charged
+// in proportion to it, and the stock dealt as a maker is charged at the maker fee rate instead.
+// A negative maker fee rate is not charged here, because the rebates are paid by the takers when dealing.
+func (or *Order) CalActualOrderCommissionInt64(feeForZeroDeal, takerFeeRate, makerFeeRate int64) int64 {
 	actualFee := sdk.NewDec(feeForZeroDeal)
 	if or.DealStock != 0 {
-		actualFee = sdk.NewDec(or.DealStock).Mul(sdk.NewDec(or.FrozenCommission)).Quo(sdk.NewDec(or.Quantity))
+		takerStock := or.DealStock - or.MakerDealStock
+		actualFee = sdk.NewDec(takerStock).Mul(sdk.NewDec(or.FrozenCommission)).Quo(sdk.NewDec(or.Quantity))
+		if or.MakerDealStock != 0 && takerFeeRate != 0 && makerFeeRate > 0 {
+			makerFee := sdk.NewDec(or.MakerDealStock).Mul(sdk.NewDec(or.FrozenCommission)).MulInt64(makerFeeRate).
+				Quo(sdk.NewDec(or.Quantity).MulInt64(takerFeeRate))
+			actualFee = actualFee.Add(makerFee)
+		}
 	}
 	moa := sdk.NewDec(MaxOrderAmount)
 	if actualFee.GT(moa) {
@@ -49,18 +67,38 @@ func (or *Order) CalActualOrderCommissionInt64(feeForZeroDeal int64) int64 {
 	return actualFee.TruncateInt64()
 }
 
+// CalMakerRebateInt64 returns the rebate paid by this order as a taker to the maker of a deal of dealStock.
+// It is a part of the commission charged on dealStock, which is discounted by the fee tier of the sender.
+func (or *Order) CalMakerRebateInt64(dealStock, discount, takerFeeRate, makerFeeRate int64) int64 {
+	if makerFeeRate >= 0 || takerFeeRate <= 0 || or.Quantity == 0 {
+		return 0
+	}
+	fee := sdk.NewDec(dealStock).Mul(sdk.NewDec(or.FrozenCommission)).Quo(sdk.NewDec(or.Quantity)).TruncateInt64()
+	fee = DiscountFee(fee, discount)
+	rebate := sdk.NewInt(fee).MulRaw(-makerFeeRate).QuoRaw(takerFeeRate).Int64()
+	if rebate > or.FrozenCommission-or.PaidMakerRebate {
+		rebate = or.FrozenCommission - or.PaidMakerRebate
+	}
+	return rebate
+}
+
 func (or *Order) CalActualOrderFeatureFeeInt64(ctx sdk.Context, freeTimeBlocks int64) int64 {
-	if or.ExistBlocks <= freeTimeBlocks {
+	height, existBlocks := or.Height, or.ExistBlocks
+	if or.FeatureFeeHeight != 0 {
+		// the order expires at the same height after it is re-queued
+		height, existBlocks = or.FeatureFeeHeight, or.Height+or.ExistBlocks-or.FeatureFeeHeight
+	}
+	if existBlocks <= freeTimeBlocks {
 		fmt.Println("======")
 		return 0
 	}
-	existTime := ctx.BlockHeight() - or.Height + 1
+	existTime := ctx.BlockHeight() - height + 1
 	if existTime < freeTimeBlocks {
 		fmt.Println("---------")
 		return 0
 	}
 	chargeBlocks := existTime - freeTimeBlocks
-	fee := sdk.NewDec(chargeBlocks).MulInt64(or.FrozenFeatureFee).QuoInt64(or.ExistBlocks - freeTimeBlocks).TruncateInt64()
+	fee := sdk.NewDec(chargeBlocks).MulInt64(or.FrozenFeatureFee).QuoInt64(existBlocks - freeTimeBlocks).TruncateInt64()
 	if fee > or.FrozenFeatureFee {
 		fmt.Println("***********")
 		fee = or.FrozenFeatureFee
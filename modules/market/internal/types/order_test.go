@@ -48,16 +48,60 @@ func TestOrderCommission(t *testing.T) {
 	order.DealStock = 0
 	order.FrozenCommission = 10000
 	order.Quantity = 100000
-	require.Equal(t, int64(100), order.CalActualOrderCommissionInt64(100))
+	require.Equal(t, int64(100), order.CalActualOrderCommissionInt64(100, 10, 10))
 	order.DealStock = 50000
-	require.Equal(t, int64(5000), order.CalActualOrderCommissionInt64(100))
+	require.Equal(t, int64(5000), order.CalActualOrderCommissionInt64(100, 10, 10))
 	order.DealStock = 50009
-	require.Equal(t, int64(5000), order.CalActualOrderCommissionInt64(100))
+	require.Equal(t, int64(5000), order.CalActualOrderCommissionInt64(100, 10, 10))
 	order.DealStock = 50010
-	require.Equal(t, int64(5001), order.CalActualOrderCommissionInt64(100))
+	require.Equal(t, int64(5001), order.CalActualOrderCommissionInt64(100, 10, 10))
 	order.FrozenCommission = MaxOrderAmount + 10
 	order.DealStock = 100000
-	require.Equal(t, MaxOrderAmount, order.CalActualOrderCommissionInt64(100))
+	require.Equal(t, MaxOrderAmount, order.CalActualOrderCommissionInt64(100, 10, 10))
+
+	// the stock dealt as a maker is charged at the maker fee rate, and a rebate is not charged here
+	order.FrozenCommission = 10000
+	order.DealStock = 50000
+	order.MakerDealStock = 30000
+	require.Equal(t, int64(3500), order.CalActualOrderCommissionInt64(100, 10, 5))
+	require.Equal(t, int64(2000), order.CalActualOrderCommissionInt64(100, 10, 0))
+	require.Equal(t, int64(2000), order.CalActualOrderCommissionInt64(100, 10, -5))
+	order.MakerDealStock = 50000
+	require.Equal(t, int64(0), order.CalActualOrderCommissionInt64(100, 10, -5))
+	require.Equal(t, int64(0), order.CalActualOrderCommissionInt64(100, 0, 0))
+}
+
+func TestOrder_CalActualOrderFeatureFeeInt64WithFeatureFeeHeight(t *testing.T) {
+	order := Order{
+		Height:           100,
+		ExistBlocks:      1000,
+		FrozenFeatureFee: 900,
+	}
+	ctx := sdk.Context{}.WithBlockHeight(599)
+	require.Equal(t, int64(400), order.CalActualOrderFeatureFeeInt64(ctx, 100))
+	// the order is re-queued at height 500, but it is charged from height 100 as before
+	order.FeatureFeeHeight = 100
+	order.Height = 500
+	order.ExistBlocks = 600
+	require.Equal(t, int64(400), order.CalActualOrderFeatureFeeInt64(ctx, 100))
+}
+
+func TestOrder_CalMakerRebateInt64(t *testing.T) {
+	order := Order{
+		Quantity:         100000,
+		FrozenCommission: 10000,
+	}
+	require.Equal(t, int64(0), order.CalMakerRebateInt64(50000, 0, 10, 5))
+	require.Equal(t, int64(0), order.CalMakerRebateInt64(50000, 0, 10, 0))
+	// half of the taker's commission on the deal
+	require.Equal(t, int64(2500), order.CalMakerRebateInt64(50000, 0, 10, -5))
+	// the taker's commission is discounted
+	require.Equal(t, int64(2000), order.CalMakerRebateInt64(50000, 20, 10, -5))
+	require.Equal(t, int64(1), order.CalMakerRebateInt64(20, 0, 10, -5))
+	require.Equal(t, int64(0), order.CalMakerRebateInt64(10, 0, 10, -5))
+	// no more than the frozen commission is paid
+	order.PaidMakerRebate = 9000
+	require.Equal(t, int64(1000), order.CalMakerRebateInt64(100000, 0, 10, -10))
 }
 
 func TestOrder_CalActualOrderFeatureFeeInt64(t *testing.T) {
@@ -104,7 +148,7 @@ func TestFuzz_CalActualOrderCommissionInt64(t *testing.T) {
 	addr, _ := sdk.AccAddressFromHex("0123456789012345678901234567890123423456")
 	for i := 0; i < 500; i++ {
 		or := getRandOrder(r, addr, &param)
-		or.CalActualOrderCommissionInt64(param.FeeForZeroDeal)
+		or.CalActualOrderCommissionInt64(param.FeeForZeroDeal, param.MarketFeeRate, param.MakerFeeRate)
 	}
 }
 
@@ -15,9 +15,13 @@ const (
 	DefaultMaxExecutedPriceChangeRatio = 25
 	DefaultMarketFeeRatePrecision      = 4
 	DefaultMarketFeeRate               = 10
+	DefaultMakerFeeRate                = 10
 	DefaultMarketFeeMin                = 1000000
 	DefaultFeeForZeroDeal              = 1000000
 	DefaultMarketMinExpiredTime        = 7 * 24 * time.Hour
+	DefaultCandleRetentionCount        = 1440
+	DefaultCircuitBreakerWindow        = 3600
+	DefaultCircuitBreakerRatio         = 0 // off until it is enabled through the params
 )
 
 var (
@@ -27,8 +31,13 @@ var (
 	KeyGTEOrderFeatureFeeByBlocks  = []byte("GTEOrderFeatureFeeByBlocks")
 	KeyMaxExecutedPriceChangeRatio = []byte("MaxExecutedPriceChangeRatio")
 	KeyMarketFeeRate               = []byte("MarketFeeRate")
+	KeyMakerFeeRate                = []byte("MakerFeeRate")
+	KeyFeeTiers                    = []byte("FeeTiers")
 	KeyMarketFeeMin                = []byte("MarketFeeMin")
 	KeyFeeForZeroDeal              = []byte("FeeForZeroDeal")
+	KeyCandleRetentionCount        = []byte("CandleRetentionCount")
+	KeyCircuitBreakerWindow        = []byte("CircuitBreakerWindow")
+	KeyCircuitBreakerRatio         = []byte("CircuitBreakerRatio")
 )
 
 type Params struct {
@@ -40,6 +49,17 @@ type Params struct {
 	MarketFeeRate               int64 `json:"market_fee_rate"`
 	MarketFeeMin                int64 `json:"market_fee_min"`
 	FeeForZeroDeal              int64 `json:"fee_for_zero_deal"`
+	// The number of the latest candles kept for each span, older candles are pruned
+	CandleRetentionCount int64 `json:"candle_retention_count"`
+	// A market is halted when its price moves more than CircuitBreakerRatio percent
+	// within CircuitBreakerWindow seconds. Zero ratio disables the circuit breaker.
+	CircuitBreakerWindow int64 `json:"circuit_breaker_window"`
+	CircuitBreakerRatio  int64 `json:"circuit_breaker_ratio"`
+	// The fee rate for the stock dealt by the orders resting in the order book, while MarketFeeRate
+	// is the taker's fee rate. It can be negative, which means a rebate to the makers.
+	MakerFeeRate int64 `json:"maker_fee_rate"`
+	// The discounts on commission for the accounts with large rolling trading volume, in increasing order
+	FeeTiers []FeeTier `json:"fee_tiers"`
 }
 
 // ParamKeyTable for market module
@@ -58,9 +78,19 @@ func DefaultParams() Params {
 		DefaultMarketFeeRate,
 		DefaultMarketFeeMin,
 		DefaultFeeForZeroDeal,
+		DefaultCandleRetentionCount,
+		DefaultCircuitBreakerWindow,
+		DefaultCircuitBreakerRatio,
+		DefaultMakerFeeRate,
+		DefaultFeeTiers(),
 	}
 }
 
+// No discount is given by default, the tiers are set by the operators through the params
+func DefaultFeeTiers() []FeeTier {
+	return nil
+}
+
 // ParamSetPairs implements the ParamSet interface and returns all the key/value pairs
 // pairs of asset module's parameters.
 // nolint
@@ -74,6 +104,11 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		{Key: KeyMarketFeeRate, Value: &p.MarketFeeRate},
 		{Key: KeyMarketFeeMin, Value: &p.MarketFeeMin},
 		{Key: KeyFeeForZeroDeal, Value: &p.FeeForZeroDeal},
+		{Key: KeyCandleRetentionCount, Value: &p.CandleRetentionCount},
+		{Key: KeyCircuitBreakerWindow, Value: &p.CircuitBreakerWindow},
+		{Key: KeyCircuitBreakerRatio, Value: &p.CircuitBreakerRatio},
+		{Key: KeyMakerFeeRate, Value: &p.MakerFeeRate},
+		{Key: KeyFeeTiers, Value: &p.FeeTiers},
 	}
 }
 
@@ -94,6 +129,23 @@ func (p *Params) ValidateGenesis() error {
 			p.MarketFeeRate, p.MarketFeeMin, p.FeeForZeroDeal, p.GTEOrderLifetime,
 			p.GTEOrderFeatureFeeByBlocks)
 	}
+	if p.CandleRetentionCount < TickerHours {
+		return fmt.Errorf("%s must be at least %d, is %d", KeyCandleRetentionCount,
+			TickerHours, p.CandleRetentionCount)
+	}
+	if p.CircuitBreakerWindow < 0 || p.CircuitBreakerRatio < 0 {
+		return fmt.Errorf("params must be positive, CircuitBreakerWindow : %d, CircuitBreakerRatio : %d",
+			p.CircuitBreakerWindow, p.CircuitBreakerRatio)
+	}
+	// the frozen commission is calculated with the taker's fee rate, and the rebate to a maker
+	// should not exceed the fee paid by the taker
+	if p.MakerFeeRate > p.MarketFeeRate || p.MakerFeeRate < -p.MarketFeeRate {
+		return fmt.Errorf("%s : %d must be in [-%d, %d], which are the negative and positive %s",
+			KeyMakerFeeRate, p.MakerFeeRate, p.MarketFeeRate, p.MarketFeeRate, KeyMarketFeeRate)
+	}
+	if err := ValidateFeeTiers(p.FeeTiers); err != nil {
+		return fmt.Errorf("%s : %s", KeyFeeTiers, err.Error())
+	}
 	return nil
 }
 
@@ -113,7 +165,12 @@ func (p Params) String() string {
   MaxExecutedPriceChangeRatio: %d
   MarketFeeRate:               %d
   MarketFeeMin:                %d
-  FeeForZeroDeal:              %d`,
+  FeeForZeroDeal:              %d
+  CandleRetentionCount:        %d
+  CircuitBreakerWindow:        %d
+  CircuitBreakerRatio:         %d
+  MakerFeeRate:                %d
+  FeeTiers:                    %v`,
 		p.CreateMarketFee,
 		p.MarketMinExpiredTime,
 		p.GTEOrderLifetime,
@@ -121,5 +178,10 @@ func (p Params) String() string {
 		p.MaxExecutedPriceChangeRatio,
 		p.MarketFeeRate,
 		p.MarketFeeMin,
-		p.FeeForZeroDeal)
+		p.FeeForZeroDeal,
+		p.CandleRetentionCount,
+		p.CircuitBreakerWindow,
+		p.CircuitBreakerRatio,
+		p.MakerFeeRate,
+		p.FeeTiers)
 }
@@ -3,6 +3,7 @@ package types
 import (
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,6 +17,11 @@ func TestParams(t *testing.T) {
 		MarketFeeRate:               100,
 		MarketFeeMin:                100,
 		FeeForZeroDeal:              100,
+		CandleRetentionCount:        100,
+		CircuitBreakerWindow:        100,
+		CircuitBreakerRatio:         100,
+		MakerFeeRate:                -100,
+		FeeTiers:                    DefaultFeeTiers(),
 	}
 	require.Equal(t, nil, params.ValidateGenesis())
 	params1 := params
@@ -39,4 +45,47 @@ func TestParams(t *testing.T) {
 	params1 = params
 	params1.FeeForZeroDeal = -1
 	require.NotNil(t, params1.ValidateGenesis())
+	params1 = params
+	params1.CandleRetentionCount = TickerHours - 1
+	require.NotNil(t, params1.ValidateGenesis())
+	params1 = params
+	params1.CircuitBreakerWindow = -1
+	require.NotNil(t, params1.ValidateGenesis())
+	params1 = params
+	params1.CircuitBreakerRatio = -1
+	require.NotNil(t, params1.ValidateGenesis())
+	params1 = params
+	params1.MakerFeeRate = 101
+	require.NotNil(t, params1.ValidateGenesis())
+	params1 = params
+	params1.MakerFeeRate = -101
+	require.NotNil(t, params1.ValidateGenesis())
+	params1 = params
+	params1.FeeTiers = []FeeTier{{Volume: 100, Discount: 20}, {Volume: 100, Discount: 30}}
+	require.NotNil(t, params1.ValidateGenesis())
+	params1.FeeTiers = []FeeTier{{Volume: 100, Discount: 20}, {Volume: 200, Discount: 10}}
+	require.NotNil(t, params1.ValidateGenesis())
+	params1.FeeTiers = []FeeTier{{Volume: 100, Discount: 101}}
+	require.NotNil(t, params1.ValidateGenesis())
+}
+
+func TestFeeTiers(t *testing.T) {
+	tier, discount := GetFeeTier(DefaultFeeTiers(), sdk.NewInt(1e16))
+	require.Equal(t, 0, tier)
+	require.EqualValues(t, 0, discount)
+
+	tiers := []FeeTier{{Volume: 1e13, Discount: 10}, {Volume: 1e14, Discount: 20}, {Volume: 1e15, Discount: 30}}
+	tier, discount = GetFeeTier(tiers, sdk.NewInt(1e13-1))
+	require.Equal(t, 0, tier)
+	require.EqualValues(t, 0, discount)
+	tier, discount = GetFeeTier(tiers, sdk.NewInt(1e13))
+	require.Equal(t, 1, tier)
+	require.EqualValues(t, 10, discount)
+	tier, discount = GetFeeTier(tiers, sdk.NewInt(1e16))
+	require.Equal(t, 3, tier)
+	require.EqualValues(t, 30, discount)
+
+	require.EqualValues(t, 1000, DiscountFee(1000, 0))
+	require.EqualValues(t, 699, DiscountFee(999, 30))
+	require.EqualValues(t, 0, DiscountFee(1000, 100))
 }
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// The trading volume of an account is accumulated in daily buckets, and
+	// the buckets in the latest VolumeWindowDays days decide its fee tier.
+	VolumeWindowDays = 30
+	SecondsPerDay    = 24 * 60 * 60
+)
+
+// FeeTier gives a discount in percent on the commission of the accounts
+// whose rolling trading volume, measured in sato CET, reaches Volume.
+type FeeTier struct {
+	Volume   int64 `json:"volume"`
+	Discount int64 `json:"discount"`
+}
+
+func (tier FeeTier) String() string {
+	return fmt.Sprintf("{Volume: %d, Discount: %d%%}", tier.Volume, tier.Discount)
+}
+
+// The tiers must have increasing volumes and non-decreasing discounts no more than 100%
+func ValidateFeeTiers(tiers []FeeTier) error {
+	for i, tier := range tiers {
+		if tier.Volume <= 0 || tier.Discount < 0 || tier.Discount > 100 {
+			return fmt.Errorf("invalid fee tier %s", tier)
+		}
+		if i > 0 && (tier.Volume <= tiers[i-1].Volume || tier.Discount < tiers[i-1].Discount) {
+			return fmt.Errorf("fee tier %s must have larger volume and discount than %s", tier, tiers[i-1])
+		}
+	}
+	return nil
+}
+
+// Return the 1-based index of the highest tier reached by the volume, and its discount.
+// Zero means no tier is reached.
+func GetFeeTier(tiers []FeeTier, volume sdk.Int) (int, int64) {
+	for i := len(tiers) - 1; i >= 0; i-- {
+		if volume.GTE(sdk.NewInt(tiers[i].Volume)) {
+			return i + 1, tiers[i].Discount
+		}
+	}
+	return 0, 0
+}
+
+func DiscountFee(fee, discount int64) int64 {
+	if discount == 0 {
+		return fee
+	}
+	return sdk.NewInt(fee).MulRaw(100 - discount).QuoRaw(100).Int64()
+}
+
+// AccountVolume is the trading volume of an account in a day, which is used in genesis
+type AccountVolume struct {
+	Address sdk.AccAddress `json:"address"`
+	Day     int64          `json:"day"`
+	Volume  sdk.Int        `json:"volume"`
+}
+
+func GetDayOfUnixTime(t int64) int64 {
+	return t / SecondsPerDay
+}
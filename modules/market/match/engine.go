@@ -0,0 +1,72 @@
+package match
+
+import (
+	"bytes"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/market/internal/types"
+)
+
+// Engine matches the bid orders against the ask orders of a market, and calls Deal for each trade.
+// highPrice, midPrice and lowPrice are the price band around the last executed price.
+type Engine interface {
+	Match(highPrice, midPrice, lowPrice sdk.Dec, bidList []OrderForTrade, askList []OrderForTrade)
+}
+
+// NewEngine returns the engine for a market's matching mode
+func NewEngine(matchingMode byte) Engine {
+	if matchingMode == types.ContinuousMatching {
+		return ContinuousEngine{}
+	}
+	return CallAuctionEngine{}
+}
+
+// CallAuctionEngine executes the crossed orders periodically at a single price, which maximizes the executed amount
+type CallAuctionEngine struct{}
+
+func (CallAuctionEngine) Match(highPrice, midPrice, lowPrice sdk.Dec, bidList []OrderForTrade, askList []OrderForTrade) {
+	Match(highPrice, midPrice, lowPrice, bidList, askList)
+}
+
+// ContinuousEngine matches the best bid against the best ask by price-time priority. Each trade happens
+// at the price of the maker, i.e. the earlier one of the two orders, so the price band is not used.
+type ContinuousEngine struct{}
+
+func (ContinuousEngine) Match(highPrice, midPrice, lowPrice sdk.Dec, bidList []OrderForTrade, askList []OrderForTrade) {
+	sort.Slice(bidList, func(i, j int) bool {
+		return precede(bidList[i], bidList[j])
+	})
+	sort.Slice(askList, func(i, j int) bool {
+		return precede(askList[i], askList[j])
+	})
+	for len(bidList) != 0 && len(askList) != 0 && askList[0].GetPrice().LTE(bidList[0].GetPrice()) {
+		bid, ask := bidList[0], askList[0]
+		if !preventSelfTrade(bid, ask) {
+			taker, maker := bid, ask
+			if isEarlier(bid, ask) {
+				taker, maker = ask, bid
+			}
+			amount := bid.GetAmount()
+			if ask.GetAmount() < amount {
+				amount = ask.GetAmount()
+			}
+			taker.Deal(maker, amount, maker.GetPrice())
+		}
+		if bid.GetAmount() == 0 {
+			bidList = bidList[1:]
+		}
+		if ask.GetAmount() == 0 {
+			askList = askList[1:]
+		}
+	}
+}
+
+// return true if a was placed before b, the hash decides the order of the orders at the same height
+func isEarlier(a, b OrderForTrade) bool {
+	if a.GetHeight() != b.GetHeight() {
+		return a.GetHeight() < b.GetHeight()
+	}
+	return bytes.Compare(a.GetHash(), b.GetHash()) < 0
+}
@@ -21,7 +21,10 @@ type OrderForTrade interface {
 	GetHash() []byte
 	GetSide() int
 	GetOwner() Account
+	GetSelfTradePrevention() byte
 	Deal(otherSide OrderForTrade, amount int64, price sdk.Dec)
+	// PreventSelfTrade removes amount from the order without a trade, and the order is canceled when nothing is left
+	PreventSelfTrade(amount int64)
 	String() string
 }
 
@@ -106,6 +109,15 @@ func ExecuteOrder(price sdk.Dec, currOrder OrderForTrade, orderList []OrderForTr
 				break
 			}
 		}
+		if preventSelfTrade(currOrder, otherSide) {
+			if otherSide.GetAmount() == 0 {
+				firstNonZeroIndex++
+			}
+			if currOrder.GetAmount() == 0 {
+				break
+			}
+			continue
+		}
 		minAmount := otherSide.GetAmount()
 		if currOrder.GetAmount() < otherSide.GetAmount() {
 			minAmount = currOrder.GetAmount()
@@ -126,6 +138,37 @@ func ExecuteOrder(price sdk.Dec, currOrder OrderForTrade, orderList []OrderForTr
 	return nil
 }
 
+// If a and b have the same owner, the self-trade prevention mode of the newer one decides how their
+// amounts are reduced instead of a trade. It returns false if a and b are allowed to trade.
+func preventSelfTrade(a, b OrderForTrade) bool {
+	if a.GetOwner().String() != b.GetOwner().String() {
+		return false
+	}
+	older, newer := a, b
+	if isEarlier(b, a) {
+		older, newer = b, a
+	}
+	switch newer.GetSelfTradePrevention() {
+	case types.SelfTradeCancelNewest:
+		newer.PreventSelfTrade(newer.GetAmount())
+	case types.SelfTradeCancelOldest:
+		older.PreventSelfTrade(older.GetAmount())
+	case types.SelfTradeCancelBoth:
+		newer.PreventSelfTrade(newer.GetAmount())
+		older.PreventSelfTrade(older.GetAmount())
+	case types.SelfTradeDecrement:
+		amount := newer.GetAmount()
+		if older.GetAmount() < amount {
+			amount = older.GetAmount()
+		}
+		newer.PreventSelfTrade(amount)
+		older.PreventSelfTrade(amount)
+	default:
+		return false
+	}
+	return true
+}
+
 type PricePoint struct {
 	price                sdk.Dec
 	accumulatedAskAmount sdk.Int
@@ -330,3 +373,82 @@ func calculateExecutionPriceWithRef(highPrice, midPrice, lowPrice sdk.Dec, ppLis
 		}
 	}
 }
+
+// shadowOrder mirrors an order in a dry run of an engine, so the wrapped order is not affected by Deal
+type shadowOrder struct {
+	OrderForTrade
+	amount int64
+	dealt  int64
+}
+
+func (so *shadowOrder) GetAmount() int64 {
+	return so.amount
+}
+
+func (so *shadowOrder) Deal(otherSide OrderForTrade, amount int64, price sdk.Dec) {
+	other := otherSide.(*shadowOrder)
+	so.amount -= amount
+	so.dealt += amount
+	other.amount -= amount
+	other.dealt += amount
+}
+
+func (so *shadowOrder) PreventSelfTrade(amount int64) {
+	so.amount -= amount
+}
+
+func toShadowOrders(orders []OrderForTrade) []OrderForTrade {
+	res := make([]OrderForTrade, len(orders))
+	for i, order := range orders {
+		res[i] = &shadowOrder{OrderForTrade: order, amount: order.GetAmount()}
+	}
+	return res
+}
+
+// MaxExcludeRounds is the most rounds ExcludeUnsatisfiedOrders dry-runs the engine
+const MaxExcludeRounds = 8
+
+// ExcludeUnsatisfiedOrders dry-runs the engine and excludes the orders for which isSatisfied returns false,
+// given the amount they would deal. It repeats until all the remaining orders are satisfied, and returns
+// the remaining bid orders, the remaining ask orders and the excluded orders. Only the constrained orders
+// can be unsatisfied, so when the remaining ones are still not all satisfied after MaxExcludeRounds rounds,
+// all the remaining constrained orders are excluded.
+func ExcludeUnsatisfiedOrders(engine Engine, highPrice, midPrice, lowPrice sdk.Dec, bidList []OrderForTrade, askList []OrderForTrade,
+	isConstrained func(order OrderForTrade) bool,
+	isSatisfied func(order OrderForTrade, dealAmount int64) bool) ([]OrderForTrade, []OrderForTrade, []OrderForTrade) {
+	var excluded []OrderForTrade
+	for round := 0; round < MaxExcludeRounds; round++ {
+		shadowBids, shadowAsks := toShadowOrders(bidList), toShadowOrders(askList)
+		engine.Match(highPrice, midPrice, lowPrice, shadowBids, shadowAsks)
+		newBidList := make([]OrderForTrade, 0, len(bidList))
+		newAskList := make([]OrderForTrade, 0, len(askList))
+		for _, order := range append(shadowBids, shadowAsks...) {
+			so := order.(*shadowOrder)
+			switch {
+			case !isSatisfied(so.OrderForTrade, so.dealt):
+				excluded = append(excluded, so.OrderForTrade)
+			case so.GetSide() == types.BID:
+				newBidList = append(newBidList, so.OrderForTrade)
+			default:
+				newAskList = append(newAskList, so.OrderForTrade)
+			}
+		}
+		if len(newBidList) == len(bidList) && len(newAskList) == len(askList) {
+			return bidList, askList, excluded
+		}
+		bidList, askList = newBidList, newAskList
+	}
+	newBidList := make([]OrderForTrade, 0, len(bidList))
+	newAskList := make([]OrderForTrade, 0, len(askList))
+	for _, order := range append(bidList, askList...) {
+		switch {
+		case isConstrained(order):
+			excluded = append(excluded, order)
+		case order.GetSide() == types.BID:
+			newBidList = append(newBidList, order)
+		default:
+			newAskList = append(newAskList, order)
+		}
+	}
+	return newBidList, newAskList, excluded
+}
@@ -61,6 +61,7 @@ type mocOrder struct {
 	remainAmount int64
 	side         int
 	owner        mocAccount
+	stp          byte
 }
 
 var _ OrderForTrade = (*mocOrder)(nil)
@@ -91,6 +92,14 @@ func (order *mocOrder) GetOwner() Account {
 	return &order.owner
 }
 
+func (order *mocOrder) GetSelfTradePrevention() byte {
+	return order.stp
+}
+
+func (order *mocOrder) PreventSelfTrade(amount int64) {
+	order.remainAmount -= amount
+}
+
 func (order *mocOrder) Deal(otherSide OrderForTrade, amount int64, price sdk.Dec) {
 	other := otherSide.(*mocOrder)
 	fmt.Printf("Deal: %s|%d-%s|%d %d price:%s\n", order.GetOwner(), order.GetAmount(), other.GetOwner(), other.GetAmount(), amount, price.String())
@@ -372,6 +381,10 @@ func testGetExecutionPrice(mid int64, orders []OrderForTrade) sdk.Dec {
 }
 
 func testMatch(tag string, mid int64, orders []OrderForTrade, dealRecordList []dealRecord) {
+	testMatchWithEngine(tag, CallAuctionEngine{}, mid, orders, dealRecordList)
+}
+
+func testMatchWithEngine(tag string, engine Engine, mid int64, orders []OrderForTrade, dealRecordList []dealRecord) {
 	currDealRecordList = dealRecordList
 	currDealRecordIndex = 0
 	fmt.Printf("=======================%s===============================\n", tag)
@@ -388,7 +401,7 @@ func testMatch(tag string, mid int64, orders []OrderForTrade, dealRecordList []d
 	midPrice := sdk.NewDec(mid)
 	highPrice := midPrice.MulInt(sdk.NewInt(105)).QuoInt(sdk.NewInt(100))
 	lowPrice := midPrice.MulInt(sdk.NewInt(95)).QuoInt(sdk.NewInt(100))
-	Match(highPrice, midPrice, lowPrice, bidList, askList)
+	engine.Match(highPrice, midPrice, lowPrice, bidList, askList)
 	if currDealRecordIndex != len(currDealRecordList) {
 		testHandler.Errorf("Missmatch in the count of deals")
 	}
@@ -492,3 +505,176 @@ func TestMatch_1(t *testing.T) {
 	testMatch("6_4", 110, createOrders6(), createDealRecord6_4())
 	testMatch("6_5", 0, createOrders6(), createDealRecord6_5())
 }
+
+func TestExcludeUnsatisfiedOrders(t *testing.T) {
+	// "fok" must be fully filled, and "post" must not deal
+	isSatisfied := func(order OrderForTrade, dealAmount int64) bool {
+		switch order.GetOwner().String() {
+		case "fok":
+			return dealAmount == order.GetAmount()
+		case "post":
+			return dealAmount == 0
+		}
+		return true
+	}
+	isConstrained := func(order OrderForTrade) bool {
+		owner := order.GetOwner().String()
+		return owner == "fok" || owner == "post"
+	}
+	bidList := []OrderForTrade{
+		newMocOrder(100, 2, 100, BUY, "fok"),
+		newMocOrder(98, 1, 30, BUY, "gte"),
+	}
+	askList := []OrderForTrade{
+		newMocOrder(97, 1, 60, SELL, "gte"),
+		newMocOrder(98, 2, 40, SELL, "post"),
+	}
+	price := sdk.NewDec(98)
+	bidList, askList, excluded := ExcludeUnsatisfiedOrders(CallAuctionEngine{}, price, price, price, bidList, askList, isConstrained, isSatisfied)
+	// "fok" can only get 60 from "gte" after "post" is excluded
+	if len(excluded) != 2 || len(bidList) != 1 || len(askList) != 1 {
+		t.Errorf("excluded:%d bids:%d asks:%d\n", len(excluded), len(bidList), len(askList))
+	}
+	if bidList[0].GetOwner().String() != "gte" || askList[0].GetOwner().String() != "gte" {
+		t.Errorf("wrong orders are kept")
+	}
+	// the dry run does not change the orders
+	for _, order := range append(excluded, append(bidList, askList...)...) {
+		if order.GetAmount() != order.(*mocOrder).totalAmount {
+			t.Errorf("the amount of %s is changed\n", order.String())
+		}
+	}
+
+	bidList = []OrderForTrade{newMocOrder(100, 2, 60, BUY, "fok")}
+	askList = []OrderForTrade{newMocOrder(97, 1, 60, SELL, "gte")}
+	bidList, askList, excluded = ExcludeUnsatisfiedOrders(CallAuctionEngine{}, price, price, price, bidList, askList, isConstrained, isSatisfied)
+	if len(excluded) != 0 || len(bidList) != 1 || len(askList) != 1 {
+		t.Errorf("a fully filled FOK order should not be excluded")
+	}
+
+	// each round excludes only one "post" order, and the rest are excluded when the rounds run out.
+	// The bid order is checked first in each round, so it counts the rounds.
+	rounds := int64(0)
+	excludeOneByOne := func(order OrderForTrade, dealAmount int64) bool {
+		if order.GetOwner().String() == "gte" {
+			rounds++
+		}
+		return order.GetOwner().String() != "post" || order.GetHeight() != rounds
+	}
+	bidList = []OrderForTrade{newMocOrder(90, 1, 60, BUY, "gte")}
+	askList = nil
+	for height := int64(1); height <= MaxExcludeRounds+2; height++ {
+		askList = append(askList, newMocOrder(100, height, 10, SELL, "post"))
+	}
+	bidList, askList, excluded = ExcludeUnsatisfiedOrders(CallAuctionEngine{}, price, price, price, bidList, askList, isConstrained, excludeOneByOne)
+	if len(excluded) != MaxExcludeRounds+2 || len(bidList) != 1 || len(askList) != 0 {
+		t.Errorf("excluded:%d bids:%d asks:%d\n", len(excluded), len(bidList), len(askList))
+	}
+	if rounds != MaxExcludeRounds {
+		t.Errorf("the engine should run no more than %d rounds", MaxExcludeRounds)
+	}
+}
+
+func TestContinuousEngine(t *testing.T) {
+	testHandler = t
+	//             price height totalAmount side owner
+	orders := []OrderForTrade{
+		newMocOrder(100, 1, 30, BUY, "b1"),
+		newMocOrder(99, 2, 50, BUY, "b2"),
+		newMocOrder(97, 3, 40, SELL, "s1"),
+		newMocOrder(101, 1, 10, SELL, "s2"),
+		newMocOrder(98, 4, 10, SELL, "s3"),
+	}
+	// the resting bids are makers, so the deals happen at the bid prices
+	dealRecords := []dealRecord{
+		newDR("s1", "b1", 30, 100),
+		newDR("s1", "b2", 10, 99),
+		newDR("s3", "b2", 10, 99),
+	}
+	testMatchWithEngine("continuous_1", ContinuousEngine{}, 100, orders, dealRecords)
+
+	orders = []OrderForTrade{
+		newMocOrder(95, 1, 20, SELL, "s1"),
+		newMocOrder(96, 1, 20, SELL, "s2"),
+		newMocOrder(100, 2, 30, BUY, "b1"),
+	}
+	// the resting asks are makers, the best price is taken first
+	dealRecords = []dealRecord{
+		newDR("b1", "s1", 20, 95),
+		newDR("b1", "s2", 10, 96),
+	}
+	testMatchWithEngine("continuous_2", ContinuousEngine{}, 100, orders, dealRecords)
+	if orders[1].GetAmount() != 10 || orders[2].GetAmount() != 0 {
+		t.Errorf("wrong amounts after matching")
+	}
+}
+
+func TestNewEngine(t *testing.T) {
+	if _, ok := NewEngine(types.CallAuctionMatching).(CallAuctionEngine); !ok {
+		t.Errorf("call auction engine expected")
+	}
+	if _, ok := NewEngine(types.ContinuousMatching).(ContinuousEngine); !ok {
+		t.Errorf("continuous engine expected")
+	}
+}
+
+func newMocOrderWithSTP(price int64, height int64, totalAmount int64, side int, owner string, stp byte) OrderForTrade {
+	order := newMocOrder(price, height, totalAmount, side, owner)
+	order.(*mocOrder).stp = stp
+	return order
+}
+
+func TestSelfTradePrevention(t *testing.T) {
+	testHandler = t
+	price := sdk.NewDec(100)
+	// the mode of the newer order decides, and the amounts left are for the older bid and the newer ask
+	testCases := []struct {
+		stp       byte
+		bidAmount int64
+		askAmount int64
+	}{
+		{types.SelfTradeAllowed, 10, 0},
+		{types.SelfTradeCancelNewest, 30, 0},
+		{types.SelfTradeCancelOldest, 0, 20},
+		{types.SelfTradeCancelBoth, 0, 0},
+		{types.SelfTradeDecrement, 10, 0},
+	}
+	for _, tc := range testCases {
+		currDealRecordList = nil
+		bid := newMocOrderWithSTP(100, 1, 30, BUY, "a", types.SelfTradeCancelBoth)
+		ask := newMocOrderWithSTP(100, 2, 20, SELL, "a", tc.stp)
+		ExecuteOrder(price, ask, []OrderForTrade{bid})
+		if bid.GetAmount() != tc.bidAmount || ask.GetAmount() != tc.askAmount {
+			t.Errorf("mode %d: bid:%d ask:%d\n", tc.stp, bid.GetAmount(), ask.GetAmount())
+		}
+	}
+
+	// the canceled order is skipped, and the other orders still trade
+	currDealRecordList = []dealRecord{newDR("a", "c", 40, 100)}
+	currDealRecordIndex = 0
+	bid := newMocOrderWithSTP(100, 2, 50, BUY, "a", types.SelfTradeCancelOldest)
+	askList := []OrderForTrade{
+		newMocOrder(100, 1, 20, SELL, "a"),
+		newMocOrder(100, 1, 40, SELL, "c"),
+	}
+	if left := ExecuteOrder(price, bid, askList); len(left) != 0 {
+		t.Errorf("all the asks should be removed")
+	}
+	if bid.GetAmount() != 10 || askList[0].GetAmount() != 0 || currDealRecordIndex != 1 {
+		t.Errorf("wrong amounts after self-trade prevention")
+	}
+
+	//             price height totalAmount side owner
+	orders := []OrderForTrade{
+		newMocOrder(100, 1, 30, BUY, "a"),
+		newMocOrderWithSTP(99, 2, 20, SELL, "a", types.SelfTradeCancelNewest),
+		newMocOrder(100, 3, 10, SELL, "b"),
+	}
+	dealRecords := []dealRecord{
+		newDR("b", "a", 10, 100),
+	}
+	testMatchWithEngine("continuous_stp", ContinuousEngine{}, 100, orders, dealRecords)
+	if orders[0].GetAmount() != 20 || orders[1].GetAmount() != 0 {
+		t.Errorf("wrong amounts after self-trade prevention in continuous matching")
+	}
+}
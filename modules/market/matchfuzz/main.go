@@ -76,6 +76,9 @@ func (order *Order) GetSide() int {
 func (order *Order) GetOwner() match.Account {
 	return &Account{ID: order.ID % 10000}
 }
+func (order *Order) GetSelfTradePrevention() byte {
+	return market.SelfTradeAllowed
+}
 func (order *Order) String() string {
 	return fmt.Sprintf("%d", order.ID)
 }
@@ -222,7 +225,14 @@ func (order *Order) Deal(otherSide match.OrderForTrade, amount int64, price sdk.
 	DealCount++
 }
 
-func runTest(seed int64, priceRange int64, amountRange int64, delStep int32, liveOrderUpper, liveOrderLower int, heightLimit int) {
+func (order *Order) PreventSelfTrade(amount int64) {
+	order.Amount -= amount
+	if order.Amount == 0 {
+		Keeper.RemoveOrder(order)
+	}
+}
+
+func runTest(engine match.Engine, seed int64, priceRange int64, amountRange int64, delStep int32, liveOrderUpper, liveOrderLower int, heightLimit int) {
 	DealCount = 0
 	LastPrice = sdk.ZeroDec()
 	Keeper = &OrderKeeper{
@@ -253,7 +263,7 @@ func runTest(seed int64, priceRange int64, amountRange int64, delStep int32, liv
 		lowPrice := LastPrice.Mul(sdk.NewDec(int64(100 - ratio))).Quo(sdk.NewDec(100))
 		highPrice := LastPrice.Mul(sdk.NewDec(int64(100 + ratio))).Quo(sdk.NewDec(100))
 
-		match.Match(highPrice, LastPrice, lowPrice, bidList, askList)
+		engine.Match(highPrice, LastPrice, lowPrice, bidList, askList)
 
 		highBuy = Keeper.GetHighestBuy().Price
 		lowSell = Keeper.GetLowestSell().Price
@@ -269,6 +279,9 @@ func runTest(seed int64, priceRange int64, amountRange int64, delStep int32, liv
 }
 
 func main() {
-	//     seed, priceRange, amountRange, delStep, liveOrderUpper, liveOrderLower, heightLimit
-	runTest(0, 100, 1000, 3, 8000, 6000, 50)
+	for _, mode := range []byte{market.CallAuctionMatching, market.ContinuousMatching} {
+		fmt.Printf("Matching mode: %d\n", mode)
+		//     engine, seed, priceRange, amountRange, delStep, liveOrderUpper, liveOrderLower, heightLimit
+		runTest(match.NewEngine(mode), 0, 100, 1000, 3, 8000, 6000, 50)
+	}
 }
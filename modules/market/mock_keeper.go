@@ -10,7 +10,8 @@ import (
 )
 
 type mockKeeper struct {
-	records []string
+	records  []string
+	discount int64
 }
 
 func (k *mockKeeper) GetRefereeAddr(ctx sdk.Context, accAddr sdk.AccAddress) sdk.AccAddress {
@@ -46,11 +47,17 @@ func (k *mockKeeper) FreezeCoins(ctx sdk.Context, acc sdk.AccAddress, amt sdk.Co
 func (k *mockKeeper) UnFreezeCoins(ctx sdk.Context, acc sdk.AccAddress, amt sdk.Coins) sdk.Error {
 	panic("implement me")
 }
+func (k *mockKeeper) GetFrozenCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	panic("implement me")
+}
 func (k *mockKeeper) SubtractFeeAndCollectFee(ctx sdk.Context, addr sdk.AccAddress, amt int64) sdk.Error {
 	fee := fmt.Sprintf("addr : %s, fee : %d", addr, amt)
 	k.records = append(k.records, fee)
 	return nil
 }
+func (k *mockKeeper) GetFeeDiscount(ctx sdk.Context, addr sdk.AccAddress) int64 {
+	return k.discount
+}
 func (k *mockKeeper) cleanRecord() {
 	k.records = make([]string, 0, 2)
 }
@@ -115,3 +122,6 @@ func (k *mocBankxKeeper) UnFreezeCoins(ctx sdk.Context, acc sdk.AccAddress, amt
 		amt[0].Amount.String(), amt[0].Denom, acc.String()))
 	return nil
 }
+func (k *mocBankxKeeper) GetFrozenCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	panic("implement me")
+}
@@ -0,0 +1,34 @@
+package oracle
+
+import (
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/types"
+)
+
+type (
+	GenesisState      = types.GenesisState
+	PriceAccumulator  = types.PriceAccumulator
+	Keeper            = keepers.Keeper
+	QueryTWAPParam    = keepers.QueryTWAPParam
+	QueryTWAPResponse = keepers.QueryTWAPResponse
+)
+
+const (
+	ModuleName             = types.ModuleName
+	StoreKey               = types.StoreKey
+	QuerierRoute           = types.QuerierRoute
+	MaxTWAPWindow          = types.MaxTWAPWindow
+	QueryTWAP              = keepers.QueryTWAP
+	CodeInvalidTWAPWindow  = types.CodeInvalidTWAPWindow
+	CodeNoPriceRecorded    = types.CodeNoPriceRecorded
+	CodeInvalidAccumulator = types.CodeInvalidAccumulator
+)
+
+var (
+	ModuleCdc           = types.ModuleCdc
+	DefaultGenesisState = types.DefaultGenesisState
+	NewGenesisState     = types.NewGenesisState
+	NewKeeper           = keepers.NewKeeper
+	NewQuerier          = keepers.NewQuerier
+	NewQueryTWAPParam   = keepers.NewQueryTWAPParam
+)
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/types"
+	"github.com/coinexchain/cosmos-utils/client/cliutil"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(cdc *codec.Codec) *cobra.Command {
+	oracleQueryCmd := &cobra.Command{
+		Use:   types.StoreKey,
+		Short: "Querying commands for the oracle module",
+	}
+	oracleQueryCmd.AddCommand(client.GetCommands(
+		QueryTWAPCmd(cdc),
+	)...)
+	return oracleQueryCmd
+}
+
+func QueryTWAPCmd(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "twap [trading-pair] [window]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Query the time-weighted average price of a trading pair over the last window seconds",
+		Long: `Query the time-weighted average price of a trading pair over the last window seconds.
+
+Example:
+	 cetcli query oracle twap abc/cet 3600 --trust-node=true --chain-id=coinexdex`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			window, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+			route := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryTWAP)
+			param := keepers.NewQueryTWAPParam(args[0], window)
+			return cliutil.CliQuery(cdc, route, param)
+		},
+	}
+}
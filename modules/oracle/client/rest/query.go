@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/types"
+	dex "github.com/coinexchain/cet-sdk/types"
+	"github.com/coinexchain/cosmos-utils/client/restutil"
+)
+
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r.HandleFunc("/oracle/twap/{stock}/{money}/{window}", queryTWAPHandlerFn(cliCtx)).Methods("GET")
+}
+
+// HTTP request handler to query the TWAP of a trading pair
+func queryTWAPHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		window, err := strconv.ParseInt(vars["window"], 10, 64)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid window")
+			return
+		}
+		route := fmt.Sprintf("custom/%s/%s", types.StoreKey, keepers.QueryTWAP)
+		param := keepers.NewQueryTWAPParam(dex.GetSymbol(vars["stock"], vars["money"]), window)
+		restutil.RestQuery(types.ModuleCdc, cliCtx, w, r, route, param, nil)
+	}
+}
@@ -0,0 +1,20 @@
+package oracle
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/types"
+)
+
+// InitGenesis - Init store state from genesis data
+func InitGenesis(ctx sdk.Context, keeper keepers.Keeper, data types.GenesisState) {
+	for _, acc := range data.Accumulators {
+		keeper.SetAccumulator(ctx, acc)
+	}
+}
+
+// ExportGenesis returns a GenesisState for a given context and keeper
+func ExportGenesis(ctx sdk.Context, keeper keepers.Keeper) types.GenesisState {
+	return types.NewGenesisState(keeper.GetAllAccumulators(ctx))
+}
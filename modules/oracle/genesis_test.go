@@ -0,0 +1,53 @@
+package oracle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/oracle"
+	"github.com/coinexchain/cet-sdk/testapp"
+)
+
+func TestGenesis(t *testing.T) {
+	testApp := testapp.NewTestApp()
+	ctx := testApp.NewCtx().WithBlockTime(time.Unix(1000, 0))
+	k := testApp.OracleKeeper
+	k.RecordPrice(ctx, "abc/cet", sdk.NewDec(10))
+	k.RecordPrice(ctx.WithBlockTime(time.Unix(1100, 0)), "abc/cet", sdk.NewDec(20))
+	k.RecordPrice(ctx.WithBlockTime(time.Unix(1100, 0)), "xyz/cet", sdk.NewDec(3))
+
+	genesis := oracle.ExportGenesis(ctx, k)
+	require.Equal(t, 3, len(genesis.Accumulators))
+	require.Nil(t, genesis.ValidateGenesis())
+
+	testApp = testapp.NewTestApp()
+	ctx = testApp.NewCtx().WithBlockTime(time.Unix(1200, 0))
+	oracle.InitGenesis(ctx, testApp.OracleKeeper, genesis)
+	require.Equal(t, genesis, oracle.ExportGenesis(ctx, testApp.OracleKeeper))
+	twap, err := testApp.OracleKeeper.GetTWAP(ctx, "abc/cet", 200)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(15), twap)
+}
+
+func TestValidateGenesis(t *testing.T) {
+	acc := oracle.PriceAccumulator{Symbol: "abc/cet", Price: sdk.NewDec(10), Cumulative: sdk.ZeroDec(), UpdateTime: 1000}
+	require.Nil(t, oracle.DefaultGenesisState().ValidateGenesis())
+	require.Nil(t, oracle.NewGenesisState([]oracle.PriceAccumulator{acc}).ValidateGenesis())
+
+	duplicate := oracle.NewGenesisState([]oracle.PriceAccumulator{acc, acc})
+	require.Equal(t, oracle.CodeInvalidAccumulator, duplicate.ValidateGenesis().(sdk.Error).Code())
+
+	invalid := acc
+	invalid.Price = sdk.ZeroDec()
+	require.NotNil(t, oracle.NewGenesisState([]oracle.PriceAccumulator{invalid}).ValidateGenesis())
+	invalid = acc
+	invalid.Symbol = ""
+	require.NotNil(t, oracle.NewGenesisState([]oracle.PriceAccumulator{invalid}).ValidateGenesis())
+	invalid = acc
+	invalid.Cumulative = sdk.NewDec(-1)
+	require.NotNil(t, oracle.NewGenesisState([]oracle.PriceAccumulator{invalid}).ValidateGenesis())
+}
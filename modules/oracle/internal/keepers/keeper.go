@@ -0,0 +1,159 @@
+package keepers
+
+import (
+	"encoding/binary"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/types"
+	dex "github.com/coinexchain/cet-sdk/types"
+)
+
+var (
+	// key = AccumulatorKeyPrefix | symbol | 0x0 | updateTime
+	AccumulatorKeyPrefix = []byte{0x11}
+	AccumulatorKeyEnd    = []byte{0x12}
+)
+
+type Keeper struct {
+	cdc *codec.Codec
+	key sdk.StoreKey
+}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		cdc: cdc,
+		key: key,
+	}
+}
+
+func int64ToBigEndianBytes(n int64) []byte {
+	var v = make([]byte, 8)
+	binary.BigEndian.PutUint64(v[:], uint64(n))
+	return v
+}
+
+func accumulatorKeyPrefix(symbol string) []byte {
+	return dex.ConcatKeys(AccumulatorKeyPrefix, []byte(symbol), []byte{0x0})
+}
+
+func accumulatorKeyEnd(symbol string) []byte {
+	return dex.ConcatKeys(AccumulatorKeyPrefix, []byte(symbol), []byte{0x1})
+}
+
+func accumulatorKey(symbol string, updateTime int64) []byte {
+	return dex.ConcatKeys(accumulatorKeyPrefix(symbol), int64ToBigEndianBytes(updateTime))
+}
+
+func (k Keeper) SetAccumulator(ctx sdk.Context, acc types.PriceAccumulator) {
+	store := ctx.KVStore(k.key)
+	store.Set(accumulatorKey(acc.Symbol, acc.UpdateTime), k.cdc.MustMarshalBinaryBare(acc))
+}
+
+// the first accumulator of symbol found in [start, end), in reverse order if reverse is true
+func (k Keeper) firstAccumulatorIn(ctx sdk.Context, start, end []byte, reverse bool) (acc types.PriceAccumulator, found bool) {
+	store := ctx.KVStore(k.key)
+	var iter sdk.Iterator
+	if reverse {
+		iter = store.ReverseIterator(start, end)
+	} else {
+		iter = store.Iterator(start, end)
+	}
+	defer iter.Close()
+	if !iter.Valid() {
+		return
+	}
+	k.cdc.MustUnmarshalBinaryBare(iter.Value(), &acc)
+	return acc, true
+}
+
+// GetLatestAccumulator returns the accumulator updated by the last recorded price of symbol
+func (k Keeper) GetLatestAccumulator(ctx sdk.Context, symbol string) (types.PriceAccumulator, bool) {
+	return k.firstAccumulatorIn(ctx, accumulatorKeyPrefix(symbol), accumulatorKeyEnd(symbol), true)
+}
+
+// RecordPrice records the current price of a trading pair. Non-positive prices are ignored.
+func (k Keeper) RecordPrice(ctx sdk.Context, symbol string, price sdk.Dec) {
+	if price.IsNil() || !price.IsPositive() {
+		return
+	}
+	now := ctx.BlockTime().Unix()
+	acc := types.PriceAccumulator{
+		Symbol:     symbol,
+		Price:      price,
+		Cumulative: sdk.ZeroDec(),
+		UpdateTime: now,
+	}
+	if last, found := k.GetLatestAccumulator(ctx, symbol); found {
+		if now < last.UpdateTime {
+			acc.UpdateTime = last.UpdateTime
+		}
+		acc.Cumulative = last.CumulativeAt(acc.UpdateTime)
+	}
+	k.SetAccumulator(ctx, acc)
+	k.pruneAccumulators(ctx, symbol, acc.UpdateTime-types.MaxTWAPWindow)
+}
+
+// remove the accumulators older than cutoff, except the latest one of them,
+// which is still needed to compute the cumulative at cutoff
+func (k Keeper) pruneAccumulators(ctx sdk.Context, symbol string, cutoff int64) {
+	if cutoff <= 0 {
+		return
+	}
+	store := ctx.KVStore(k.key)
+	iter := store.Iterator(accumulatorKeyPrefix(symbol), accumulatorKey(symbol, cutoff+1))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for i := 0; i+1 < len(keys); i++ {
+		store.Delete(keys[i])
+	}
+}
+
+// GetTWAP returns the time-weighted average price of symbol over the last window seconds.
+// If the prices of symbol have been recorded for less than window seconds, the average
+// is taken from the first recorded price.
+func (k Keeper) GetTWAP(ctx sdk.Context, symbol string, window int64) (sdk.Dec, sdk.Error) {
+	if window <= 0 || window > types.MaxTWAPWindow {
+		return sdk.ZeroDec(), types.ErrInvalidTWAPWindow(window)
+	}
+	last, found := k.GetLatestAccumulator(ctx, symbol)
+	if !found {
+		return sdk.ZeroDec(), types.ErrNoPriceRecorded(symbol)
+	}
+	now := ctx.BlockTime().Unix()
+	if now < last.UpdateTime {
+		now = last.UpdateTime
+	}
+	start := now - window
+	var first types.PriceAccumulator
+	found = false
+	if start >= 0 {
+		first, found = k.firstAccumulatorIn(ctx, accumulatorKeyPrefix(symbol), accumulatorKey(symbol, start+1), true)
+	}
+	if !found {
+		first, _ = k.firstAccumulatorIn(ctx, accumulatorKeyPrefix(symbol), accumulatorKeyEnd(symbol), false)
+		start = first.UpdateTime
+	}
+	if start == now {
+		return last.Price, nil
+	}
+	total := last.CumulativeAt(now).Sub(first.CumulativeAt(start))
+	return total.QuoInt64(now - start), nil
+}
+
+func (k Keeper) GetAllAccumulators(ctx sdk.Context) []types.PriceAccumulator {
+	store := ctx.KVStore(k.key)
+	iter := store.Iterator(AccumulatorKeyPrefix, AccumulatorKeyEnd)
+	defer iter.Close()
+	accumulators := make([]types.PriceAccumulator, 0, 100)
+	for ; iter.Valid(); iter.Next() {
+		var acc types.PriceAccumulator
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &acc)
+		accumulators = append(accumulators, acc)
+	}
+	return accumulators
+}
@@ -0,0 +1,83 @@
+package keepers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/keepers"
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/types"
+	"github.com/coinexchain/cet-sdk/testapp"
+)
+
+func TestRecordPriceAndGetTWAP(t *testing.T) {
+	testApp := testapp.NewTestApp()
+	ctx := testApp.NewCtx().WithBlockTime(time.Unix(1000, 0))
+	k := testApp.OracleKeeper
+	symbol := "abc/cet"
+
+	_, err := k.GetTWAP(ctx, symbol, 100)
+	require.Equal(t, types.CodeNoPriceRecorded, err.Code())
+
+	k.RecordPrice(ctx, symbol, sdk.NewDec(10))
+	twap, err := k.GetTWAP(ctx, symbol, 100)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(10), twap)
+
+	// non-positive prices are ignored
+	k.RecordPrice(ctx.WithBlockTime(time.Unix(1050, 0)), symbol, sdk.ZeroDec())
+	k.RecordPrice(ctx.WithBlockTime(time.Unix(1100, 0)), symbol, sdk.NewDec(20))
+	ctx = ctx.WithBlockTime(time.Unix(1200, 0))
+	for window, expected := range map[int64]sdk.Dec{
+		50:   sdk.NewDec(20),
+		100:  sdk.NewDec(20),
+		150:  sdk.NewDec(2500).QuoInt64(150),
+		200:  sdk.NewDec(15),
+		1000: sdk.NewDec(15), // the average since the first recorded price
+	} {
+		twap, err = k.GetTWAP(ctx, symbol, window)
+		require.Nil(t, err)
+		require.Equal(t, expected, twap, "window %d", window)
+	}
+
+	_, err = k.GetTWAP(ctx, symbol, 0)
+	require.Equal(t, types.CodeInvalidTWAPWindow, err.Code())
+	_, err = k.GetTWAP(ctx, symbol, types.MaxTWAPWindow+1)
+	require.Equal(t, types.CodeInvalidTWAPWindow, err.Code())
+
+	// the accumulator at 1000 is pruned, the one at 1100 is kept to compute the cumulative at 1150
+	ctx = ctx.WithBlockTime(time.Unix(1150+types.MaxTWAPWindow, 0))
+	k.RecordPrice(ctx, symbol, sdk.NewDec(30))
+	accumulators := k.GetAllAccumulators(ctx)
+	require.Equal(t, 2, len(accumulators))
+	require.EqualValues(t, 1100, accumulators[0].UpdateTime)
+	require.Equal(t, sdk.NewDec(20*types.MaxTWAPWindow+2000), accumulators[1].Cumulative)
+	twap, err = k.GetTWAP(ctx, symbol, types.MaxTWAPWindow)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDec(20), twap)
+}
+
+func TestQueryTWAP(t *testing.T) {
+	testApp := testapp.NewTestApp()
+	ctx := testApp.NewCtx().WithBlockTime(time.Unix(1000, 0))
+	k := testApp.OracleKeeper
+	k.RecordPrice(ctx, "abc/cet", sdk.NewDec(10))
+	k.RecordPrice(ctx.WithBlockTime(time.Unix(1100, 0)), "abc/cet", sdk.NewDec(20))
+	ctx = ctx.WithBlockTime(time.Unix(1200, 0))
+
+	querier := keepers.NewQuerier(k)
+	reqBytes := testApp.Cdc.MustMarshalJSON(keepers.NewQueryTWAPParam("abc/cet", 200))
+	res, err := querier(ctx, []string{keepers.QueryTWAP}, abci.RequestQuery{Data: reqBytes})
+	require.Nil(t, err)
+	var resp keepers.QueryTWAPResponse
+	testApp.Cdc.MustUnmarshalJSON(res, &resp)
+	require.Equal(t, sdk.NewDec(15), resp.TWAP)
+
+	reqBytes = testApp.Cdc.MustMarshalJSON(keepers.NewQueryTWAPParam("xyz/cet", 200))
+	_, err = querier(ctx, []string{keepers.QueryTWAP}, abci.RequestQuery{Data: reqBytes})
+	require.Equal(t, types.CodeNoPriceRecorded, err.Code())
+}
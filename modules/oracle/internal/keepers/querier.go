@@ -0,0 +1,64 @@
+package keepers
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/coinexchain/cet-sdk/modules/oracle/internal/types"
+)
+
+const (
+	QueryTWAP = "twap"
+)
+
+type QueryTWAPParam struct {
+	TradingPair string `json:"trading_pair"`
+	Window      int64  `json:"window"`
+}
+
+func NewQueryTWAPParam(symbol string, window int64) QueryTWAPParam {
+	return QueryTWAPParam{
+		TradingPair: symbol,
+		Window:      window,
+	}
+}
+
+type QueryTWAPResponse struct {
+	TradingPair string  `json:"trading_pair"`
+	Window      int64   `json:"window"`
+	TWAP        sdk.Dec `json:"twap"`
+}
+
+// creates a querier for oracle REST endpoints
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryTWAP:
+			return queryTWAP(ctx, req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("query symbol : " + path[0])
+		}
+	}
+}
+
+func queryTWAP(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var param QueryTWAPParam
+	if err := k.cdc.UnmarshalJSON(req.Data, &param); err != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("failed to parse param: %s", err))
+	}
+	twap, err := k.GetTWAP(ctx, param.TradingPair, param.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := QueryTWAPResponse{TradingPair: param.TradingPair, Window: param.Window, TWAP: twap}
+	res, errRes := codec.MarshalJSONIndent(types.ModuleCdc, resp)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
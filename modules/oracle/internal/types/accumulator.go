@@ -0,0 +1,42 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PriceAccumulator is the state of the price of a trading pair at UpdateTime. Cumulative is the
+// sum of price*seconds since the first recorded price, so the TWAP between two accumulators is
+// the difference of their cumulatives divided by the seconds between them.
+type PriceAccumulator struct {
+	Symbol     string  `json:"symbol"`
+	Price      sdk.Dec `json:"price"`
+	Cumulative sdk.Dec `json:"cumulative"`
+	UpdateTime int64   `json:"update_time"`
+}
+
+// CumulativeAt returns the cumulative at a time no earlier than UpdateTime, assuming
+// the price did not change after UpdateTime
+func (acc PriceAccumulator) CumulativeAt(unixTime int64) sdk.Dec {
+	if unixTime <= acc.UpdateTime {
+		return acc.Cumulative
+	}
+	return acc.Cumulative.Add(acc.Price.MulInt64(unixTime - acc.UpdateTime))
+}
+
+func (acc PriceAccumulator) Validate() error {
+	if len(acc.Symbol) == 0 {
+		return fmt.Errorf("empty symbol in price accumulator")
+	}
+	if acc.Price.IsNil() || !acc.Price.IsPositive() {
+		return fmt.Errorf("invalid price of %s at %d", acc.Symbol, acc.UpdateTime)
+	}
+	if acc.Cumulative.IsNil() || acc.Cumulative.IsNegative() {
+		return fmt.Errorf("invalid cumulative of %s at %d", acc.Symbol, acc.UpdateTime)
+	}
+	if acc.UpdateTime < 0 {
+		return fmt.Errorf("invalid update time %d of %s", acc.UpdateTime, acc.Symbol)
+	}
+	return nil
+}
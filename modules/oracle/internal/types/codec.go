@@ -0,0 +1,16 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+var ModuleCdc = codec.New()
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	ModuleCdc.Seal()
+}
+
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(PriceAccumulator{}, "oracle/PriceAccumulator", nil)
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	CodeSpaceOracle sdk.CodespaceType = "oracle"
+
+	// 1301 ～ 1399
+	CodeInvalidTWAPWindow  sdk.CodeType = 1301
+	CodeNoPriceRecorded    sdk.CodeType = 1302
+	CodeInvalidAccumulator sdk.CodeType = 1303
+)
+
+func ErrInvalidTWAPWindow(window int64) sdk.Error {
+	return sdk.NewError(CodeSpaceOracle, CodeInvalidTWAPWindow, "Invalid TWAP window : %d; The range of expected values (0, %d]", window, MaxTWAPWindow)
+}
+
+func ErrNoPriceRecorded(symbol string) sdk.Error {
+	return sdk.NewError(CodeSpaceOracle, CodeNoPriceRecorded, "No price has been recorded for %s", symbol)
+}
+
+func ErrInvalidAccumulator(s string) sdk.Error {
+	return sdk.NewError(CodeSpaceOracle, CodeInvalidAccumulator, s)
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"fmt"
+)
+
+// GenesisState - all oracle state that must be provided at genesis
+type GenesisState struct {
+	Accumulators []PriceAccumulator `json:"accumulators"`
+}
+
+// NewGenesisState - Create a new genesis state
+func NewGenesisState(accumulators []PriceAccumulator) GenesisState {
+	return GenesisState{
+		Accumulators: accumulators,
+	}
+}
+
+// DefaultGenesisState - Return a default genesis state
+func DefaultGenesisState() GenesisState {
+	return NewGenesisState([]PriceAccumulator{})
+}
+
+// ValidateGenesis performs basic validation of oracle genesis data returning an
+// error for any failed validation criteria.
+func (data GenesisState) ValidateGenesis() error {
+	accKeys := make(map[string]struct{}, len(data.Accumulators))
+	for _, acc := range data.Accumulators {
+		if err := acc.Validate(); err != nil {
+			return ErrInvalidAccumulator(err.Error())
+		}
+		key := fmt.Sprintf("%s@%d", acc.Symbol, acc.UpdateTime)
+		if _, exists := accKeys[key]; exists {
+			return ErrInvalidAccumulator(fmt.Sprintf("duplicate accumulator of %s at %d", acc.Symbol, acc.UpdateTime))
+		}
+		accKeys[key] = struct{}{}
+	}
+	return nil
+}
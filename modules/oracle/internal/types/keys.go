@@ -0,0 +1,12 @@
+package types
+
+const (
+	ModuleName   = "oracle"
+	QuerierRoute = ModuleName
+	RouterKey    = ModuleName
+	StoreKey     = ModuleName
+
+	// The longest window over which a TWAP can be queried, in seconds.
+	// Older accumulators are pruned when new prices are recorded.
+	MaxTWAPWindow = 24 * 60 * 60
+)
@@ -36,6 +36,7 @@ import (
 	"github.com/coinexchain/cet-sdk/modules/distributionx"
 	"github.com/coinexchain/cet-sdk/modules/incentive"
 	"github.com/coinexchain/cet-sdk/modules/market"
+	"github.com/coinexchain/cet-sdk/modules/oracle"
 	"github.com/coinexchain/cet-sdk/modules/stakingx"
 	"github.com/coinexchain/cet-sdk/modules/supplyx"
 	"github.com/coinexchain/cet-sdk/msgqueue"
@@ -80,6 +81,8 @@ type TestApp struct {
 	keyComment   *sdk.KVStoreKey
 	keyAutoSwap  *sdk.KVStoreKey
 
+	keyOracle *sdk.KVStoreKey
+
 	// Manage getting and setting accounts
 	AccountKeeper   auth.AccountKeeper
 	AccountXKeeper  authx.AccountXKeeper
@@ -103,6 +106,7 @@ type TestApp struct {
 	AliasKeeper     alias.Keeper
 	CommentKeeper   comment.Keeper
 	AutoSwapKeeper  *autoswap.Keeper
+	OracleKeeper    oracle.Keeper
 }
 
 func NewTestApp() *TestApp {
@@ -122,6 +126,7 @@ func makeCodec() *codec.Codec {
 		comment.AppModuleBasic{},
 		incentive.AppModuleBasic{},
 		autoswap.AppModuleBasic{},
+		oracle.AppModuleBasic{},
 
 		//modules wraps those of cosmos
 		authx.AppModuleBasic{}, //before `bank` to override `/bank/balances/{address}`
@@ -174,6 +179,8 @@ func newTestApp(Cdc *codec.Codec) *TestApp {
 		keyAlias:     sdk.NewKVStoreKey(alias.StoreKey),
 		keyComment:   sdk.NewKVStoreKey(comment.StoreKey),
 		keyAutoSwap:  sdk.NewKVStoreKey(autoswap.StoreKey),
+
+		keyOracle: sdk.NewKVStoreKey(oracle.StoreKey),
 	}
 }
 
@@ -318,6 +325,8 @@ func (app *TestApp) initKeepers(invCheckPeriod uint) {
 		app.AccountXKeeper,
 		app.MsgQueProducer)
 
+	app.OracleKeeper = oracle.NewKeeper(app.Cdc, app.keyOracle)
+
 	app.MarketKeeper = market.NewBaseKeeper(
 		app.keyMarket,
 		app.TokenKeeper,
@@ -327,6 +336,7 @@ func (app *TestApp) initKeepers(invCheckPeriod uint) {
 		app.ParamsKeeper.Subspace(market.StoreKey),
 		app.AccountKeeper,
 		app.AccountXKeeper,
+		app.OracleKeeper,
 	)
 	// register the staking hooks
 	// NOTE: The StakingKeeper above is passed by reference, so that it can be
@@ -357,6 +367,7 @@ func (app *TestApp) initKeepers(invCheckPeriod uint) {
 		app.ParamsKeeper.Subspace(autoswap.StoreKey),
 		app.BankxKeeper, app.AccountKeeper, app.AccountXKeeper,
 		app.SupplyKeeper, app.AssetKeeper,
+		app.OracleKeeper,
 	)
 }
 
@@ -390,6 +401,7 @@ func (app *TestApp) mountStores() {
 	cms.MountStoreWithDB(app.keyStakingX, sdk.StoreTypeIAVL, db)
 	cms.MountStoreWithDB(app.tkeyStaking, sdk.StoreTypeTransient, db)
 	cms.MountStoreWithDB(app.keyAutoSwap, sdk.StoreTypeIAVL, db)
+	cms.MountStoreWithDB(app.keyOracle, sdk.StoreTypeIAVL, db)
 	_ = cms.LoadLatestVersion()
 	app.Cms = cms
 }